@@ -177,3 +177,42 @@ func NewClefTransactor(clef *external.ExternalSigner, account accounts.Account)
 		Context: context.Background(),
 	}
 }
+
+// NewKeyedPrioritySigner is a utility method to easily create a priority signer
+// from the private key of a priority transactor.
+func NewKeyedPrioritySigner(key *ecdsa.PrivateKey, chainID *big.Int) (PrioritySignerFn, error) {
+	if chainID == nil {
+		return nil, ErrNoChainID
+	}
+	signer := types.LatestSignerForChainID(chainID)
+	return func(tx *types.Transaction) (*types.Transaction, error) {
+		signature, err := crypto.Sign(signer.Hash(tx).Bytes(), key)
+		if err != nil {
+			return nil, err
+		}
+		return tx.WithPrioritySignature(signer, signature)
+	}, nil
+}
+
+// NewKeyStorePrioritySigner is a utility method to easily create a priority signer
+// from the decrypted key of a priority transactor in a keystore.
+func NewKeyStorePrioritySigner(keystore *keystore.KeyStore, account accounts.Account, chainID *big.Int) (PrioritySignerFn, error) {
+	if chainID == nil {
+		return nil, ErrNoChainID
+	}
+	return func(tx *types.Transaction) (*types.Transaction, error) {
+		return keystore.SignPriorityTx(account, tx, chainID)
+	}, nil
+}
+
+// NewWalletPrioritySigner is a utility method to easily create a priority signer
+// from the account of a priority transactor in any wallet supporting priority
+// signatures, such as a keystore or an external signer.
+func NewWalletPrioritySigner(wallet accounts.Wallet, account accounts.Account, chainID *big.Int) (PrioritySignerFn, error) {
+	if chainID == nil {
+		return nil, ErrNoChainID
+	}
+	return func(tx *types.Transaction) (*types.Transaction, error) {
+		return wallet.SignPriorityTx(account, tx, chainID)
+	}, nil
+}
@@ -76,7 +76,35 @@ type SimulatedBackend struct {
 // and uses a simulated blockchain for testing purposes.
 // A simulated backend always uses chainID 1337.
 func NewSimulatedBackendWithDatabase(database ethdb.Database, alloc core.GenesisAlloc, gasLimit uint64) *SimulatedBackend {
-	genesis := core.Genesis{Config: params.AllEthashProtocolChanges, GasLimit: gasLimit, Alloc: alloc}
+	return newSimulatedBackend(database, params.AllEthashProtocolChanges, alloc, gasLimit)
+}
+
+// simulatedPriorityTransactorsAddress is the address the priority transactors
+// contract of a simulated backend is deployed at.
+var simulatedPriorityTransactorsAddress = common.HexToAddress("0x0000000000000000000000000000000000001001")
+
+// NewSimulatedBackendWithPriorityTransactors creates a new binding backend using a
+// simulated blockchain for testing purposes, which accepts priority transactions
+// signed by the given priority transactors.
+// A simulated backend always uses chainID 1337.
+func NewSimulatedBackendWithPriorityTransactors(alloc core.GenesisAlloc, gasLimit uint64, transactors common.PriorityTransactorMap) (*SimulatedBackend, error) {
+	code, err := core.PriorityTransactorsCode(transactors)
+	if err != nil {
+		return nil, err
+	}
+	config := *params.AllEthashProtocolChanges
+	config.PriorityTransactorsContractAddress = simulatedPriorityTransactorsAddress
+
+	genesisAlloc := make(core.GenesisAlloc, len(alloc)+1)
+	for addr, account := range alloc {
+		genesisAlloc[addr] = account
+	}
+	genesisAlloc[simulatedPriorityTransactorsAddress] = core.GenesisAccount{Code: code, Balance: new(big.Int)}
+	return newSimulatedBackend(rawdb.NewMemoryDatabase(), &config, genesisAlloc, gasLimit), nil
+}
+
+func newSimulatedBackend(database ethdb.Database, config *params.ChainConfig, alloc core.GenesisAlloc, gasLimit uint64) *SimulatedBackend {
+	genesis := core.Genesis{Config: config, GasLimit: gasLimit, Alloc: alloc}
 	genesis.MustCommit(database)
 	blockchain, _ := core.NewBlockChain(database, nil, genesis.Config, ethash.NewFaker(), vm.Config{}, nil, nil)
 
@@ -661,6 +689,21 @@ func (b *SimulatedBackend) SendTransaction(ctx context.Context, tx *types.Transa
 	if tx.Nonce() != nonce {
 		return fmt.Errorf("invalid transaction nonce: got %d, want %d", tx.Nonce(), nonce)
 	}
+	if tx.IsPriority() {
+		pubkeys, err := types.PrioritySenders(signer, tx)
+		if err != nil {
+			return fmt.Errorf("invalid transaction: %v", err)
+		}
+		evm := vm.NewEVM(core.NewEVMBlockContext(b.pendingBlock.Header(), b.blockchain, nil), vm.TxContext{}, b.pendingState.Copy(), b.config, vm.Config{})
+		transactors := core.MustGetPriorityTransactors(evm)
+		lookup := func(pubkey common.PublicKey) (common.PriorityTransactor, bool) {
+			transactor, ok := transactors[pubkey]
+			return transactor, ok
+		}
+		if _, err := core.ValidatePrioritySenders(pubkeys, lookup); err != nil {
+			return fmt.Errorf("invalid transaction: %v", err)
+		}
+	}
 	// Include tx in chain
 	blocks, _ := core.GenerateChain(b.config, block, ethash.NewFaker(), b.database, 1, func(number int, block *core.BlockGen) {
 		for _, tx := range b.pendingBlock.Transactions() {
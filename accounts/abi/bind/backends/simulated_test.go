@@ -360,6 +360,50 @@ func TestSendTransaction(t *testing.T) {
 	}
 }
 
+func TestSendPriorityTransaction(t *testing.T) {
+	testAddr := crypto.PubkeyToAddress(testKey.PublicKey)
+	priorityKey, _ := crypto.GenerateKey()
+	transactors := common.PriorityTransactorMap{
+		common.BytesToPublicKey(crypto.FromECDSAPub(&priorityKey.PublicKey)): {IsGasPriceWaiver: true, EntityName: "Test Entity"},
+	}
+	sim, err := NewSimulatedBackendWithPriorityTransactors(core.GenesisAlloc{testAddr: {Balance: big.NewInt(10000000000000000)}}, 10000000, transactors)
+	if err != nil {
+		t.Fatalf("could not create backend: %v", err)
+	}
+	defer sim.Close()
+	bgCtx := context.Background()
+
+	// Send a gas price waived transfer through a bound contract
+	opts, _ := bind.NewKeyedTransactorWithChainID(testKey, big.NewInt(1337))
+	opts.PrioritySigner, _ = bind.NewKeyedPrioritySigner(priorityKey, big.NewInt(1337))
+	opts.GasFeeCap, opts.GasTipCap, opts.GasLimit = new(big.Int), new(big.Int), params.TxGas
+
+	contract := bind.NewBoundContract(common.Address{0x1}, abi.ABI{}, sim, sim, sim)
+	tx, err := contract.Transfer(opts)
+	if err != nil {
+		t.Fatalf("could not send priority tx: %v", err)
+	}
+	sim.Commit()
+
+	receipt, err := sim.TransactionReceipt(bgCtx, tx.Hash())
+	if err != nil {
+		t.Fatalf("could not get receipt: %v", err)
+	}
+	if receipt.Status != types.ReceiptStatusSuccessful {
+		t.Errorf("priority tx failed")
+	}
+	if balance, _ := sim.BalanceAt(bgCtx, testAddr, nil); balance.Cmp(big.NewInt(10000000000000000)) != 0 {
+		t.Errorf("gas price not waived: balance %v", balance)
+	}
+
+	// Priority transactions signed by unknown transactors are rejected
+	otherKey, _ := crypto.GenerateKey()
+	opts.PrioritySigner, _ = bind.NewKeyedPrioritySigner(otherKey, big.NewInt(1337))
+	if _, err := contract.Transfer(opts); err == nil {
+		t.Errorf("priority tx from unknown transactor accepted")
+	}
+}
+
 func TestTransactionByHash(t *testing.T) {
 	testAddr := crypto.PubkeyToAddress(testKey.PublicKey)
 
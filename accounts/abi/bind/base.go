@@ -36,6 +36,10 @@ import (
 // sign the transaction before submission.
 type SignerFn func(common.Address, *types.Transaction) (*types.Transaction, error)
 
+// PrioritySignerFn is a signer function callback adding the priority signature of
+// a priority transactor to a transaction already signed by its sender.
+type PrioritySignerFn func(*types.Transaction) (*types.Transaction, error)
+
 // CallOpts is the collection of options to fine tune a contract call request.
 type CallOpts struct {
 	Pending     bool            // Whether to operate on the pending state or the last known one
@@ -51,6 +55,8 @@ type TransactOpts struct {
 	Nonce  *big.Int       // Nonce to use for the transaction execution (nil = use pending state)
 	Signer SignerFn       // Method to use for signing the transaction (mandatory)
 
+	PrioritySigner PrioritySignerFn // Method to use for the priority signature (nil = no priority transaction)
+
 	Value     *big.Int // Funds to transfer along the transaction (nil = 0 = no funds)
 	GasPrice  *big.Int // Gas price to use for the transaction execution (nil = gas price oracle)
 	GasFeeCap *big.Int // Gas fee cap to use for the 1559 transaction execution (nil = gas price oracle)
@@ -274,6 +280,17 @@ func (c *BoundContract) createDynamicTx(opts *TransactOpts, contract *common.Add
 	if err != nil {
 		return nil, err
 	}
+	if opts.PrioritySigner != nil {
+		return types.NewTx(&types.PriorityTx{
+			To:        contract,
+			Nonce:     nonce,
+			GasFeeCap: gasFeeCap,
+			GasTipCap: gasTipCap,
+			Gas:       gasLimit,
+			Value:     value,
+			Data:      input,
+		}), nil
+	}
 	baseTx := &types.DynamicFeeTx{
 		To:        contract,
 		Nonce:     nonce,
@@ -290,6 +307,9 @@ func (c *BoundContract) createLegacyTx(opts *TransactOpts, contract *common.Addr
 	if opts.GasFeeCap != nil || opts.GasTipCap != nil {
 		return nil, errors.New("maxFeePerGas or maxPriorityFeePerGas specified but london is not active yet")
 	}
+	if opts.PrioritySigner != nil {
+		return nil, errors.New("priority signer specified but london is not active yet")
+	}
 	// Normalize value
 	value := opts.Value
 	if value == nil {
@@ -364,6 +384,9 @@ func (c *BoundContract) transact(opts *TransactOpts, contract *common.Address, i
 	if opts.GasPrice != nil && (opts.GasFeeCap != nil || opts.GasTipCap != nil) {
 		return nil, errors.New("both gasPrice and (maxFeePerGas or maxPriorityFeePerGas) specified")
 	}
+	if opts.GasPrice != nil && opts.PrioritySigner != nil {
+		return nil, errors.New("both gasPrice and priority signer specified")
+	}
 	// Create the transaction
 	var (
 		rawTx *types.Transaction
@@ -393,6 +416,11 @@ func (c *BoundContract) transact(opts *TransactOpts, contract *common.Address, i
 	if err != nil {
 		return nil, err
 	}
+	if opts.PrioritySigner != nil {
+		if signedTx, err = opts.PrioritySigner(signedTx); err != nil {
+			return nil, err
+		}
+	}
 	if opts.NoSend {
 		return signedTx, nil
 	}
@@ -329,6 +329,39 @@ func TestTransactGasFee(t *testing.T) {
 	assert.True(mt.suggestGasPriceCalled)
 }
 
+func TestTransactPriority(t *testing.T) {
+	assert := assert.New(t)
+
+	key, _ := crypto.GenerateKey()
+	priorityKey, _ := crypto.GenerateKey()
+	chainID := big.NewInt(1337)
+
+	opts, err := bind.NewKeyedTransactorWithChainID(key, chainID)
+	assert.Nil(err)
+	opts.PrioritySigner, err = bind.NewKeyedPrioritySigner(priorityKey, chainID)
+	assert.Nil(err)
+
+	mt := &mockTransactor{baseFee: big.NewInt(100), gasTipCap: big.NewInt(5)}
+	bc := bind.NewBoundContract(common.Address{}, abi.ABI{}, nil, mt, nil)
+	tx, err := bc.Transact(opts, "")
+	assert.Nil(err)
+	assert.Equal(uint8(types.PriorityTxType), tx.Type())
+
+	// Both the sender and the priority signatures must check out
+	signer := types.LatestSignerForChainID(chainID)
+	from, err := types.Sender(signer, tx)
+	assert.Nil(err)
+	assert.Equal(opts.From, from)
+	pubkey, err := types.PrioritySender(signer, tx)
+	assert.Nil(err)
+	assert.Equal(common.BytesToPublicKey(crypto.FromECDSAPub(&priorityKey.PublicKey)), pubkey)
+
+	// Priority transactions can't be sent with a legacy gas price
+	opts.GasPrice = big.NewInt(1)
+	_, err = bc.Transact(opts, "")
+	assert.NotNil(err)
+}
+
 func unpackAndCheck(t *testing.T, bc *bind.BoundContract, expected map[string]interface{}, mockLog types.Log) {
 	received := make(map[string]interface{})
 	if err := bc.UnpackLogIntoMap(received, "received", mockLog); err != nil {
@@ -151,6 +151,16 @@ type Wallet interface {
 
 	// SignTxWithPassphrase is identical to SignTx, but also takes a password
 	SignTxWithPassphrase(account Account, passphrase string, tx *types.Transaction, chainID *big.Int) (*types.Transaction, error)
+
+	// SignPriorityTx requests the wallet to co-sign the given priority transaction
+	// with the key of a priority transactor, leaving the sender signature as is.
+	//
+	// The account is looked up and authenticated the same way as for SignTx. If the
+	// wallet cannot produce priority signatures, ErrNotSupported is returned.
+	SignPriorityTx(account Account, tx *types.Transaction, chainID *big.Int) (*types.Transaction, error)
+
+	// SignPriorityTxWithPassphrase is identical to SignPriorityTx, but also takes a password
+	SignPriorityTxWithPassphrase(account Account, passphrase string, tx *types.Transaction, chainID *big.Int) (*types.Transaction, error)
 }
 
 // Backend is a "wallet provider" that may contain a batch of accounts they can
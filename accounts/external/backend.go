@@ -217,7 +217,7 @@ func (api *ExternalSigner) SignTx(account accounts.Account, tx *types.Transactio
 	case types.DynamicFeeTxType:
 		args.MaxFeePerGas = (*hexutil.Big)(tx.GasFeeCap())
 		args.MaxPriorityFeePerGas = (*hexutil.Big)(tx.GasTipCap())
-	case types.PriorityTxType:
+	case types.PriorityTxType, types.MultiPriorityTxType:
 		args.MaxFeePerGas = (*hexutil.Big)(tx.GasFeeCap())
 		args.MaxPriorityFeePerGas = (*hexutil.Big)(tx.GasTipCap())
 	default:
@@ -244,6 +244,28 @@ func (api *ExternalSigner) SignTx(account accounts.Account, tx *types.Transactio
 	return res.Tx, nil
 }
 
+// SignPriorityTx sends the priority transaction to the external signer, to be
+// co-signed with the given priority transactor account. The transaction is sent
+// in its binary form so the signer co-signs exactly what the sender signed.
+func (api *ExternalSigner) SignPriorityTx(account accounts.Account, tx *types.Transaction, chainID *big.Int) (*types.Transaction, error) {
+	if !tx.IsPriority() {
+		return nil, fmt.Errorf("unsupported tx type %d", tx.Type())
+	}
+	raw, err := tx.MarshalBinary()
+	if err != nil {
+		return nil, err
+	}
+	var res signTransactionResult
+	if err := api.client.Call(&res, "account_signPriorityTransaction", common.NewMixedcaseAddress(account.Address), hexutil.Bytes(raw)); err != nil {
+		return nil, err
+	}
+	return res.Tx, nil
+}
+
+func (api *ExternalSigner) SignPriorityTxWithPassphrase(account accounts.Account, passphrase string, tx *types.Transaction, chainID *big.Int) (*types.Transaction, error) {
+	return nil, fmt.Errorf("password-operations not supported on external signers")
+}
+
 func (api *ExternalSigner) SignTextWithPassphrase(account accounts.Account, passphrase string, text []byte) ([]byte, error) {
 	return []byte{}, fmt.Errorf("password-operations not supported on external signers")
 }
@@ -313,6 +313,46 @@ func (ks *KeyStore) SignTxWithPassphrase(a accounts.Account, passphrase string,
 	return types.SignTx(tx, signer, key.PrivateKey)
 }
 
+// SignPriorityTx adds the priority signature of the requested account to the
+// given priority transaction.
+func (ks *KeyStore) SignPriorityTx(a accounts.Account, tx *types.Transaction, chainID *big.Int) (*types.Transaction, error) {
+	// Look up the key to sign with and abort if it cannot be found
+	ks.mu.RLock()
+	defer ks.mu.RUnlock()
+
+	unlockedKey, found := ks.unlocked[a.Address]
+	if !found {
+		return nil, ErrLocked
+	}
+	return signPriorityTx(tx, chainID, unlockedKey.PrivateKey)
+}
+
+// SignPriorityTxWithPassphrase adds the priority signature to the transaction if
+// the private key matching the given address can be decrypted with the given
+// passphrase.
+func (ks *KeyStore) SignPriorityTxWithPassphrase(a accounts.Account, passphrase string, tx *types.Transaction, chainID *big.Int) (*types.Transaction, error) {
+	_, key, err := ks.getDecryptedKey(a, passphrase)
+	if err != nil {
+		return nil, err
+	}
+	defer zeroKey(key.PrivateKey)
+	return signPriorityTx(tx, chainID, key.PrivateKey)
+}
+
+// signPriorityTx signs the hash of a priority transaction, which doesn't cover
+// the sender signature, and sets it as the priority signature.
+func signPriorityTx(tx *types.Transaction, chainID *big.Int, key *ecdsa.PrivateKey) (*types.Transaction, error) {
+	if !tx.IsPriority() {
+		return nil, types.ErrTxTypeNotSupported
+	}
+	signer := types.LatestSignerForChainID(chainID)
+	signature, err := crypto.Sign(signer.Hash(tx).Bytes(), key)
+	if err != nil {
+		return nil, err
+	}
+	return tx.WithPrioritySignature(signer, signature)
+}
+
 // Unlock unlocks the given account indefinitely.
 func (ks *KeyStore) Unlock(a accounts.Account, passphrase string) error {
 	return ks.TimedUnlock(a, passphrase, 0)
@@ -17,6 +17,7 @@
 package keystore
 
 import (
+	"math/big"
 	"math/rand"
 	"os"
 	"runtime"
@@ -29,6 +30,7 @@ import (
 
 	"github.com/electroneum/electroneum-sc/accounts"
 	"github.com/electroneum/electroneum-sc/common"
+	"github.com/electroneum/electroneum-sc/core/types"
 	"github.com/electroneum/electroneum-sc/crypto"
 	"github.com/electroneum/electroneum-sc/event"
 )
@@ -112,6 +114,62 @@ func TestSignWithPassphrase(t *testing.T) {
 	}
 }
 
+func TestSignPriorityTx(t *testing.T) {
+	_, ks := tmpKeyStore(t, true)
+
+	pass := "passwd"
+	acc, err := ks.NewAccount(pass)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, key, err := ks.getDecryptedKey(acc, pass)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sender, _ := crypto.GenerateKey()
+
+	chainID := big.NewInt(1)
+	signer := types.LatestSignerForChainID(chainID)
+	tx, err := types.SignNewTx(sender, signer, &types.PriorityTx{
+		ChainID:   chainID,
+		Gas:       21000,
+		GasFeeCap: big.NewInt(1),
+		GasTipCap: big.NewInt(1),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := ks.SignPriorityTx(acc, tx, chainID); err != ErrLocked {
+		t.Fatalf("expected locked account error, have %v", err)
+	}
+	if _, err := ks.SignPriorityTxWithPassphrase(acc, "invalid passwd", tx, chainID); err == nil {
+		t.Fatal("expected SignPriorityTxWithPassphrase to fail with invalid password")
+	}
+	signed, err := ks.SignPriorityTxWithPassphrase(acc, pass, tx, chainID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pubkey, err := types.PrioritySender(signer, signed)
+	if err != nil {
+		t.Fatalf("failed to recover priority sender: %v", err)
+	}
+	if want := crypto.ECDSAPubkeyToPublicKey(key.PrivateKey.PublicKey); pubkey != want {
+		t.Errorf("priority sender mismatch: have %x, want %x", pubkey, want)
+	}
+	from, err := types.Sender(signer, signed)
+	if err != nil {
+		t.Fatalf("failed to recover sender: %v", err)
+	}
+	if want := crypto.PubkeyToAddress(sender.PublicKey); from != want {
+		t.Errorf("sender mismatch: have %x, want %x", from, want)
+	}
+	// Only priority transactions can be co-signed
+	legacy := types.NewTransaction(0, common.Address{}, big.NewInt(0), 21000, big.NewInt(1), nil)
+	if _, err := ks.SignPriorityTxWithPassphrase(acc, pass, legacy, chainID); err != types.ErrTxTypeNotSupported {
+		t.Errorf("expected unsupported tx type error, have %v", err)
+	}
+}
+
 func TestTimedUnlock(t *testing.T) {
 	_, ks := tmpKeyStore(t, true)
 
@@ -148,3 +148,26 @@ func (w *keystoreWallet) SignTxWithPassphrase(account accounts.Account, passphra
 	// Account seems valid, request the keystore to sign
 	return w.keystore.SignTxWithPassphrase(account, passphrase, tx, chainID)
 }
+
+// SignPriorityTx implements accounts.Wallet, attempting to co-sign the given
+// priority transaction with the given account as priority transactor.
+func (w *keystoreWallet) SignPriorityTx(account accounts.Account, tx *types.Transaction, chainID *big.Int) (*types.Transaction, error) {
+	// Make sure the requested account is contained within
+	if !w.Contains(account) {
+		return nil, accounts.ErrUnknownAccount
+	}
+	// Account seems valid, request the keystore to sign
+	return w.keystore.SignPriorityTx(account, tx, chainID)
+}
+
+// SignPriorityTxWithPassphrase implements accounts.Wallet, attempting to co-sign
+// the given priority transaction with the given account using passphrase as
+// extra authentication.
+func (w *keystoreWallet) SignPriorityTxWithPassphrase(account accounts.Account, passphrase string, tx *types.Transaction, chainID *big.Int) (*types.Transaction, error) {
+	// Make sure the requested account is contained within
+	if !w.Contains(account) {
+		return nil, accounts.ErrUnknownAccount
+	}
+	// Account seems valid, request the keystore to sign
+	return w.keystore.SignPriorityTxWithPassphrase(account, passphrase, tx, chainID)
+}
@@ -762,6 +762,32 @@ func (w *Wallet) SignTxWithPassphrase(account accounts.Account, passphrase strin
 	return w.SignTx(account, tx, chainID)
 }
 
+// SignPriorityTx requests the wallet to co-sign the given priority transaction
+// with the given account as priority transactor.
+func (w *Wallet) SignPriorityTx(account accounts.Account, tx *types.Transaction, chainID *big.Int) (*types.Transaction, error) {
+	if !tx.IsPriority() {
+		return nil, types.ErrTxTypeNotSupported
+	}
+	signer := types.LatestSignerForChainID(chainID)
+	hash := signer.Hash(tx)
+	sig, err := w.signHash(account, hash[:])
+	if err != nil {
+		return nil, err
+	}
+	return tx.WithPrioritySignature(signer, sig)
+}
+
+// SignPriorityTxWithPassphrase requests the wallet to co-sign the given priority
+// transaction, with the given passphrase as extra authentication information.
+func (w *Wallet) SignPriorityTxWithPassphrase(account accounts.Account, passphrase string, tx *types.Transaction, chainID *big.Int) (*types.Transaction, error) {
+	if !w.session.verified {
+		if err := w.Open(passphrase); err != nil {
+			return nil, err
+		}
+	}
+	return w.SignPriorityTx(account, tx, chainID)
+}
+
 // findAccountPath returns the derivation path for the provided account.
 // It first checks for the address in the list of pinned accounts, and if it is
 // not found, attempts to parse the derivation path from the account's URL.
@@ -29,6 +29,7 @@ import (
 	"github.com/electroneum/electroneum-sc/cmd/utils"
 	"github.com/electroneum/electroneum-sc/common"
 	"github.com/electroneum/electroneum-sc/common/hexutil"
+	istanbulBackend "github.com/electroneum/electroneum-sc/consensus/istanbul/backend"
 	"github.com/electroneum/electroneum-sc/core"
 	"github.com/electroneum/electroneum-sc/core/rawdb"
 	"github.com/electroneum/electroneum-sc/core/state"
@@ -47,7 +48,7 @@ var (
 		Name:      "init",
 		Usage:     "Bootstrap and initialize a new genesis block",
 		ArgsUsage: "<genesisPath>",
-		Flags:     utils.DatabasePathFlags,
+		Flags:     append([]cli.Flag{utils.StateSchemeFlag}, utils.DatabasePathFlags...),
 		Category:  "BLOCKCHAIN COMMANDS",
 		Description: `
 The init command initializes a new genesis block and definition for the network.
@@ -168,6 +169,21 @@ It's deprecated, please use "geth db export" instead.
 This command dumps out the state for a given block (or latest, if none provided).
 `,
 	}
+	validatorHistoryCommand = cli.Command{
+		Action:    utils.MigrateFlags(validatorHistory),
+		Name:      "validator-history",
+		Usage:     "List the blocks that changed the IBFT validator set",
+		ArgsUsage: "[<blockNumFirst> <blockNumLast>]",
+		Flags: append([]cli.Flag{
+			utils.CacheFlag,
+		}, utils.DatabasePathFlags...),
+		Category: "BLOCKCHAIN COMMANDS",
+		Description: `
+This command replays the IBFT validator snapshots between the given blocks
+(or the whole chain, if none provided) and prints every block where the
+validator set changed as JSON, with the validators added and removed and the
+vote or transition that caused the change.`,
+	}
 )
 
 // initGenesis will initialise the given JSON format genesis file and writes it as
@@ -204,6 +220,11 @@ func initGenesis(ctx *cli.Context) error {
 		if err != nil {
 			utils.Fatalf("Failed to open database: %v", err)
 		}
+		// The light client retrieves the state on demand and always keeps it
+		// hash-based
+		if name == "chaindata" {
+			utils.SetupStateScheme(ctx, chaindb)
+		}
 		_, hash, err := core.SetupGenesisBlock(chaindb, genesis)
 		if err != nil {
 			utils.Fatalf("Failed to write genesis block: %v", err)
@@ -278,7 +299,7 @@ func importChain(ctx *cli.Context) error {
 	fmt.Printf("Import done in %v.\n\n", time.Since(start))
 
 	// Output pre-compaction stats mostly to see the import trashing
-	showLeveldbStats(db)
+	showDBStats(db)
 
 	// Print the memory statistics used by the importing
 	mem := new(runtime.MemStats)
@@ -301,7 +322,7 @@ func importChain(ctx *cli.Context) error {
 	}
 	fmt.Printf("Compaction done in %v.\n\n", time.Since(start))
 
-	showLeveldbStats(db)
+	showDBStats(db)
 	return importErr
 }
 
@@ -463,6 +484,44 @@ func dump(ctx *cli.Context) error {
 	return nil
 }
 
+func validatorHistory(ctx *cli.Context) error {
+	stack, _ := makeConfigNode(ctx)
+	defer stack.Close()
+
+	chain, _ := utils.MakeChain(ctx, stack)
+	defer chain.Stop()
+
+	engine, ok := chain.Engine().(*istanbulBackend.Backend)
+	if !ok {
+		utils.Fatalf("This command requires an IBFT chain")
+	}
+	first, last := uint64(0), chain.CurrentBlock().NumberU64()
+	if len(ctx.Args()) > 0 {
+		if len(ctx.Args()) < 2 {
+			utils.Fatalf("This command requires both the first and last block numbers")
+		}
+		var ferr, lerr error
+		first, ferr = strconv.ParseUint(ctx.Args().Get(0), 10, 64)
+		last, lerr = strconv.ParseUint(ctx.Args().Get(1), 10, 64)
+		if ferr != nil || lerr != nil {
+			utils.Fatalf("Error in parsing parameters: block number not an integer")
+		}
+		if head := chain.CurrentBlock().NumberU64(); last > head {
+			utils.Fatalf("Error: block number %d larger than head block %d", last, head)
+		}
+	}
+	changes, err := engine.ValidatorHistory(chain, first, last)
+	if err != nil {
+		utils.Fatalf("Failed to list validator changes: %v", err)
+	}
+	out, err := json.MarshalIndent(changes, "", "  ")
+	if err != nil {
+		return err
+	}
+	fmt.Println(string(out))
+	return nil
+}
+
 // hashish returns true for strings that look like hashes.
 func hashish(x string) bool {
 	_, err := strconv.Atoi(x)
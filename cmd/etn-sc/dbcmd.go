@@ -73,6 +73,8 @@ Remove blockchain and state databases`,
 			dbMetadataCmd,
 			dbMigrateFreezerCmd,
 			dbCheckStateContentCmd,
+			dbBackupCmd,
+			dbRestoreCmd,
 		},
 	}
 	dbInspectCmd = cli.Command{
@@ -98,7 +100,7 @@ a data corruption.`,
 	dbStatCmd = cli.Command{
 		Action: utils.MigrateFlags(dbStats),
 		Name:   "stats",
-		Usage:  "Print leveldb statistics",
+		Usage:  "Print database statistics",
 		Flags: utils.GroupFlags([]cli.Flag{
 			utils.SyncModeFlag,
 		}, utils.NetworkFlags, utils.DatabasePathFlags),
@@ -106,7 +108,7 @@ a data corruption.`,
 	dbCompactCmd = cli.Command{
 		Action: utils.MigrateFlags(dbCompact),
 		Name:   "compact",
-		Usage:  "Compact leveldb database. WARNING: May take a very long time",
+		Usage:  "Compact database. WARNING: May take a very long time",
 		Flags: utils.GroupFlags([]cli.Flag{
 			utils.SyncModeFlag,
 			utils.CacheFlag,
@@ -208,6 +210,30 @@ WARNING: This is a low-level operation which may cause database corruption!`,
 		Description: `The freezer-migrate command checks your database for receipts in a legacy format and updates those.
 WARNING: please back-up the receipt files in your ancients before running this command.`,
 	}
+	dbBackupCmd = cli.Command{
+		Action:    utils.MigrateFlags(dbBackup),
+		Name:      "backup",
+		Usage:     "Backs up the chain database, including the ancients, into a directory",
+		ArgsUsage: "<backup directory>",
+		Flags: utils.GroupFlags([]cli.Flag{
+			utils.SyncModeFlag,
+		}, utils.NetworkFlags, utils.DatabasePathFlags),
+		Description: `The backup command copies the key-value store and the ancients of the chain
+database into a new directory, along with a manifest of checksums to verify the
+backup with before restoring it. Use admin.backup to back up a running node in
+the background, the manifest path it returns exists once the backup completed.`,
+	}
+	dbRestoreCmd = cli.Command{
+		Action:    utils.MigrateFlags(dbRestore),
+		Name:      "restore",
+		Usage:     "Restores the chain database from a backup directory",
+		ArgsUsage: "<backup directory>",
+		Flags: utils.GroupFlags([]cli.Flag{
+			utils.SyncModeFlag,
+		}, utils.NetworkFlags, utils.DatabasePathFlags),
+		Description: `The restore command verifies the checksums of a backup and writes it into the
+chain database, which must not exist yet.`,
+	}
 )
 
 func removeDB(ctx *cli.Context) error {
@@ -355,7 +381,7 @@ func checkStateContent(ctx *cli.Context) error {
 	return nil
 }
 
-func showLeveldbStats(db ethdb.KeyValueStater) {
+func showDBStats(db ethdb.KeyValueStater) {
 	if stats, err := db.Stat("leveldb.stats"); err != nil {
 		log.Warn("Failed to read database stats", "error", err)
 	} else {
@@ -375,7 +401,7 @@ func dbStats(ctx *cli.Context) error {
 	db := utils.MakeChainDatabase(ctx, stack, true)
 	defer db.Close()
 
-	showLeveldbStats(db)
+	showDBStats(db)
 	return nil
 }
 
@@ -387,7 +413,7 @@ func dbCompact(ctx *cli.Context) error {
 	defer db.Close()
 
 	log.Info("Stats before compaction")
-	showLeveldbStats(db)
+	showDBStats(db)
 
 	log.Info("Triggering compaction")
 	if err := db.Compact(nil, nil); err != nil {
@@ -395,7 +421,7 @@ func dbCompact(ctx *cli.Context) error {
 		return err
 	}
 	log.Info("Stats after compaction")
-	showLeveldbStats(db)
+	showDBStats(db)
 	return nil
 }
 
@@ -519,7 +545,7 @@ func dbDumpTrie(ctx *cli.Context) error {
 			return err
 		}
 	}
-	theTrie, err := trie.New(stRoot, trie.NewDatabase(db))
+	theTrie, err := trie.New(stRoot, utils.MakeTrieDatabase(db))
 	if err != nil {
 		return err
 	}
@@ -706,6 +732,46 @@ func exportChaindata(ctx *cli.Context) error {
 	return utils.ExportChaindata(ctx.Args().Get(1), kind, exporter(db), stop)
 }
 
+func dbBackup(ctx *cli.Context) error {
+	if ctx.NArg() != 1 {
+		return fmt.Errorf("required arguments: %v", ctx.Command.ArgsUsage)
+	}
+	stack, _ := makeConfigNode(ctx)
+	defer stack.Close()
+
+	db := utils.MakeChainDatabase(ctx, stack, true)
+	defer db.Close()
+
+	_, err := rawdb.Backup(db, ctx.Args().Get(0))
+	return err
+}
+
+func dbRestore(ctx *cli.Context) error {
+	if ctx.NArg() != 1 {
+		return fmt.Errorf("required arguments: %v", ctx.Command.ArgsUsage)
+	}
+	stack, _ := makeConfigNode(ctx)
+	defer stack.Close()
+
+	var (
+		root    = stack.ResolvePath("chaindata")
+		freezer = ctx.GlobalString(utils.AncientFlag.Name)
+	)
+	switch {
+	case freezer == "":
+		freezer = filepath.Join(root, "ancient")
+	case !filepath.IsAbs(freezer):
+		freezer = stack.ResolvePath(freezer)
+	}
+	return rawdb.Restore(ctx.Args().Get(0), rawdb.OpenOptions{
+		Type:              stack.Config().DBEngine,
+		Directory:         root,
+		AncientsDirectory: freezer,
+		Cache:             ctx.GlobalInt(utils.CacheFlag.Name) * ctx.GlobalInt(utils.CacheDatabaseFlag.Name) / 100,
+		Handles:           utils.MakeDatabaseHandles(ctx.GlobalInt(utils.FDLimitFlag.Name)),
+	})
+}
+
 func showMetaData(ctx *cli.Context) error {
 	stack, _ := makeConfigNode(ctx)
 	defer stack.Close()
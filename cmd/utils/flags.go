@@ -67,6 +67,7 @@ import (
 	"github.com/electroneum/electroneum-sc/p2p/nat"
 	"github.com/electroneum/electroneum-sc/p2p/netutil"
 	"github.com/electroneum/electroneum-sc/params"
+	"github.com/electroneum/electroneum-sc/trie"
 	pcsclite "github.com/gballet/go-libpcsclite"
 	gopsutil "github.com/shirou/gopsutil/mem"
 	"gopkg.in/urfave/cli.v1"
@@ -122,6 +123,10 @@ var (
 		Name:  "datadir.ancient",
 		Usage: "Data directory for ancient chain segments (default = inside chaindata)",
 	}
+	DBEngineFlag = cli.StringFlag{
+		Name:  "db.engine",
+		Usage: "Backing database implementation to use ('leveldb' or 'pebble', default = engine of the existing database or leveldb)",
+	}
 	MinFreeDiskSpaceFlag = DirectoryFlag{
 		Name:  "datadir.minfreedisk",
 		Usage: "Minimum free disk space in MB, once reached triggers auto shut down (default = --cache.gc converted to MB, 0 = disabled)",
@@ -158,7 +163,7 @@ var (
 	}
 	DeveloperFlag = cli.BoolFlag{
 		Name:  "dev",
-		Usage: "Ephemeral proof-of-authority network with a pre-funded developer account, mining enabled",
+		Usage: "Ephemeral single-validator QBFT network with a pre-funded developer account, mining enabled",
 	}
 	DeveloperPeriodFlag = cli.IntFlag{
 		Name:  "dev.period",
@@ -219,6 +224,10 @@ var (
 		Usage: `Blockchain garbage collection mode ("full", "archive")`,
 		Value: "full",
 	}
+	StateSchemeFlag = cli.StringFlag{
+		Name:  "state.scheme",
+		Usage: `Scheme to use for storing state ("hash" or "path", default = scheme of the existing database or "hash")`,
+	}
 	SnapshotFlag = cli.BoolTFlag{
 		Name:  "snapshot",
 		Usage: `Enables snapshot-database mode (default = enable)`,
@@ -228,6 +237,11 @@ var (
 		Usage: "Number of recent blocks to maintain transactions index for (default = about one year, 0 = entire chain)",
 		Value: ethconfig.Defaults.TxLookupLimit,
 	}
+	HistoryExpiryFlag = cli.Uint64Flag{
+		Name:  "history.expiry",
+		Usage: "Number of recent blocks to keep bodies and receipts of, older ones are dropped from the ancients (default = 0, entire chain)",
+		Value: ethconfig.Defaults.HistoryExpiry,
+	}
 	LightKDFFlag = cli.BoolFlag{
 		Name:  "lightkdf",
 		Usage: "Reduce key-derivation RAM & CPU usage at some expense of KDF strength",
@@ -496,6 +510,15 @@ var (
 		Name:  "miner.PrioritiseElectroneum",
 		Usage: "Prioritise Electroneum Ltd Transactions when filling blocks",
 	}
+	MinerPriorityFairFlag = cli.BoolFlag{
+		Name:  "miner.priorityfair",
+		Usage: "Order priority transactions in turns across transactor entities, first in first out for zero-fee ones (overrides --miner.PrioritiseElectroneum)",
+	}
+	MinerPriorityReserveFlag = cli.Uint64Flag{
+		Name:  "miner.priorityreserve",
+		Usage: "Percentage of the block gas limit reserved for fairly ordered priority transactions",
+		Value: ethconfig.Defaults.Miner.PriorityGasReserve,
+	}
 	// Account settings
 	UnlockedAccountFlag = cli.StringFlag{
 		Name:  "unlock",
@@ -713,6 +736,14 @@ var (
 		Name:  "netrestrict",
 		Usage: "Restricts network communication to the given IP networks (CIDR masks)",
 	}
+	PermissionedNodesFlag = cli.StringFlag{
+		Name:  "permissions.nodes",
+		Usage: "Allowlist file (JSON list of enode URLs) of the nodes allowed to connect, reloaded on change",
+	}
+	PermissionsAuditLogFlag = cli.StringFlag{
+		Name:  "permissions.auditlog",
+		Usage: "File to append the node permission decisions to, as JSON lines",
+	}
 	DNSDiscoveryFlag = cli.StringFlag{
 		Name:  "discovery.dns",
 		Usage: "Sets DNS discovery entry points (use \"\" to disable DNS)",
@@ -845,6 +876,7 @@ var (
 		DataDirFlag,
 		AncientFlag,
 		RemoteDBFlag,
+		DBEngineFlag,
 	}
 )
 
@@ -1301,6 +1333,16 @@ func SetNodeConfig(ctx *cli.Context, cfg *node.Config) {
 	if ctx.GlobalIsSet(JWTSecretFlag.Name) {
 		cfg.JWTSecret = ctx.GlobalString(JWTSecretFlag.Name)
 	}
+	if ctx.GlobalIsSet(DBEngineFlag.Name) {
+		dbEngine := ctx.GlobalString(DBEngineFlag.Name)
+		if dbEngine != "leveldb" && dbEngine != "pebble" {
+			Fatalf("Invalid choice for db.engine '%s', allowed 'leveldb' or 'pebble'", dbEngine)
+		}
+		if dbEngine == "pebble" && !rawdb.PebbleEnabled {
+			Fatalf("Database engine 'pebble' is not supported on this platform")
+		}
+		cfg.DBEngine = dbEngine
+	}
 
 	if ctx.GlobalIsSet(ExternalSignerFlag.Name) {
 		cfg.ExternalSigner = ctx.GlobalString(ExternalSignerFlag.Name)
@@ -1482,6 +1524,15 @@ func setMiner(ctx *cli.Context, cfg *miner.Config) {
 		log.Warn("In using --PrioritiseElectroneum you have decided to Prioritise Electroneum's transactions when mining")
 		cfg.PrioritiseElectroneum = ctx.GlobalBool(MinerPrioritiseElectroneumFlag.Name)
 	}
+	if ctx.GlobalIsSet(MinerPriorityFairFlag.Name) {
+		cfg.PriorityFairOrdering = ctx.GlobalBool(MinerPriorityFairFlag.Name)
+	}
+	if ctx.GlobalIsSet(MinerPriorityReserveFlag.Name) {
+		cfg.PriorityGasReserve = ctx.GlobalUint64(MinerPriorityReserveFlag.Name)
+		if cfg.PriorityGasReserve > 100 {
+			Fatalf("Option %q must be a percentage, at most 100", MinerPriorityReserveFlag.Name)
+		}
+	}
 }
 
 func setRequiredBlocks(ctx *cli.Context, cfg *ethconfig.Config) {
@@ -1512,6 +1563,16 @@ func setRequiredBlocks(ctx *cli.Context, cfg *ethconfig.Config) {
 	}
 }
 
+// setPermissions configures the nodes allowed to connect to a permissioned network.
+func setPermissions(ctx *cli.Context, cfg *ethconfig.Config) {
+	if ctx.GlobalIsSet(PermissionedNodesFlag.Name) {
+		cfg.PermissionedNodes = ctx.GlobalString(PermissionedNodesFlag.Name)
+	}
+	if ctx.GlobalIsSet(PermissionsAuditLogFlag.Name) {
+		cfg.PermissionsAuditLog = ctx.GlobalString(PermissionsAuditLogFlag.Name)
+	}
+}
+
 // CheckExclusive verifies that only a single instance of the provided flags was
 // set by the user. Each flag might optionally be followed by a string type to
 // specialize it further.
@@ -1576,6 +1637,7 @@ func SetEthConfig(ctx *cli.Context, stack *node.Node, cfg *ethconfig.Config) {
 	setEthash(ctx, cfg)
 	setMiner(ctx, &cfg.Miner)
 	setRequiredBlocks(ctx, cfg)
+	setPermissions(ctx, cfg)
 	setLes(ctx, cfg)
 	// Cap the cache allowance and tune the garbage collector
 	mem, err := gopsutil.VirtualMemory()
@@ -1617,6 +1679,26 @@ func SetEthConfig(ctx *cli.Context, stack *node.Node, cfg *ethconfig.Config) {
 	if ctx.GlobalIsSet(GCModeFlag.Name) {
 		cfg.NoPruning = ctx.GlobalString(GCModeFlag.Name) == "archive"
 	}
+	if ctx.GlobalIsSet(StateSchemeFlag.Name) {
+		cfg.StateScheme = ctx.GlobalString(StateSchemeFlag.Name)
+		if cfg.StateScheme != rawdb.HashScheme && cfg.StateScheme != rawdb.PathScheme {
+			Fatalf("--%s must be either '%s' or '%s'", StateSchemeFlag.Name, rawdb.HashScheme, rawdb.PathScheme)
+		}
+	}
+	if cfg.StateScheme == rawdb.PathScheme {
+		// The path scheme only keeps the recent states and can't be filled by
+		// snap sync, which delivers the trie nodes by hash
+		if cfg.NoPruning {
+			Fatalf("Archive mode is not supported by the '%s' state scheme", rawdb.PathScheme)
+		}
+		if cfg.SyncMode != downloader.FullSync {
+			if ctx.GlobalIsSet(SyncModeFlag.Name) {
+				Fatalf("Sync mode %q is not supported by the '%s' state scheme", cfg.SyncMode, rawdb.PathScheme)
+			}
+			log.Info("Switching to full sync since the path-based state scheme is used")
+			cfg.SyncMode = downloader.FullSync
+		}
+	}
 	if ctx.GlobalIsSet(CacheNoPrefetchFlag.Name) {
 		cfg.NoPrefetch = ctx.GlobalBool(CacheNoPrefetchFlag.Name)
 	}
@@ -1629,6 +1711,22 @@ func SetEthConfig(ctx *cli.Context, stack *node.Node, cfg *ethconfig.Config) {
 	if ctx.GlobalIsSet(TxLookupLimitFlag.Name) {
 		cfg.TxLookupLimit = ctx.GlobalUint64(TxLookupLimitFlag.Name)
 	}
+	if ctx.GlobalIsSet(HistoryExpiryFlag.Name) {
+		cfg.HistoryExpiry = ctx.GlobalUint64(HistoryExpiryFlag.Name)
+	}
+	if cfg.HistoryExpiry != 0 {
+		if cfg.NoPruning {
+			Fatalf("History expiry is not supported by archive nodes")
+		}
+		// Blocks only expire once their transactions are no longer indexed
+		if cfg.TxLookupLimit == 0 || cfg.TxLookupLimit > cfg.HistoryExpiry {
+			log.Warn("Limiting transaction index to the history expiry", "txlookuplimit", cfg.HistoryExpiry)
+			cfg.TxLookupLimit = cfg.HistoryExpiry
+		}
+		if ctx.GlobalIsSet(LightServeFlag.Name) {
+			log.Warn("LES server cannot serve the bodies and receipts of expired blocks")
+		}
+	}
 	if ctx.GlobalIsSet(CacheFlag.Name) || ctx.GlobalIsSet(CacheTrieFlag.Name) {
 		cfg.TrieCleanCache = ctx.GlobalInt(CacheFlag.Name) * ctx.GlobalInt(CacheTrieFlag.Name) / 100
 	}
@@ -1738,8 +1836,20 @@ func SetEthConfig(ctx *cli.Context, stack *node.Node, cfg *ethconfig.Config) {
 		}
 		log.Info("Using developer account", "address", developer.Address)
 
+		// The node key seals the developer chain as its single validator, and the
+		// developer account co-signs priority transactions
+		validator := crypto.PubkeyToAddress(stack.GetNodeKey().PublicKey)
+		log.Info("Using developer validator", "address", validator)
+
+		priority, err := developerPriorityKey(ks, developer)
+		if err != nil {
+			Fatalf("Failed to get developer priority key: %v", err)
+		}
+		transactors := common.PriorityTransactorMap{
+			priority: {IsGasPriceWaiver: true, EntityName: "developer"},
+		}
 		// Create a new developer genesis block or reuse existing one
-		cfg.Genesis = core.DeveloperGenesisBlock(uint64(ctx.GlobalInt(DeveloperPeriodFlag.Name)), ctx.GlobalUint64(DeveloperGasLimitFlag.Name), developer.Address)
+		cfg.Genesis = core.DeveloperGenesisBlock(uint64(ctx.GlobalInt(DeveloperPeriodFlag.Name)), ctx.GlobalUint64(DeveloperGasLimitFlag.Name), developer.Address, validator, transactors)
 		if ctx.GlobalIsSet(DataDirFlag.Name) {
 			// If datadir doesn't exist we need to open db in write-mode
 			// so leveldb can create files.
@@ -1765,6 +1875,21 @@ func SetEthConfig(ctx *cli.Context, stack *node.Node, cfg *ethconfig.Config) {
 	}
 }
 
+// developerPriorityKey returns the public key of the unlocked developer account,
+// recovered from a signature as the keystore doesn't expose it.
+func developerPriorityKey(ks *keystore.KeyStore, developer accounts.Account) (common.PublicKey, error) {
+	hash := crypto.Keccak256([]byte("developer priority key"))
+	sig, err := ks.SignHash(developer, hash)
+	if err != nil {
+		return common.PublicKey{}, err
+	}
+	pubkey, err := crypto.SigToPub(hash, sig)
+	if err != nil {
+		return common.PublicKey{}, err
+	}
+	return crypto.ECDSAPubkeyToPublicKey(*pubkey), nil
+}
+
 // SetDNSDiscoveryDefaults configures DNS discovery with the given URL if
 // no URLs are set.
 func SetDNSDiscoveryDefaults(cfg *ethconfig.Config, genesis common.Hash) {
@@ -1932,6 +2057,26 @@ func MakeChainDatabase(ctx *cli.Context, stack *node.Node, readonly bool) ethdb.
 	return chainDb
 }
 
+// MakeTrieDatabase opens a trie database on top of the given chain database,
+// using the state scheme the database was initialized with.
+func MakeTrieDatabase(chaindb ethdb.Database) *trie.Database {
+	return trie.NewDatabaseWithConfig(chaindb, &trie.Config{
+		Preimages: true,
+		Scheme:    rawdb.ReadStateScheme(chaindb),
+	})
+}
+
+// SetupStateScheme resolves the state scheme from the flag and the existing
+// database content, and records it in the database.
+func SetupStateScheme(ctx *cli.Context, chaindb ethdb.Database) string {
+	scheme, err := rawdb.ParseStateScheme(ctx.GlobalString(StateSchemeFlag.Name), chaindb)
+	if err != nil {
+		Fatalf("%v", err)
+	}
+	rawdb.WriteStateScheme(chaindb, scheme)
+	return scheme
+}
+
 func MakeGenesis(ctx *cli.Context) *core.Genesis {
 	var genesis *core.Genesis
 	switch {
@@ -1951,6 +2096,7 @@ func MakeGenesis(ctx *cli.Context) *core.Genesis {
 func MakeChain(ctx *cli.Context, stack *node.Node) (chain *core.BlockChain, chainDb ethdb.Database) {
 	var err error
 	chainDb = MakeChainDatabase(ctx, stack, false) // TODO(rjl493456442) support read-only database
+	SetupStateScheme(ctx, chainDb)
 	config, _, err := core.SetupGenesisBlock(chainDb, MakeGenesis(ctx))
 	if err != nil {
 		Fatalf("%v", err)
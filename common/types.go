@@ -443,6 +443,15 @@ func (ma *MixedcaseAddress) Original() string {
 type PriorityTransactor struct {
 	IsGasPriceWaiver bool
 	EntityName       string
+
+	// Quotas of the transactor, zero meaning unlimited
+	MaxGasPerBlock       uint64 // Maximum gas of the transactor's transactions in a block
+	MaxPendingTxs        uint64 // Maximum number of the transactor's transactions in the pool
+	MaxWaivedGasPerEpoch uint64 // Maximum gas waived for the transactor per consensus epoch
+
+	// Number of keys of the transactor's entity that must co-sign its transactions,
+	// zero or one meaning a single priority signature is enough
+	SignatureThreshold uint64
 }
 
 // PublicKey represents the 65 byte *uncompressed* secp256k1 pubkey used for priority signatures within txes of PriorityTx type
@@ -138,6 +138,14 @@ type Handler interface {
 	SetBroadcaster(Broadcaster)
 }
 
+// StateVerifier is implemented by the consensus engines whose headers carry fields
+// derived from the state of the block itself.
+type StateVerifier interface {
+	// VerifyState checks the header fields that depend on the given post-state
+	// of the block. It's invoked after the block has been processed.
+	VerifyState(chain ChainHeaderReader, header *types.Header, state *state.StateDB) error
+}
+
 // PoW is a consensus engine based on proof-of-work.
 type PoW interface {
 	Engine
@@ -155,4 +163,7 @@ type Istanbul interface {
 
 	// Stop stops the engine
 	Stop() error
+
+	// Signers retrieves the validators that committed-sealed the given header.
+	Signers(header *types.Header) ([]common.Address, error)
 }
@@ -74,6 +74,16 @@ type Backend interface {
 	// HasBadProposal returns whether the block with the hash is a bad block
 	HasBadProposal(hash common.Hash) bool
 
+	// WriteJournal persists the journal of the messages sent by the core, so
+	// that it survives a restart
+	WriteJournal(data []byte) error
+
+	// ReadJournal retrieves the journal persisted by WriteJournal
+	ReadJournal() ([]byte, error)
+
+	// WriteEvidence persists the evidence of a validator sending conflicting messages
+	WriteEvidence(evidence *Evidence) error
+
 	Close() error
 
 	// StartQBFTConsensus stops existing legacy ibft consensus and starts the new qbft consensus
@@ -18,10 +18,13 @@ package backend
 
 import (
 	"errors"
+	"fmt"
+	"math"
 	"math/big"
 
 	"github.com/electroneum/electroneum-sc/common"
 	"github.com/electroneum/electroneum-sc/consensus"
+	"github.com/electroneum/electroneum-sc/consensus/istanbul"
 	istanbulcommon "github.com/electroneum/electroneum-sc/consensus/istanbul/common"
 	"github.com/electroneum/electroneum-sc/core/types"
 	"github.com/electroneum/electroneum-sc/rpc"
@@ -46,6 +49,12 @@ type Status struct {
 	NumBlocks     uint64                 `json:"numBlocks"`
 }
 
+type LivenessStatus struct {
+	Validators map[common.Address]*ValidatorActivity `json:"validators"`
+	StartBlock uint64                                `json:"startBlock"`
+	EndBlock   uint64                                `json:"endBlock"`
+}
+
 // NodeAddress returns the public address that is used to sign block headers in IBFT
 func (api *API) NodeAddress() common.Address {
 	return api.backend.Address()
@@ -143,6 +152,38 @@ func (api *API) GetValidators(number *rpc.BlockNumber) ([]common.Address, error)
 	return snap.validators(), nil
 }
 
+// maxValidatorHistoryRange is the maximum number of blocks GetValidatorHistory
+// replays in a single call.
+const maxValidatorHistoryRange = 10000
+
+// GetValidatorHistory returns the blocks between the given numbers (inclusive)
+// that changed the validator set, with the validators added and removed and
+// the vote or transition that caused the change. Defaults to the last 10000
+// blocks, larger ranges have to be queried in several calls.
+func (api *API) GetValidatorHistory(startBlockNum *rpc.BlockNumber, endBlockNum *rpc.BlockNumber) ([]*ValidatorChange, error) {
+	current := api.chain.CurrentHeader().Number.Uint64()
+	end := current
+	if endBlockNum != nil && *endBlockNum >= 0 {
+		end = uint64(*endBlockNum)
+	}
+	start := uint64(0)
+	if startBlockNum != nil && *startBlockNum >= 0 {
+		start = uint64(*startBlockNum)
+	} else if end >= maxValidatorHistoryRange {
+		start = end - maxValidatorHistoryRange + 1
+	}
+	if start > end {
+		return nil, errors.New("start block number should be less than end block number")
+	}
+	if end > current {
+		return nil, errors.New("end block number should be less than or equal to current block height")
+	}
+	if end-start >= maxValidatorHistoryRange {
+		return nil, fmt.Errorf("block range too large, at most %d blocks are allowed", maxValidatorHistoryRange)
+	}
+	return api.backend.ValidatorHistory(api.chain, start, end)
+}
+
 // GetValidatorsAtHash retrieves the state snapshot at a given block.
 func (api *API) GetValidatorsAtHash(hash common.Hash) ([]common.Address, error) {
 	header := api.chain.GetHeaderByHash(hash)
@@ -253,6 +294,102 @@ func (api *API) Status(startBlockNum *rpc.BlockNumber, endBlockNum *rpc.BlockNum
 	}, nil
 }
 
+// GetValidatorLiveness returns the number of blocks proposed and signed, the
+// missed proposer rounds and the last active block of each validator between
+// the given blocks (inclusive). Defaults to the last 64 blocks.
+func (api *API) GetValidatorLiveness(startBlockNum *rpc.BlockNumber, endBlockNum *rpc.BlockNumber) (*LivenessStatus, error) {
+	var start, end uint64
+	if (startBlockNum == nil) != (endBlockNum == nil) {
+		return nil, errors.New("pass both the start and end block numbers")
+	}
+	current := api.chain.CurrentHeader().Number.Uint64()
+	if startBlockNum == nil {
+		end = current
+		if end > 64 {
+			start = end - 63
+		} else {
+			start = 1
+		}
+	} else {
+		if *startBlockNum < 0 || *endBlockNum < 0 {
+			return nil, errors.New("block numbers should be non-negative")
+		}
+		start, end = uint64(*startBlockNum), uint64(*endBlockNum)
+		if start > end {
+			return nil, errors.New("start block number should be less than end block number")
+		}
+		if end > current {
+			return nil, errors.New("end block number should be less than or equal to current block height")
+		}
+		if start == 0 {
+			start = 1
+		}
+	}
+	status := &LivenessStatus{
+		Validators: make(map[common.Address]*ValidatorActivity),
+		StartBlock: start,
+		EndBlock:   end,
+	}
+	if end == 0 {
+		return status, nil
+	}
+	last, err := api.backend.liveness(api.chain, end)
+	if err != nil {
+		return nil, err
+	}
+	first, err := api.backend.liveness(api.chain, start-1)
+	if err != nil {
+		return nil, err
+	}
+	status.Validators = last.since(first)
+
+	// Include the validators that were inactive in the range
+	blockNumber := rpc.BlockNumber(end)
+	validators, err := api.GetValidators(&blockNumber)
+	if err != nil {
+		return nil, err
+	}
+	for _, validator := range validators {
+		if _, ok := status.Validators[validator]; !ok {
+			status.Validators[validator] = new(ValidatorActivity)
+		}
+	}
+	return status, nil
+}
+
+// GetEquivocationEvidence returns the evidence of validators sending conflicting
+// consensus messages for the blocks between the given numbers (inclusive).
+// Defaults to all the evidence collected by the node.
+func (api *API) GetEquivocationEvidence(startBlockNum *rpc.BlockNumber, endBlockNum *rpc.BlockNumber) ([]*istanbul.Evidence, error) {
+	start, end := uint64(0), uint64(math.MaxUint64)
+	if startBlockNum != nil && *startBlockNum > 0 {
+		start = uint64(*startBlockNum)
+	}
+	if endBlockNum != nil && *endBlockNum >= 0 {
+		end = uint64(*endBlockNum)
+	}
+	if start > end {
+		return nil, errors.New("start block number should be less than end block number")
+	}
+	return loadEvidence(api.backend.db, start, end)
+}
+
+// GetConsensusTimeline returns when the consensus messages, quorums and round
+// timeouts were observed for the last count heights (defaults to 10).
+func (api *API) GetConsensusTimeline(count *int) ([]*istanbul.Timeline, error) {
+	n := 10
+	if count != nil {
+		n = *count
+	}
+	api.backend.coreMu.RLock()
+	defer api.backend.coreMu.RUnlock()
+
+	if !api.backend.coreStarted || api.backend.core == nil {
+		return nil, istanbul.ErrStoppedEngine
+	}
+	return api.backend.core.Timelines(n), nil
+}
+
 func (api *API) IsValidator(blockNum *rpc.BlockNumber) (bool, error) {
 	var blockNumber rpc.BlockNumber
 	if blockNum != nil {
@@ -318,3 +455,22 @@ func (api *API) GetTotalEmission(blockNum *rpc.BlockNumber) (*big.Int, error) {
 
 	return api.backend.GetTotalEmission(api.chain, header), nil
 }
+
+// GetSupply returns the ETN minted and the fees burnt in the given block (or the
+// latest one), along with the totals up to and including that block.
+func (api *API) GetSupply(blockNum *rpc.BlockNumber) (*Supply, error) {
+	chain, ok := api.chain.(supplyChain)
+	if !ok {
+		return nil, errors.New("supply accounting is not supported by the chain")
+	}
+	var header *types.Header
+	if blockNum == nil || *blockNum == rpc.LatestBlockNumber {
+		header = api.chain.CurrentHeader()
+	} else {
+		header = api.chain.GetHeaderByNumber(uint64(blockNum.Int64()))
+	}
+	if header == nil {
+		return nil, istanbulcommon.ErrUnknownBlock
+	}
+	return api.backend.supply(chain, header)
+}
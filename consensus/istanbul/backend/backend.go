@@ -42,6 +42,9 @@ import (
 const (
 	// fetcherID is the ID indicates the block is from Istanbul engine
 	fetcherID = "istanbul"
+
+	// dbKeyJournal is the database key of the consensus journal of the core
+	dbKeyJournal = "istanbul-journal"
 )
 
 // New creates an Ethereum backend for Istanbul core engine.
@@ -49,6 +52,7 @@ func New(config istanbul.Config, privateKey *ecdsa.PrivateKey, db ethdb.Database
 	// Allocate the snapshot caches and create the engine
 	recents, _ := lru.NewARC(inmemorySnapshots)
 	recentsEmission, _ := lru.NewARC(inmemoryEmissions)
+	recentsBurnt, _ := lru.NewARC(inmemoryEmissions)
 	recentsBlockSnapshot, _ := lru.NewARC(inmemoryBlockSnapshots)
 	recentMessages, _ := lru.NewARC(inmemoryPeers)
 	knownMessages, _ := lru.NewARC(inmemoryMessages)
@@ -63,6 +67,7 @@ func New(config istanbul.Config, privateKey *ecdsa.PrivateKey, db ethdb.Database
 		commitCh:             make(chan *types.Block, 1),
 		recents:              recents,
 		recentsEmission:      recentsEmission,
+		recentsBurnt:         recentsBurnt,
 		recentsBlockSnapshot: recentsBlockSnapshot,
 		candidates:           make(map[common.Address]bool),
 		coreStarted:          false,
@@ -71,6 +76,8 @@ func New(config istanbul.Config, privateKey *ecdsa.PrivateKey, db ethdb.Database
 	}
 
 	sb.qbftEngine = qbftengine.NewEngine(sb.config, sb.address, sb.Sign)
+	sb.livenessIndexer, sb.livenessBackend = newLivenessIndexer(sb)
+	sb.burntIndexer, sb.burntBackend = newBurntIndexer(sb)
 
 	return sb
 }
@@ -112,8 +119,16 @@ type Backend struct {
 	recents *lru.ARCCache
 	// Emission for recent blocks
 	recentsEmission *lru.ARCCache
+	// Burnt fees for recent blocks
+	recentsBurnt *lru.ARCCache
 	// Block Snapshot for recent blocks
 	recentsBlockSnapshot *lru.ARCCache
+	// Index of the validator liveness, moved forward on chain head events
+	livenessIndexer *core.ChainIndexer
+	livenessBackend *livenessIndexer
+	// Index of the fees burnt, moved forward on chain head events
+	burntIndexer *core.ChainIndexer
+	burntBackend *burntIndexer
 
 	// event subscription for ChainHeadEvent event
 	broadcaster consensus.Broadcaster
@@ -348,8 +363,31 @@ func (sb *Backend) HasBadProposal(hash common.Hash) bool {
 	return sb.hasBadBlock(sb.db, hash)
 }
 
+// WriteJournal implements istanbul.Backend.WriteJournal
+func (sb *Backend) WriteJournal(data []byte) error {
+	return sb.db.Put([]byte(dbKeyJournal), data)
+}
+
+// ReadJournal implements istanbul.Backend.ReadJournal
+func (sb *Backend) ReadJournal() ([]byte, error) {
+	return sb.db.Get([]byte(dbKeyJournal))
+}
+
 func (sb *Backend) Close() error {
-	return nil
+	if err := sb.livenessIndexer.Close(); err != nil {
+		return err
+	}
+	return sb.burntIndexer.Close()
+}
+
+// StartIndexers starts indexing the validator liveness and the fees burnt on the
+// chain as new heads are imported.
+func (sb *Backend) StartIndexers(chain *core.BlockChain) {
+	sb.livenessBackend.chain = chain
+	sb.livenessIndexer.Start(chain)
+
+	sb.burntBackend.chain = chain
+	sb.burntIndexer.Start(chain)
 }
 
 func (sb *Backend) startQBFT() error {
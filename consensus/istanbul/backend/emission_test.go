@@ -21,7 +21,11 @@ import (
 	"testing"
 
 	"github.com/electroneum/electroneum-sc/common"
+	"github.com/electroneum/electroneum-sc/consensus"
+	"github.com/electroneum/electroneum-sc/consensus/istanbul"
 	"github.com/electroneum/electroneum-sc/core/rawdb"
+	"github.com/electroneum/electroneum-sc/core/types"
+	"github.com/electroneum/electroneum-sc/params"
 )
 
 func TestEmissionSaveAndLoad(t *testing.T) {
@@ -47,3 +51,62 @@ func TestEmissionSaveAndLoad(t *testing.T) {
 		t.Errorf("hash mismatch: have %v, want %v", emission1.Hash, emission.Hash)
 	}
 }
+
+// emissionChain is a chain reader only providing the chain config.
+type emissionChain struct {
+	consensus.ChainHeaderReader
+	config *params.ChainConfig
+}
+
+func (c *emissionChain) Config() *params.ChainConfig {
+	return c.config
+}
+
+func TestEmissionScheduleTransitions(t *testing.T) {
+	sb := &Backend{config: &istanbul.Config{
+		BlockReward:         big.NewInt(100),
+		HalvingPeriod:       10,
+		EmissionBlockOffset: big.NewInt(0),
+		Transitions: []params.Transition{
+			{Block: big.NewInt(5), BlockReward: big.NewInt(1000)},
+			{Block: big.NewInt(12), MaxSupply: big.NewInt(6400)},
+		},
+	}}
+	chain := &emissionChain{config: &params.ChainConfig{}}
+
+	headers := make([]*types.Header, 13)
+	for i := range headers {
+		headers[i] = &types.Header{Number: big.NewInt(int64(i))}
+	}
+	genesis := newEmission(0, headers[0].Hash(), big.NewInt(0))
+
+	// 4 blocks of 100, 5 of 1000, 2 of 500 after halving and none past max supply
+	emission, err := sb.emissionApply(chain, genesis, headers[1:])
+	if err != nil {
+		t.Fatalf("failed to apply emission: %v", err)
+	}
+	if want := big.NewInt(6400); emission.CirculatingSupply.Cmp(want) != 0 {
+		t.Errorf("circulating supply mismatch: have %v, want %v", emission.CirculatingSupply, want)
+	}
+	if emission.Number != 12 || emission.Hash != headers[12].Hash() {
+		t.Errorf("emission block mismatch: have %d %x", emission.Number, emission.Hash)
+	}
+
+	// Emission snapshots taken on both sides of the transitions must agree
+	for _, split := range []int{4, 5, 9, 11} {
+		before, err := sb.emissionApply(chain, genesis, headers[1:split+1])
+		if err != nil {
+			t.Fatalf("failed to apply emission up to %d: %v", split, err)
+		}
+		after, err := sb.emissionApply(chain, before, headers[split+1:])
+		if err != nil {
+			t.Fatalf("failed to apply emission from %d: %v", split, err)
+		}
+		if after.CirculatingSupply.Cmp(emission.CirculatingSupply) != 0 {
+			t.Errorf("split %d: circulating supply mismatch: have %v, want %v", split, after.CirculatingSupply, emission.CirculatingSupply)
+		}
+	}
+	if reward := sb.GetBaseBlockReward(chain, headers[12], emission.CirculatingSupply); reward.Sign() != 0 {
+		t.Errorf("reward after max supply mismatch: have %v, want 0", reward)
+	}
+}
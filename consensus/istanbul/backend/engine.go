@@ -20,6 +20,7 @@ import (
 	"fmt"
 	"math/big"
 	"math/rand"
+	"reflect"
 	"time"
 
 	"github.com/electroneum/electroneum-sc/common"
@@ -28,8 +29,10 @@ import (
 	"github.com/electroneum/electroneum-sc/consensus/istanbul"
 	istanbulcommon "github.com/electroneum/electroneum-sc/consensus/istanbul/common"
 	"github.com/electroneum/electroneum-sc/consensus/istanbul/validator"
+	"github.com/electroneum/electroneum-sc/core"
 	"github.com/electroneum/electroneum-sc/core/state"
 	"github.com/electroneum/electroneum-sc/core/types"
+	"github.com/electroneum/electroneum-sc/core/vm"
 	"github.com/electroneum/electroneum-sc/ethdb"
 	"github.com/electroneum/electroneum-sc/log"
 	"github.com/electroneum/electroneum-sc/params"
@@ -45,6 +48,25 @@ const (
 	inmemoryMessages       = 1024
 )
 
+// chainStateReader is the part of the blockchain required to read the validator
+// set from the validator contract.
+type chainStateReader interface {
+	core.ChainContext
+	StateAt(root common.Hash) (*state.StateDB, error)
+}
+
+// headerChainContext provides the chain context of the EVM reading the validator
+// contract, which only resolves headers for the BLOCKHASH opcode.
+type headerChainContext struct {
+	consensus.ChainHeaderReader
+	engine consensus.Engine
+}
+
+// Engine implements core.ChainContext, returning the istanbul engine.
+func (c *headerChainContext) Engine() consensus.Engine {
+	return c.engine
+}
+
 // Author retrieves the Ethereum address of the account that minted the given
 // block, which may be different from the header's coinbase if a consensus
 // engine is based on signatures.
@@ -151,6 +173,11 @@ func (sb *Backend) Prepare(chain consensus.ChainHeaderReader, header *types.Head
 		return err
 	}
 
+	// Votes have no effect while the validators are managed by a contract
+	if sb.config.IsContractValidatorSelection(header.Number) {
+		return nil
+	}
+
 	// get valid candidate list
 	sb.candidatesLock.RLock()
 	var addresses []common.Address
@@ -184,7 +211,9 @@ func (sb *Backend) Prepare(chain consensus.ChainHeaderReader, header *types.Head
 func (sb *Backend) Finalize(chain consensus.ChainHeaderReader, header *types.Header, state *state.StateDB, txs []*types.Transaction, uncles []*types.Header) {
 	if header.Coinbase != (common.Address{}) {
 		blockReward := sb.GetBaseBlockReward(chain, header, nil)
-		state.AddBalance(header.Coinbase, blockReward)
+		for _, share := range sb.blockRewardShares(chain, header, blockReward) {
+			state.AddBalance(share.Address, share.Amount)
+		}
 	}
 	sb.EngineForBlockNumber(header.Number).Finalize(chain, header, state, txs, uncles)
 }
@@ -193,9 +222,51 @@ func (sb *Backend) Finalize(chain consensus.ChainHeaderReader, header *types.Hea
 // nor block rewards given, and returns the final block.
 func (sb *Backend) FinalizeAndAssemble(chain consensus.ChainHeaderReader, header *types.Header, state *state.StateDB, txs []*types.Transaction, uncles []*types.Header, receipts []*types.Receipt) (*types.Block, error) {
 	sb.Finalize(chain, header, state, txs, uncles)
+
+	// Record the contract managed validators of the next block in the header, so
+	// that the following headers can be verified without the state of this one
+	if sb.config.IsContractValidatorSelection(new(big.Int).Add(header.Number, common.Big1)) {
+		validators, weights, err := sb.contractValidators(chain, header, state)
+		if err != nil {
+			return nil, err
+		}
+		if err := sb.EngineForBlockNumber(header.Number).WriteValidators(header, validators, weights); err != nil {
+			return nil, err
+		}
+	}
 	return sb.EngineForBlockNumber(header.Number).FinalizeAndAssemble(chain, header, state, txs, uncles, receipts)
 }
 
+// VerifyState implements consensus.StateVerifier, checking that the contract
+// managed validators recorded in the header match the contract in its state.
+func (sb *Backend) VerifyState(chain consensus.ChainHeaderReader, header *types.Header, state *state.StateDB) error {
+	if !sb.config.IsContractValidatorSelection(new(big.Int).Add(header.Number, common.Big1)) {
+		return nil
+	}
+	validators, weights, err := sb.contractValidators(chain, header, state)
+	if err != nil {
+		return err
+	}
+	engine := sb.EngineForBlockNumber(header.Number)
+	recorded, err := engine.Validators(header)
+	if err != nil {
+		return err
+	}
+	recordedWeights, err := engine.ValidatorWeights(header)
+	if err != nil {
+		return err
+	}
+	if !reflect.DeepEqual(recorded, validators) || len(recordedWeights) != len(weights) {
+		return istanbulcommon.ErrInvalidContractValidators
+	}
+	for i, addr := range validators {
+		if len(weights) > 0 && recordedWeights[addr] != weights[i] {
+			return istanbulcommon.ErrInvalidContractValidators
+		}
+	}
+	return nil
+}
+
 // Seal generates a new block for the given input block with the local miner's
 // seal place on top.
 func (sb *Backend) Seal(chain consensus.ChainHeaderReader, block *types.Block, results chan<- *types.Block, stop <-chan struct{}) error {
@@ -256,13 +327,28 @@ func (sb *Backend) Seal(chain consensus.ChainHeaderReader, block *types.Block, r
 }
 
 func (sb *Backend) GetBaseBlockReward(chain consensus.ChainHeaderReader, header *types.Header, circulatingSupply *big.Int) *big.Int {
-	// original heights * 24 to make them compatible with 5-second block time
-	var legacyV9ForkHeight = new(big.Int).Mul(chain.Config().LegacyV9ForkHeight, big.NewInt(24))
-	var legacyToSmartchainMigrationHeight = new(big.Int).Mul(chain.Config().LegacyToSmartchainMigrationHeight, big.NewInt(24))
+	config := sb.config.GetConfig(header.Number)
+
+	var baseReward = math.MustParseBig256(params.ETNBlockReward)
+	if config.BlockReward != nil {
+		baseReward = new(big.Int).Set(config.BlockReward)
+	}
+	var halvingPeriod = new(big.Int).SetUint64(params.ETNHalvingPeriod)
+	if config.HalvingPeriod != 0 {
+		halvingPeriod = new(big.Int).SetUint64(config.HalvingPeriod)
+	}
+	var maxSupply = math.MustParseBig256(params.ETNMaxSupply)
+	if config.MaxSupply != nil {
+		maxSupply = config.MaxSupply
+	}
+	var offset = config.EmissionBlockOffset
+	if offset == nil {
+		// original heights * 24 to make them compatible with 5-second block time
+		var legacyV9ForkHeight = new(big.Int).Mul(chain.Config().LegacyV9ForkHeight, big.NewInt(24))
+		var legacyToSmartchainMigrationHeight = new(big.Int).Mul(chain.Config().LegacyToSmartchainMigrationHeight, big.NewInt(24))
 
-	var halvingPeriod = big.NewInt(25228800)                                             // 4 years in 5-second block time
-	var baseReward = big.NewInt(4e+18)                                                   // ~100ETN every 120 seconds
-	var offset = new(big.Int).Sub(legacyToSmartchainMigrationHeight, legacyV9ForkHeight) // block height at time of BC migration - legacyV9ForkHeight
+		offset = new(big.Int).Sub(legacyToSmartchainMigrationHeight, legacyV9ForkHeight) // block height at time of BC migration - legacyV9ForkHeight
+	}
 	var offsetBlockNumber = new(big.Int).Add(header.Number, offset)
 	var halvings = new(big.Int).Div(offsetBlockNumber, halvingPeriod) // (blockNumber + offset) / halvingPeriod
 
@@ -277,10 +363,10 @@ func (sb *Backend) GetBaseBlockReward(chain consensus.ChainHeaderReader, header
 	}
 
 	// 0 block reward once circulating supply = max supply & if circsupply+basereward > maxsupply, reduce the base reward accordingly
-	if circulatingSupply.Cmp(math.MustParseBig256(params.ETNMaxSupply)) >= 0 {
+	if circulatingSupply.Cmp(maxSupply) >= 0 {
 		return big.NewInt(0)
-	} else if new(big.Int).Add(circulatingSupply, baseReward).Cmp(math.MustParseBig256(params.ETNMaxSupply)) > 0 {
-		baseReward = new(big.Int).Sub(math.MustParseBig256(params.ETNMaxSupply), circulatingSupply)
+	} else if new(big.Int).Add(circulatingSupply, baseReward).Cmp(maxSupply) > 0 {
+		baseReward = new(big.Int).Sub(maxSupply, circulatingSupply)
 	}
 
 	// Shift the base reward "halvings" times
@@ -525,6 +611,26 @@ func (sb *Backend) snapshot(chain consensus.ChainHeaderReader, number uint64, ha
 			}
 		}
 
+		// If the validators of the next block are managed by a contract, read them
+		// from the state of this block instead of replaying the header votes
+		if sb.config.IsContractValidatorSelection(new(big.Int).SetUint64(number + 1)) {
+			header, err := sb.snapshotHeader(chain, number, hash, parents)
+			if err != nil {
+				return nil, err
+			}
+			if snap, err = sb.contractSnapshot(chain, header); err != nil {
+				sb.logger.Error("IBFT: failed to read validators from contract", "number", number, "hash", hash, "err", err)
+				return nil, err
+			}
+			sb.snapLogger(snap).Trace("IBFT: loaded validators from contract")
+			if number%checkpointInterval == 0 {
+				if err := sb.storeSnap(snap); err != nil {
+					return nil, err
+				}
+			}
+			break
+		}
+
 		// If we're at block zero, make a snapshot
 		if number == 0 {
 			genesis := chain.GetHeaderByNumber(0)
@@ -577,6 +683,11 @@ func (sb *Backend) snapshot(chain consensus.ChainHeaderReader, number uint64, ha
 	if err != nil {
 		return nil, err
 	}
+	// Contract managed validators come with their own weights, otherwise use the
+	// weights configured for the next block
+	if next := new(big.Int).SetUint64(snap.Number + 1); !sb.config.IsContractValidatorSelection(next) {
+		snap.ValSet.SetWeights(sb.config.GetConfig(next).ValidatorWeights)
+	}
 	sb.recents.Add(snap.Hash, snap)
 
 	// If we've generated a new checkpoint snapshot, save to disk
@@ -589,6 +700,125 @@ func (sb *Backend) snapshot(chain consensus.ChainHeaderReader, number uint64, ha
 	return snap, err
 }
 
+// snapshotHeader retrieves the header with the given number and hash, picking it
+// from the explicit parents if available.
+func (sb *Backend) snapshotHeader(chain consensus.ChainHeaderReader, number uint64, hash common.Hash, parents []*types.Header) (*types.Header, error) {
+	for i := len(parents) - 1; i >= 0; i-- {
+		if parents[i].Number.Uint64() == number {
+			if parents[i].Hash() != hash {
+				return nil, consensus.ErrUnknownAncestor
+			}
+			return parents[i], nil
+		}
+	}
+	var header *types.Header
+	if number == 0 {
+		// The genesis is looked up by number, same as for the header votes
+		header = chain.GetHeaderByNumber(0)
+	} else {
+		header = chain.GetHeader(hash, number)
+	}
+	if header == nil {
+		return nil, consensus.ErrUnknownAncestor
+	}
+	return header, nil
+}
+
+// contractSnapshot creates a snapshot of the given header with the contract managed
+// validators of the next block. Sealed blocks record them in their extra-data, so
+// only the validators following the genesis are read from the contract state.
+func (sb *Backend) contractSnapshot(chain consensus.ChainHeaderReader, header *types.Header) (*Snapshot, error) {
+	var (
+		validators []common.Address
+		weights    map[common.Address]uint64
+		err        error
+	)
+	if header.Number.Sign() == 0 {
+		reader, err := sb.stateReader(chain)
+		if err != nil {
+			return nil, err
+		}
+		statedb, err := reader.StateAt(header.Root)
+		if err != nil {
+			return nil, err
+		}
+		validators, weights, err = sb.readContractValidators(chain, header, statedb)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		engine := sb.EngineForBlockNumber(header.Number)
+		if validators, err = engine.Validators(header); err != nil {
+			return nil, err
+		}
+		if len(validators) == 0 {
+			return nil, istanbulcommon.ErrInvalidContractValidators
+		}
+		if weights, err = engine.ValidatorWeights(header); err != nil {
+			return nil, err
+		}
+	}
+	valSet := validator.NewSet(validators, sb.config.ProposerPolicy)
+	if weights != nil {
+		valSet.SetWeights(weights)
+	}
+	return newSnapshot(sb.config.GetConfig(header.Number).Epoch, header.Number.Uint64(), header.Hash(), valSet), nil
+}
+
+// contractValidators reads the validators of the block following the given header
+// from the validator contract in its state, sorted the way they are recorded in
+// the header, together with their proposer weights under the Weighted policy.
+func (sb *Backend) contractValidators(chain consensus.ChainHeaderReader, header *types.Header, statedb *state.StateDB) ([]common.Address, []uint64, error) {
+	validators, weights, err := sb.readContractValidators(chain, header, statedb)
+	if err != nil {
+		return nil, nil, err
+	}
+	validators = validator.SortedAddresses(validator.NewSet(validators, sb.config.ProposerPolicy).List())
+	if weights == nil {
+		return validators, nil, nil
+	}
+	sorted := make([]uint64, len(validators))
+	for i, addr := range validators {
+		sorted[i] = weights[addr]
+	}
+	return validators, sorted, nil
+}
+
+// readContractValidators calls the validator contract of the block following the
+// given header in the given state.
+func (sb *Backend) readContractValidators(chain consensus.ChainHeaderReader, header *types.Header, statedb *state.StateDB) ([]common.Address, map[common.Address]uint64, error) {
+	var (
+		next     = new(big.Int).Add(header.Number, common.Big1)
+		address  = sb.config.GetConfig(next).ValidatorContract
+		blockCtx = core.NewEVMBlockContext(header, &headerChainContext{chain, sb}, nil)
+		evm      = vm.NewEVM(blockCtx, vm.TxContext{}, statedb, chain.Config(), vm.Config{})
+	)
+	validators, err := core.GetContractValidators(evm, address)
+	if err != nil {
+		return nil, nil, err
+	}
+	if sb.config.ProposerPolicy.Id != istanbul.Weighted {
+		return validators, nil, nil
+	}
+	weights, err := core.GetContractValidatorWeights(evm, address, validators)
+	if err != nil {
+		return nil, nil, err
+	}
+	return validators, weights, nil
+}
+
+// stateReader returns a chain able to provide the state required to read the
+// validator contract, falling back to the chain the engine was started with.
+func (sb *Backend) stateReader(chain consensus.ChainHeaderReader) (chainStateReader, error) {
+	if reader, ok := chain.(chainStateReader); ok {
+		return reader, nil
+	}
+	if reader, ok := sb.chain.(chainStateReader); ok {
+		return reader, nil
+	}
+	return nil, istanbulcommon.ErrMissingValidatorState
+}
+
 // SealHash returns the hash of a block prior to it being sealed.
 func (sb *Backend) SealHash(header *types.Header) common.Hash {
 	return sb.EngineForBlockNumber(header.Number).SealHash(header)
@@ -19,6 +19,7 @@ package backend
 import (
 	"bytes"
 	"crypto/ecdsa"
+	"errors"
 	"math/big"
 	"reflect"
 	"testing"
@@ -34,6 +35,7 @@ import (
 	"github.com/electroneum/electroneum-sc/core/types"
 	"github.com/electroneum/electroneum-sc/core/vm"
 	"github.com/electroneum/electroneum-sc/crypto"
+	"github.com/electroneum/electroneum-sc/params"
 )
 
 func newBlockchainFromConfig(genesis *core.Genesis, nodeKeys []*ecdsa.PrivateKey, cfg istanbul.Config) (*core.BlockChain, *Backend) {
@@ -440,3 +442,153 @@ OUT3:
 		}
 	}
 }
+
+// validatorContractCode returns the runtime code of a contract that answers any
+// call with the ABI encoding of the given validators.
+func validatorContractCode(validators []common.Address) []byte {
+	ret := append(common.LeftPadBytes([]byte{0x20}, 32), common.LeftPadBytes(big.NewInt(int64(len(validators))).Bytes(), 32)...)
+	for _, v := range validators {
+		ret = append(ret, common.LeftPadBytes(v.Bytes(), 32)...)
+	}
+	size := byte(len(ret))
+	code := []byte{
+		byte(vm.PUSH1), size, byte(vm.PUSH1), 13, byte(vm.PUSH1), 0, byte(vm.CODECOPY),
+		byte(vm.PUSH1), size, byte(vm.PUSH1), 0, byte(vm.RETURN),
+	}
+	return append(append(code, byte(vm.STOP)), ret...)
+}
+
+func TestContractValidatorSet(t *testing.T) {
+	_, nodeKeys := testutils.GenesisAndKeys(1)
+	nodeAddr := crypto.PubkeyToAddress(nodeKeys[0].PublicKey)
+
+	// The header votes would start with an extra validator, the contract does not
+	genesis := testutils.Genesis([]common.Address{nodeAddr, common.HexToAddress("0x1234")})
+
+	contractAddr := common.HexToAddress("0x0000000000000000000000000000000000008888")
+	chainConfig := *genesis.Config
+	chainConfig.Transitions = []params.Transition{{
+		Block:                    big.NewInt(0),
+		ValidatorContractAddress: contractAddr,
+		ValidatorSelectionMode:   params.ContractMode,
+	}}
+	genesis.Config = &chainConfig
+	genesis.Alloc = core.GenesisAlloc{contractAddr: {Code: validatorContractCode([]common.Address{nodeAddr}), Balance: common.Big0}}
+
+	config := copyConfig(istanbul.DefaultConfig)
+	config.Transitions = chainConfig.Transitions
+	chain, engine := newBlockchainFromConfig(genesis, nodeKeys, config)
+	defer engine.Stop()
+
+	snap, err := engine.snapshot(chain, 0, chain.Genesis().Hash(), nil)
+	if err != nil {
+		t.Fatalf("failed to get snapshot: %v", err)
+	}
+	if validators := snap.validators(); !reflect.DeepEqual(validators, []common.Address{nodeAddr}) {
+		t.Fatalf("validators mismatch: have %v, want %v", validators, []common.Address{nodeAddr})
+	}
+
+	// A single contract validator is enough to seal and import blocks
+	block := makeBlock(chain, engine, chain.Genesis())
+	if _, err := chain.InsertChain(types.Blocks{block}); err != nil {
+		t.Fatalf("failed to insert block: %v", err)
+	}
+	snap, err = engine.snapshot(chain, 1, block.Hash(), nil)
+	if err != nil {
+		t.Fatalf("failed to get snapshot: %v", err)
+	}
+	if validators := snap.validators(); !reflect.DeepEqual(validators, []common.Address{nodeAddr}) {
+		t.Errorf("validators mismatch: have %v, want %v", validators, []common.Address{nodeAddr})
+	}
+}
+
+// newContractChain creates a single validator chain whose validators are managed
+// by a contract from the genesis on.
+func newContractChain() (*core.Genesis, []*ecdsa.PrivateKey, istanbul.Config) {
+	_, nodeKeys := testutils.GenesisAndKeys(1)
+	nodeAddr := crypto.PubkeyToAddress(nodeKeys[0].PublicKey)
+	genesis := testutils.Genesis([]common.Address{nodeAddr})
+
+	contractAddr := common.HexToAddress("0x0000000000000000000000000000000000008888")
+	chainConfig := *genesis.Config
+	chainConfig.Transitions = []params.Transition{{
+		Block:                    big.NewInt(0),
+		ValidatorContractAddress: contractAddr,
+		ValidatorSelectionMode:   params.ContractMode,
+	}}
+	genesis.Config = &chainConfig
+	genesis.Alloc = core.GenesisAlloc{contractAddr: {Code: validatorContractCode([]common.Address{nodeAddr}), Balance: common.Big0}}
+
+	config := copyConfig(istanbul.DefaultConfig)
+	config.Transitions = chainConfig.Transitions
+	return genesis, nodeKeys, config
+}
+
+// Tests that the contract managed validators are recorded in the sealed headers,
+// so that headers can be verified before the state of their parents is known.
+func TestContractValidatorHeaders(t *testing.T) {
+	genesis, nodeKeys, config := newContractChain()
+	chain, engine := newBlockchainFromConfig(genesis, nodeKeys, config)
+	defer engine.Stop()
+
+	var (
+		nodeAddr = crypto.PubkeyToAddress(nodeKeys[0].PublicKey)
+		parent   = chain.Genesis()
+		headers  []*types.Header
+	)
+	for i := 0; i < 2; i++ {
+		block := makeBlock(chain, engine, parent)
+		if _, err := chain.InsertChain(types.Blocks{block}); err != nil {
+			t.Fatalf("failed to insert block %d: %v", i+1, err)
+		}
+		if err := engine.NewChainHead(); err != nil {
+			t.Fatalf("failed to move to block %d: %v", i+2, err)
+		}
+		validators, err := engine.EngineForBlockNumber(block.Number()).Validators(block.Header())
+		if err != nil {
+			t.Fatalf("failed to read validators of block %d: %v", i+1, err)
+		}
+		if !reflect.DeepEqual(validators, []common.Address{nodeAddr}) {
+			t.Fatalf("block %d: validators mismatch: have %v, want %v", i+1, validators, []common.Address{nodeAddr})
+		}
+		headers = append(headers, block.Header())
+		parent = block
+	}
+
+	// A fresh node only having the genesis state verifies the whole batch
+	_, other := newBlockchainFromConfig(genesis, nodeKeys, config)
+	defer other.Stop()
+
+	_, results := other.VerifyHeaders(other.chain, headers, nil)
+	for i := range headers {
+		select {
+		case err := <-results:
+			if err != nil {
+				t.Fatalf("header %d: verification failed: %v", i+1, err)
+			}
+		case <-time.After(5 * time.Second):
+			t.Fatalf("header %d: verification timed out", i+1)
+		}
+	}
+}
+
+// Tests that blocks recording validators other than the contract ones are rejected
+// once their state has been computed.
+func TestContractValidatorMismatch(t *testing.T) {
+	genesis, nodeKeys, config := newContractChain()
+	chain, engine := newBlockchainFromConfig(genesis, nodeKeys, config)
+	defer engine.Stop()
+
+	block := makeBlockWithoutSeal(chain, engine, chain.Genesis(), true)
+	header := block.Header()
+	if err := engine.EngineForBlockNumber(header.Number).WriteValidators(header, []common.Address{common.HexToAddress("0x1234")}, nil); err != nil {
+		t.Fatalf("failed to write validators: %v", err)
+	}
+	resultCh := make(chan *types.Block, 1)
+	go engine.Seal(chain, block.WithSeal(header), resultCh, make(chan struct{}))
+	block = <-resultCh
+
+	if _, err := chain.InsertChain(types.Blocks{block}); !errors.Is(err, istanbulcommon.ErrInvalidContractValidators) {
+		t.Fatalf("error mismatch: have %v, want %v", err, istanbulcommon.ErrInvalidContractValidators)
+	}
+}
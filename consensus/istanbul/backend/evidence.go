@@ -0,0 +1,75 @@
+// Copyright 2023 Electroneum Ltd
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package backend
+
+import (
+	"encoding/binary"
+	"encoding/json"
+
+	"github.com/electroneum/electroneum-sc/consensus/istanbul"
+	"github.com/electroneum/electroneum-sc/ethdb"
+)
+
+const dbKeyEvidencePrefix = "istanbul-evidence"
+
+// evidenceKey = dbKeyEvidencePrefix + sequence (uint64 big endian) + validator + code + round (uint64 big endian)
+func evidenceKey(evidence *istanbul.Evidence) []byte {
+	key := append([]byte(dbKeyEvidencePrefix), encodeSequence(evidence.Sequence.Uint64())...)
+	key = append(key, evidence.Validator.Bytes()...)
+	key = append(key, byte(evidence.Code))
+	return append(key, encodeSequence(evidence.Round.Uint64())...)
+}
+
+func encodeSequence(number uint64) []byte {
+	enc := make([]byte, 8)
+	binary.BigEndian.PutUint64(enc, number)
+	return enc
+}
+
+// storeEvidence inserts the equivocation evidence into the database.
+func storeEvidence(db ethdb.KeyValueWriter, evidence *istanbul.Evidence) error {
+	blob, err := json.Marshal(evidence)
+	if err != nil {
+		return err
+	}
+	return db.Put(evidenceKey(evidence), blob)
+}
+
+// loadEvidence retrieves the equivocation evidence stored for the sequences in
+// the given range (inclusive).
+func loadEvidence(db ethdb.Iteratee, start, end uint64) ([]*istanbul.Evidence, error) {
+	it := db.NewIterator([]byte(dbKeyEvidencePrefix), encodeSequence(start))
+	defer it.Release()
+
+	result := make([]*istanbul.Evidence, 0)
+	for it.Next() {
+		evidence := new(istanbul.Evidence)
+		if err := json.Unmarshal(it.Value(), evidence); err != nil {
+			return nil, err
+		}
+		if evidence.Sequence.Uint64() > end {
+			break
+		}
+		result = append(result, evidence)
+	}
+	return result, it.Error()
+}
+
+// WriteEvidence implements istanbul.Backend.WriteEvidence
+func (sb *Backend) WriteEvidence(evidence *istanbul.Evidence) error {
+	return storeEvidence(sb.db, evidence)
+}
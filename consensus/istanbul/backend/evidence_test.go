@@ -0,0 +1,56 @@
+// Copyright 2023 Electroneum Ltd
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package backend
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/electroneum/electroneum-sc/common"
+	"github.com/electroneum/electroneum-sc/consensus/istanbul"
+	"github.com/electroneum/electroneum-sc/core/rawdb"
+)
+
+func TestEvidenceSaveAndLoad(t *testing.T) {
+	db := rawdb.NewMemoryDatabase()
+	for _, seq := range []int64{3, 300, 7} {
+		evidence := &istanbul.Evidence{
+			Validator: common.HexToAddress("0x1"),
+			Code:      0x13,
+			Sequence:  big.NewInt(seq),
+			Round:     big.NewInt(1),
+			First:     []byte{0x1},
+			Second:    []byte{0x2},
+		}
+		if err := storeEvidence(db, evidence); err != nil {
+			t.Fatalf("store evidence failed: %v", err)
+		}
+	}
+	evidence, err := loadEvidence(db, 5, 1000)
+	if err != nil {
+		t.Fatalf("load evidence failed: %v", err)
+	}
+	if len(evidence) != 2 {
+		t.Fatalf("evidence count mismatch: have %d, want %d", len(evidence), 2)
+	}
+	if evidence[0].Sequence.Int64() != 7 || evidence[1].Sequence.Int64() != 300 {
+		t.Errorf("evidence order mismatch: have %v, %v", evidence[0].Sequence, evidence[1].Sequence)
+	}
+	if evidence, _ := loadEvidence(db, 0, 3); len(evidence) != 1 {
+		t.Errorf("evidence count mismatch: have %d, want %d", len(evidence), 1)
+	}
+}
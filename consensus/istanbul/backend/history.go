@@ -0,0 +1,174 @@
+// Copyright 2023 Electroneum Ltd
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package backend
+
+import (
+	"math/big"
+
+	"github.com/electroneum/electroneum-sc/common"
+	"github.com/electroneum/electroneum-sc/consensus"
+	istanbulcommon "github.com/electroneum/electroneum-sc/consensus/istanbul/common"
+	"github.com/electroneum/electroneum-sc/core/types"
+)
+
+// Causes of a validator set change
+const (
+	ChangeCauseVote       = "vote"       // A header vote reached majority
+	ChangeCauseContract   = "contract"   // The validator contract returned a different set
+	ChangeCauseTransition = "transition" // A transition changed the validator selection mode or contract
+)
+
+// ValidatorChange is a change of the validator set made by a block. The new
+// set is the one validating the blocks after it.
+type ValidatorChange struct {
+	Number   uint64           `json:"number"`
+	Hash     common.Hash      `json:"hash"`
+	Added    []common.Address `json:"added"`
+	Removed  []common.Address `json:"removed"`
+	Cause    string           `json:"cause"`
+	Vote     *Vote            `json:"vote,omitempty"`     // Vote of the block that reached majority
+	Voters   []common.Address `json:"voters,omitempty"`   // Validators whose votes made the majority
+	Contract *common.Address  `json:"contract,omitempty"` // Validator contract the new set was read from
+}
+
+// diffValidators returns the addresses added to and removed from the sorted
+// validator list prev to get next.
+func diffValidators(prev, next []common.Address) (added, removed []common.Address) {
+	in := func(list []common.Address, addr common.Address) bool {
+		for _, a := range list {
+			if a == addr {
+				return true
+			}
+		}
+		return false
+	}
+	added, removed = []common.Address{}, []common.Address{}
+	for _, addr := range next {
+		if !in(prev, addr) {
+			added = append(added, addr)
+		}
+	}
+	for _, addr := range prev {
+		if !in(next, addr) {
+			removed = append(removed, addr)
+		}
+	}
+	return added, removed
+}
+
+// ValidatorHistory returns the blocks between start and end (inclusive) that
+// changed the validator set, replaying the snapshots block by block. Only the
+// snapshot of the block before start is looked up through the caches, the
+// following ones are derived from it without being cached.
+func (sb *Backend) ValidatorHistory(chain consensus.ChainHeaderReader, start, end uint64) ([]*ValidatorChange, error) {
+	if start == 0 {
+		start = 1
+	}
+	parent := chain.GetHeaderByNumber(start - 1)
+	if parent == nil {
+		return nil, istanbulcommon.ErrUnknownBlock
+	}
+	prev, err := sb.snapshot(chain, parent.Number.Uint64(), parent.Hash(), nil)
+	if err != nil {
+		return nil, err
+	}
+	changes := make([]*ValidatorChange, 0)
+	for number := start; number <= end; number++ {
+		header := chain.GetHeaderByNumber(number)
+		if header == nil {
+			return nil, istanbulcommon.ErrUnknownBlock
+		}
+		snap, err := sb.nextSnapshot(chain, prev, header)
+		if err != nil {
+			return nil, err
+		}
+		added, removed := diffValidators(prev.validators(), snap.validators())
+		if len(added) > 0 || len(removed) > 0 {
+			change := &ValidatorChange{
+				Number:  number,
+				Hash:    header.Hash(),
+				Added:   added,
+				Removed: removed,
+			}
+			if err := sb.explainChange(change, prev, header); err != nil {
+				return nil, err
+			}
+			changes = append(changes, change)
+		}
+		prev = snap
+	}
+	return changes, nil
+}
+
+// nextSnapshot derives the snapshot of the given header from the snapshot of its
+// parent, the same way snapshot does but without touching the caches.
+func (sb *Backend) nextSnapshot(chain consensus.ChainHeaderReader, parent *Snapshot, header *types.Header) (*Snapshot, error) {
+	next := new(big.Int).Add(header.Number, common.Big1)
+	if sb.config.IsContractValidatorSelection(next) {
+		return sb.contractSnapshot(chain, header)
+	}
+	snap, err := sb.snapApply(parent, []*types.Header{header})
+	if err != nil {
+		return nil, err
+	}
+	snap.ValSet.SetWeights(sb.config.GetConfig(next).ValidatorWeights)
+	return snap, nil
+}
+
+// explainChange fills in what caused the validator set change made by the
+// header, given the snapshot of its parent.
+func (sb *Backend) explainChange(change *ValidatorChange, parent *Snapshot, header *types.Header) error {
+	var (
+		next           = new(big.Int).Add(header.Number, common.Big1)
+		contract       = sb.config.GetConfig(next).ValidatorContract
+		contractBefore = sb.config.IsContractValidatorSelection(header.Number)
+		contractAfter  = sb.config.IsContractValidatorSelection(next)
+	)
+	switch {
+	case contractBefore != contractAfter || (contractAfter && sb.config.GetConfig(header.Number).ValidatorContract != contract):
+		change.Cause = ChangeCauseTransition
+		if contractAfter {
+			change.Contract = &contract
+		}
+
+	case contractAfter:
+		change.Cause = ChangeCauseContract
+		change.Contract = &contract
+
+	default:
+		author, err := sb.Author(header)
+		if err != nil {
+			return err
+		}
+		candidate, authorize, err := sb.EngineForBlockNumber(header.Number).ReadVote(header)
+		if err != nil {
+			return err
+		}
+		change.Cause = ChangeCauseVote
+		change.Vote = &Vote{Validator: author, Block: header.Number.Uint64(), Address: candidate, Authorize: authorize}
+		// Votes are reset on checkpoint blocks, before the vote of the header is cast
+		if header.Number.Uint64()%parent.Epoch != 0 {
+			for _, vote := range parent.Votes {
+				if vote.Address == candidate && vote.Authorize == authorize && vote.Validator != author {
+					change.Voters = append(change.Voters, vote.Validator)
+				}
+			}
+		}
+		change.Voters = append(change.Voters, author)
+	}
+	return nil
+}
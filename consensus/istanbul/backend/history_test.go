@@ -0,0 +1,172 @@
+// Copyright 2023 Electroneum Ltd
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package backend
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"math/big"
+	"reflect"
+	"testing"
+
+	"github.com/electroneum/electroneum-sc/common"
+	"github.com/electroneum/electroneum-sc/consensus/istanbul"
+	istanbulcommon "github.com/electroneum/electroneum-sc/consensus/istanbul/common"
+	qbftengine "github.com/electroneum/electroneum-sc/consensus/istanbul/engine"
+	"github.com/electroneum/electroneum-sc/consensus/istanbul/testutils"
+	"github.com/electroneum/electroneum-sc/core"
+	"github.com/electroneum/electroneum-sc/core/types"
+	"github.com/electroneum/electroneum-sc/rpc"
+)
+
+// historyChain is a blockchain extended with headers that were never imported.
+type historyChain struct {
+	*core.BlockChain
+	headers []*types.Header
+}
+
+func (c *historyChain) GetHeader(hash common.Hash, number uint64) *types.Header {
+	if header := c.GetHeaderByNumber(number); header != nil && header.Hash() == hash {
+		return header
+	}
+	return nil
+}
+
+func (c *historyChain) GetHeaderByNumber(number uint64) *types.Header {
+	if number > 0 && number <= uint64(len(c.headers)) {
+		return c.headers[number-1]
+	}
+	return c.BlockChain.GetHeaderByNumber(number)
+}
+
+func (c *historyChain) CurrentHeader() *types.Header {
+	if len(c.headers) > 0 {
+		return c.headers[len(c.headers)-1]
+	}
+	return c.BlockChain.CurrentHeader()
+}
+
+func TestDiffValidators(t *testing.T) {
+	prev := []common.Address{{0x1}, {0x2}, {0x3}}
+	next := []common.Address{{0x2}, {0x3}, {0x4}}
+
+	added, removed := diffValidators(prev, next)
+	if !reflect.DeepEqual(added, []common.Address{{0x4}}) {
+		t.Errorf("added mismatch: have %v", added)
+	}
+	if !reflect.DeepEqual(removed, []common.Address{{0x1}}) {
+		t.Errorf("removed mismatch: have %v", removed)
+	}
+	if added, removed := diffValidators(prev, prev); len(added) != 0 || len(removed) != 0 {
+		t.Errorf("unexpected diff: added %v, removed %v", added, removed)
+	}
+}
+
+func TestValidatorHistory(t *testing.T) {
+	accounts := newTesterAccountPool()
+	validators := []common.Address{accounts.address("A"), accounts.address("B")}
+	if bytes.Compare(validators[0][:], validators[1][:]) > 0 {
+		validators[0], validators[1] = validators[1], validators[0]
+	}
+	genesis := testutils.Genesis(validators)
+	config := copyConfig(istanbul.DefaultConfig)
+
+	blockchain, backend := newBlockchainFromConfig(genesis, []*ecdsa.PrivateKey{accounts.accounts["A"]}, config)
+	defer backend.Stop()
+
+	// C is voted in by A and B, then the chain goes on without votes
+	votes := []testerVote{
+		{validator: "A", voted: "C", auth: true},
+		{validator: "B", voted: "C", auth: true},
+		{validator: "A"},
+	}
+	chain := &historyChain{BlockChain: blockchain}
+	for j, vote := range votes {
+		header := &types.Header{
+			Number:     big.NewInt(int64(j) + 1),
+			Coinbase:   accounts.address(vote.validator),
+			Difficulty: istanbulcommon.DefaultDifficulty,
+			MixDigest:  types.IstanbulDigest,
+		}
+		_ = qbftengine.ApplyHeaderQBFTExtra(header, qbftengine.WriteValidators(validators))
+		if j > 0 {
+			header.ParentHash = chain.headers[j-1].Hash()
+		} else {
+			header.ParentHash = blockchain.Genesis().Hash()
+		}
+		if len(vote.voted) > 0 {
+			if err := accounts.writeValidatorVote(header, vote.validator, vote.voted, vote.auth); err != nil {
+				t.Fatalf("failed to write vote: %v", err)
+			}
+		}
+		chain.headers = append(chain.headers, header)
+	}
+
+	changes, err := backend.ValidatorHistory(chain, 0, uint64(len(votes)))
+	if err != nil {
+		t.Fatalf("failed to get validator history: %v", err)
+	}
+	if len(changes) != 1 {
+		t.Fatalf("change count mismatch: have %d, want %d", len(changes), 1)
+	}
+	change := changes[0]
+	if change.Number != 2 || change.Hash != chain.headers[1].Hash() || change.Cause != ChangeCauseVote {
+		t.Errorf("change mismatch: have %+v", change)
+	}
+	if !reflect.DeepEqual(change.Added, []common.Address{accounts.address("C")}) || len(change.Removed) != 0 {
+		t.Errorf("diff mismatch: added %v, removed %v", change.Added, change.Removed)
+	}
+	want := &Vote{Validator: accounts.address("B"), Block: 2, Address: accounts.address("C"), Authorize: true}
+	if !reflect.DeepEqual(change.Vote, want) {
+		t.Errorf("vote mismatch: have %+v, want %+v", change.Vote, want)
+	}
+	if !reflect.DeepEqual(change.Voters, []common.Address{accounts.address("A"), accounts.address("B")}) {
+		t.Errorf("voters mismatch: have %v", change.Voters)
+	}
+	// The replayed snapshots are not cached
+	for _, header := range chain.headers {
+		if backend.recents.Contains(header.Hash()) {
+			t.Errorf("snapshot of block %d cached", header.Number)
+		}
+	}
+	// The API defaults to the whole chain as it is shorter than the maximum range
+	api := &API{chain: chain, backend: backend}
+	if changes, err := api.GetValidatorHistory(nil, nil); err != nil || len(changes) != 1 {
+		t.Errorf("API history mismatch: have %v (%v), want 1 change", changes, err)
+	}
+}
+
+func TestValidatorHistoryRange(t *testing.T) {
+	blockchain, backend := newBlockChain(1)
+	defer backend.Stop()
+
+	// The range is checked before replaying any header, so plain headers will do
+	chain := &historyChain{BlockChain: blockchain}
+	for i := 1; i <= maxValidatorHistoryRange+1; i++ {
+		chain.headers = append(chain.headers, &types.Header{Number: big.NewInt(int64(i))})
+	}
+	api := &API{chain: chain, backend: backend}
+
+	start, end := rpc.BlockNumber(1), rpc.BlockNumber(maxValidatorHistoryRange+1)
+	if _, err := api.GetValidatorHistory(&start, &end); err == nil {
+		t.Fatalf("range of %d blocks accepted", maxValidatorHistoryRange+1)
+	}
+	start = 0
+	if _, err := api.GetValidatorHistory(&start, &end); err == nil {
+		t.Fatalf("range from the genesis accepted")
+	}
+}
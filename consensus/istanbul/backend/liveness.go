@@ -0,0 +1,312 @@
+// Copyright 2023 Electroneum Ltd
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package backend
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/electroneum/electroneum-sc/common"
+	"github.com/electroneum/electroneum-sc/consensus"
+	istanbulcommon "github.com/electroneum/electroneum-sc/consensus/istanbul/common"
+	"github.com/electroneum/electroneum-sc/core"
+	"github.com/electroneum/electroneum-sc/core/rawdb"
+	"github.com/electroneum/electroneum-sc/core/types"
+	"github.com/electroneum/electroneum-sc/ethdb"
+)
+
+const (
+	dbKeyLivenessPrefix      = "istanbul-liveness"
+	dbKeyLivenessIndexPrefix = "istanbul-liveness-index-"
+
+	livenessInterval   = checkpointInterval     // Number of blocks in a section of the liveness index
+	livenessConfirms   = 64                     // Number of confirmations before a section is indexed
+	livenessThrottling = 100 * time.Millisecond // Time to wait between indexing two consecutive sections
+)
+
+// errLivenessNotIndexed is returned if the liveness index lags too far behind the
+// requested block to serve it.
+var errLivenessNotIndexed = errors.New("validator liveness not indexed up to the requested block yet")
+
+// ValidatorActivity is the consensus activity of a single validator.
+type ValidatorActivity struct {
+	Proposed     uint64 `json:"proposed"`     // Number of blocks authored by the validator
+	Signed       uint64 `json:"signed"`       // Number of blocks the validator committed seals for
+	MissedRounds uint64 `json:"missedRounds"` // Number of rounds the validator was the proposer for, but no block was committed
+	LastSeen     uint64 `json:"lastSeen"`     // Last block the validator authored or signed, zero if none in the range
+}
+
+// Liveness is the cumulative activity of the validators from genesis up to a
+// given block. The activity over a range of blocks is the difference of the
+// liveness at both ends, so only the checkpoints need to be persisted.
+type Liveness struct {
+	Number     uint64                                `json:"number"`
+	Hash       common.Hash                           `json:"hash"`
+	Validators map[common.Address]*ValidatorActivity `json:"validators"`
+}
+
+func newLiveness(number uint64, hash common.Hash) *Liveness {
+	return &Liveness{
+		Number:     number,
+		Hash:       hash,
+		Validators: make(map[common.Address]*ValidatorActivity),
+	}
+}
+
+// loadLiveness loads an existing liveness checkpoint from the database.
+func loadLiveness(hash common.Hash, db ethdb.Database) (*Liveness, error) {
+	blob, err := db.Get(append([]byte(dbKeyLivenessPrefix), hash[:]...))
+	if err != nil {
+		return nil, err
+	}
+	liveness := new(Liveness)
+	if err := json.Unmarshal(blob, liveness); err != nil {
+		return nil, err
+	}
+	return liveness, nil
+}
+
+// store inserts the liveness checkpoint into the database.
+func (l *Liveness) store(db ethdb.KeyValueWriter) error {
+	blob, err := json.Marshal(l)
+	if err != nil {
+		return err
+	}
+	return db.Put(append([]byte(dbKeyLivenessPrefix), l.Hash[:]...), blob)
+}
+
+// copy creates a deep copy of the liveness.
+func (l *Liveness) copy() *Liveness {
+	cpy := newLiveness(l.Number, l.Hash)
+	for addr, activity := range l.Validators {
+		a := *activity
+		cpy.Validators[addr] = &a
+	}
+	return cpy
+}
+
+func (l *Liveness) activity(addr common.Address) *ValidatorActivity {
+	activity, ok := l.Validators[addr]
+	if !ok {
+		activity = new(ValidatorActivity)
+		l.Validators[addr] = activity
+	}
+	return activity
+}
+
+// since returns the activity of the validators between the given (older)
+// liveness and this one. Validators not seen in between have no LastSeen block.
+func (l *Liveness) since(prev *Liveness) map[common.Address]*ValidatorActivity {
+	result := make(map[common.Address]*ValidatorActivity, len(l.Validators))
+	for addr, activity := range l.Validators {
+		a := *activity
+		if p, ok := prev.Validators[addr]; ok {
+			a.Proposed -= p.Proposed
+			a.Signed -= p.Signed
+			a.MissedRounds -= p.MissedRounds
+		}
+		if a.LastSeen <= prev.Number {
+			a.LastSeen = 0
+		}
+		result[addr] = &a
+	}
+	return result
+}
+
+// livenessIndexer implements core.ChainIndexerBackend, storing the cumulative
+// validator activity at the head of every section of the canonical chain.
+type livenessIndexer struct {
+	backend  *Backend
+	chain    consensus.ChainHeaderReader
+	liveness *Liveness       // Liveness at the head of the previous section
+	headers  []*types.Header // Headers of the section being processed
+}
+
+// newLivenessIndexer returns a chain indexer that tracks the validator activity
+// of the canonical chain as new heads are imported.
+func newLivenessIndexer(backend *Backend) (*core.ChainIndexer, *livenessIndexer) {
+	indexer := &livenessIndexer{backend: backend}
+	table := rawdb.NewTable(backend.db, dbKeyLivenessIndexPrefix)
+
+	return core.NewChainIndexer(backend.db, table, indexer, livenessInterval, livenessConfirms, livenessThrottling, "liveness"), indexer
+}
+
+// Reset implements core.ChainIndexerBackend, starting a new section from the
+// liveness at the head of the previous one.
+func (l *livenessIndexer) Reset(ctx context.Context, section uint64, lastSectionHead common.Hash) error {
+	l.liveness, l.headers = nil, l.headers[:0]
+	if section == 0 {
+		return nil
+	}
+	liveness, err := loadLiveness(lastSectionHead, l.backend.db)
+	if err != nil {
+		return err
+	}
+	l.liveness = liveness
+	return nil
+}
+
+// Process implements core.ChainIndexerBackend, queueing a header of the section.
+func (l *livenessIndexer) Process(ctx context.Context, header *types.Header) error {
+	if header.Number.Sign() == 0 {
+		l.liveness = newLiveness(0, header.Hash())
+		return nil
+	}
+	l.headers = append(l.headers, header)
+	return nil
+}
+
+// Commit implements core.ChainIndexerBackend, accounting the activity of the
+// section and storing the liveness at its head.
+func (l *livenessIndexer) Commit() error {
+	liveness, err := l.backend.livenessApply(l.chain, l.liveness, l.headers)
+	if err != nil {
+		return err
+	}
+	return liveness.store(l.backend.db)
+}
+
+// Prune returns an empty error since we don't support pruning here.
+func (l *livenessIndexer) Prune(threshold uint64) error {
+	return nil
+}
+
+// liveness retrieves the cumulative validator activity up to and including the
+// given canonical block. It starts from the last indexed section at or below the
+// block and only accounts the headers since on demand.
+func (sb *Backend) liveness(chain consensus.ChainHeaderReader, number uint64) (*Liveness, error) {
+	var liveness *Liveness
+
+	section := (number + 1) / livenessInterval
+	if sections, _, _ := sb.livenessIndexer.Sections(); sections < section {
+		section = sections
+	}
+	if section > 0 {
+		l, err := loadLiveness(sb.livenessIndexer.SectionHead(section-1), sb.db)
+		if err != nil {
+			return nil, err
+		}
+		liveness = l
+	} else {
+		genesis := chain.GetHeaderByNumber(0)
+		if genesis == nil {
+			return nil, istanbulcommon.ErrUnknownBlock
+		}
+		liveness = newLiveness(0, genesis.Hash())
+	}
+	if number-liveness.Number > livenessInterval+livenessConfirms {
+		return nil, errLivenessNotIndexed
+	}
+	headers := make([]*types.Header, 0, number-liveness.Number)
+	for n := liveness.Number + 1; n <= number; n++ {
+		header := chain.GetHeaderByNumber(n)
+		if header == nil {
+			return nil, istanbulcommon.ErrUnknownBlock
+		}
+		headers = append(headers, header)
+	}
+	return sb.livenessApply(chain, liveness, headers)
+}
+
+// livenessApply accounts the activity of the given headers on top of the liveness.
+func (sb *Backend) livenessApply(chain consensus.ChainHeaderReader, liveness *Liveness, headers []*types.Header) (*Liveness, error) {
+	if len(headers) == 0 {
+		return liveness, nil
+	}
+	if headers[0].Number.Uint64() != liveness.Number+1 || headers[0].ParentHash != liveness.Hash {
+		return nil, istanbulcommon.ErrInvalidVotingChain
+	}
+	cpy := liveness.copy()
+
+	for i, header := range headers {
+		if i > 0 && header.ParentHash != headers[i-1].Hash() {
+			return nil, istanbulcommon.ErrInvalidVotingChain
+		}
+		number := header.Number.Uint64()
+
+		author, err := sb.Author(header)
+		if err != nil {
+			return nil, err
+		}
+		proposer := cpy.activity(author)
+		proposer.Proposed++
+		proposer.LastSeen = number
+
+		signers, err := sb.Signers(header)
+		if err != nil {
+			return nil, err
+		}
+		for _, signer := range signers {
+			activity := cpy.activity(signer)
+			activity.Signed++
+			activity.LastSeen = number
+		}
+
+		// Every round before the committed one had a proposer that failed to get
+		// its block committed
+		extra, err := types.ExtractQBFTExtra(header)
+		if err != nil {
+			return nil, err
+		}
+		if extra.Round > 0 {
+			missed, err := sb.missedProposers(chain, header, extra.Round, headers[:i])
+			if err != nil {
+				return nil, err
+			}
+			for _, addr := range missed {
+				cpy.activity(addr).MissedRounds++
+			}
+		}
+	}
+	cpy.Number = headers[len(headers)-1].Number.Uint64()
+	cpy.Hash = headers[len(headers)-1].Hash()
+
+	return cpy, nil
+}
+
+// missedProposers returns the proposers of the rounds before the given round of
+// the header, as selected by the validators of its parent.
+func (sb *Backend) missedProposers(chain consensus.ChainHeaderReader, header *types.Header, round uint32, parents []*types.Header) ([]common.Address, error) {
+	number := header.Number.Uint64()
+	snap, err := sb.snapshot(chain, number-1, header.ParentHash, parents)
+	if err != nil {
+		return nil, err
+	}
+	var lastProposer common.Address
+	if number > 1 {
+		parent, err := sb.snapshotHeader(chain, number-1, header.ParentHash, parents)
+		if err != nil {
+			return nil, err
+		}
+		if lastProposer, err = sb.Author(parent); err != nil {
+			return nil, err
+		}
+	}
+	// Work on a copy as calculating the proposer updates the validator set
+	valSet := snap.ValSet.Copy()
+
+	missed := make([]common.Address, 0, round)
+	for r := uint32(0); r < round; r++ {
+		valSet.CalcProposer(lastProposer, number, uint64(r))
+		if proposer := valSet.GetProposer(); proposer != nil {
+			missed = append(missed, proposer.Address())
+		}
+	}
+	return missed, nil
+}
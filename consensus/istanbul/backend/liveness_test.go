@@ -0,0 +1,154 @@
+// Copyright 2023 Electroneum Ltd
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package backend
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/electroneum/electroneum-sc/common"
+	qbftengine "github.com/electroneum/electroneum-sc/consensus/istanbul/engine"
+	"github.com/electroneum/electroneum-sc/core/rawdb"
+	"github.com/electroneum/electroneum-sc/core/types"
+	"github.com/electroneum/electroneum-sc/rpc"
+)
+
+func TestLivenessSaveAndLoad(t *testing.T) {
+	liveness := newLiveness(1024, common.HexToHash("1234567890"))
+	liveness.activity(common.HexToAddress("0x1")).Proposed = 3
+	db := rawdb.NewMemoryDatabase()
+	if err := liveness.store(db); err != nil {
+		t.Fatalf("store liveness failed: %v", err)
+	}
+	liveness1, err := loadLiveness(liveness.Hash, db)
+	if err != nil {
+		t.Fatalf("load liveness failed: %v", err)
+	}
+	if liveness1.Number != liveness.Number || liveness1.Hash != liveness.Hash {
+		t.Errorf("liveness mismatch: have %v/%v, want %v/%v", liveness1.Number, liveness1.Hash, liveness.Number, liveness.Hash)
+	}
+	if have := liveness1.Validators[common.HexToAddress("0x1")].Proposed; have != 3 {
+		t.Errorf("proposed mismatch: have %v, want %v", have, 3)
+	}
+}
+
+func TestLivenessSince(t *testing.T) {
+	var (
+		active   = common.HexToAddress("0x1")
+		inactive = common.HexToAddress("0x2")
+	)
+	prev := newLiveness(10, common.HexToHash("0x10"))
+	prev.activity(active).Signed = 10
+	prev.activity(inactive).Signed, prev.activity(inactive).LastSeen = 5, 5
+
+	liveness := prev.copy()
+	liveness.Number, liveness.Hash = 20, common.HexToHash("0x20")
+	liveness.activity(active).Signed, liveness.activity(active).LastSeen = 15, 20
+
+	activity := liveness.since(prev)
+	if have := activity[active]; have.Signed != 5 || have.LastSeen != 20 {
+		t.Errorf("active validator mismatch: have %+v", have)
+	}
+	// Activity before the range doesn't leak into it
+	if have := activity[inactive]; have.Signed != 0 || have.LastSeen != 0 {
+		t.Errorf("inactive validator mismatch: have %+v", have)
+	}
+}
+
+func TestValidatorLiveness(t *testing.T) {
+	chain, engine := newBlockChain(1)
+	defer engine.Stop()
+
+	// The core may commit a block after a round change, in which case the single
+	// validator missed the earlier rounds
+	var missed uint64
+	parent := chain.Genesis()
+	for i := 0; i < 3; i++ {
+		block := makeBlock(chain, engine, parent)
+		if _, err := chain.InsertChain(types.Blocks{block}); err != nil {
+			t.Fatalf("failed to insert block %d: %v", i+1, err)
+		}
+		engine.NewChainHead()
+		if i > 0 {
+			extra, err := types.ExtractQBFTExtra(block.Header())
+			if err != nil {
+				t.Fatalf("failed to extract extra: %v", err)
+			}
+			missed += uint64(extra.Round)
+		}
+		parent = block
+	}
+
+	api := &API{chain: chain, backend: engine}
+	start, end := rpc.BlockNumber(2), rpc.BlockNumber(3)
+	status, err := api.GetValidatorLiveness(&start, &end)
+	if err != nil {
+		t.Fatalf("failed to get liveness: %v", err)
+	}
+	activity := status.Validators[engine.Address()]
+	if activity == nil {
+		t.Fatalf("missing activity for validator %v", engine.Address())
+	}
+	if activity.Proposed != 2 || activity.Signed != 2 || activity.MissedRounds != missed || activity.LastSeen != 3 {
+		t.Errorf("activity mismatch: have %+v", activity)
+	}
+
+	// A block committed in round 2 means the proposers of rounds 0 and 1 missed
+	block := makeBlockWithoutSeal(chain, engine, parent, true)
+	header := block.Header()
+	seal, err := engine.SignWithoutHashing(qbftengine.PrepareCommittedSeal(header, 2))
+	if err != nil {
+		t.Fatalf("failed to sign committed seal: %v", err)
+	}
+	if err := engine.EngineForBlockNumber(header.Number).CommitHeader(header, [][]byte{seal}, big.NewInt(2)); err != nil {
+		t.Fatalf("failed to commit header: %v", err)
+	}
+	liveness, err := engine.liveness(chain, 3)
+	if err != nil {
+		t.Fatalf("failed to get liveness: %v", err)
+	}
+	next, err := engine.livenessApply(chain, liveness, []*types.Header{header})
+	if err != nil {
+		t.Fatalf("failed to apply header: %v", err)
+	}
+	activity = next.since(liveness)[engine.Address()]
+	if activity.Proposed != 1 || activity.Signed != 1 || activity.MissedRounds != 2 || activity.LastSeen != 4 {
+		t.Errorf("activity mismatch: have %+v", activity)
+	}
+
+	// The indexer stores the same liveness at the head of a section
+	indexer := &livenessIndexer{backend: engine, chain: chain}
+	if err := indexer.Reset(context.Background(), 0, common.Hash{}); err != nil {
+		t.Fatalf("failed to reset indexer: %v", err)
+	}
+	for n := uint64(0); n <= 3; n++ {
+		if err := indexer.Process(context.Background(), chain.GetHeaderByNumber(n)); err != nil {
+			t.Fatalf("failed to process header %d: %v", n, err)
+		}
+	}
+	if err := indexer.Commit(); err != nil {
+		t.Fatalf("failed to commit section: %v", err)
+	}
+	stored, err := loadLiveness(chain.GetHeaderByNumber(3).Hash(), engine.db)
+	if err != nil {
+		t.Fatalf("failed to load indexed liveness: %v", err)
+	}
+	if have, want := *stored.Validators[engine.Address()], *liveness.Validators[engine.Address()]; have != want {
+		t.Errorf("indexed activity mismatch: have %+v, want %+v", have, want)
+	}
+}
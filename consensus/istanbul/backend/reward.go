@@ -0,0 +1,81 @@
+// Copyright 2023 Electroneum Ltd
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package backend
+
+import (
+	"math/big"
+
+	"github.com/electroneum/electroneum-sc/common"
+	"github.com/electroneum/electroneum-sc/consensus"
+	"github.com/electroneum/electroneum-sc/core/types"
+	"github.com/electroneum/electroneum-sc/params"
+)
+
+// rewardShare is the part of the block reward credited to an account
+type rewardShare struct {
+	Address common.Address
+	Amount  *big.Int
+}
+
+// splitReward distributes the reward according to the split. The signers get
+// equal parts of the signers share. Rounding remainders, and the signers share
+// when there are no signers, go to the proposer so that the shares always add
+// up to the reward accounted for in the emission.
+func splitReward(split *params.RewardSplit, reward *big.Int, proposer common.Address, signers []common.Address) []rewardShare {
+	if split == nil {
+		return []rewardShare{{Address: proposer, Amount: new(big.Int).Set(reward)}}
+	}
+	percent := func(p uint64) *big.Int {
+		return new(big.Int).Div(new(big.Int).Mul(reward, new(big.Int).SetUint64(p)), big.NewInt(100))
+	}
+	var (
+		shares    []rewardShare
+		remaining = new(big.Int).Set(reward)
+	)
+	if split.TreasuryPercent > 0 {
+		amount := percent(split.TreasuryPercent)
+		shares = append(shares, rewardShare{Address: split.TreasuryAddress, Amount: amount})
+		remaining.Sub(remaining, amount)
+	}
+	if split.SignersPercent > 0 && len(signers) > 0 {
+		amount := new(big.Int).Div(percent(split.SignersPercent), big.NewInt(int64(len(signers))))
+		for _, signer := range signers {
+			shares = append(shares, rewardShare{Address: signer, Amount: new(big.Int).Set(amount)})
+			remaining.Sub(remaining, amount)
+		}
+	}
+	return append(shares, rewardShare{Address: proposer, Amount: remaining})
+}
+
+// blockRewardShares returns how the reward of the block is distributed. The
+// committed seals of a block are not known when it is assembled, so the signers
+// share goes to the validators that signed the parent block.
+func (sb *Backend) blockRewardShares(chain consensus.ChainHeaderReader, header *types.Header, reward *big.Int) []rewardShare {
+	split := chain.Config().GetRewardSplit(header.Number)
+
+	var signers []common.Address
+	if split != nil && split.SignersPercent > 0 && header.Number.Uint64() > 1 {
+		if parent := chain.GetHeader(header.ParentHash, header.Number.Uint64()-1); parent != nil {
+			var err error
+			if signers, err = sb.Signers(parent); err != nil {
+				sb.logger.Warn("IBFT: failed to get parent block signers for reward split", "number", header.Number, "err", err)
+				signers = nil
+			}
+		}
+	}
+	return splitReward(split, reward, header.Coinbase, signers)
+}
@@ -0,0 +1,67 @@
+// Copyright 2023 Electroneum Ltd
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package backend
+
+import (
+	"math/big"
+	"reflect"
+	"testing"
+
+	"github.com/electroneum/electroneum-sc/common"
+	"github.com/electroneum/electroneum-sc/params"
+)
+
+func TestSplitReward(t *testing.T) {
+	proposer, treasury := common.Address{0x1}, common.Address{0x2}
+	signers := []common.Address{{0x3}, {0x4}, {0x5}}
+	split := &params.RewardSplit{ProposerPercent: 50, SignersPercent: 30, TreasuryPercent: 20, TreasuryAddress: treasury}
+
+	tests := []struct {
+		split   *params.RewardSplit
+		reward  int64
+		signers []common.Address
+		want    []rewardShare
+	}{
+		{nil, 1000, signers, []rewardShare{{proposer, big.NewInt(1000)}}},
+		{split, 1000, signers, []rewardShare{
+			{treasury, big.NewInt(200)},
+			{signers[0], big.NewInt(100)}, {signers[1], big.NewInt(100)}, {signers[2], big.NewInt(100)},
+			{proposer, big.NewInt(500)},
+		}},
+		// Rounding remainders go to the proposer
+		{split, 1001, signers, []rewardShare{
+			{treasury, big.NewInt(200)},
+			{signers[0], big.NewInt(100)}, {signers[1], big.NewInt(100)}, {signers[2], big.NewInt(100)},
+			{proposer, big.NewInt(501)},
+		}},
+		// Without signers the proposer gets their share
+		{split, 1000, nil, []rewardShare{{treasury, big.NewInt(200)}, {proposer, big.NewInt(800)}}},
+	}
+	for i, test := range tests {
+		shares := splitReward(test.split, big.NewInt(test.reward), proposer, test.signers)
+		if !reflect.DeepEqual(shares, test.want) {
+			t.Errorf("test %d: shares mismatch: have %v, want %v", i, shares, test.want)
+		}
+		total := new(big.Int)
+		for _, share := range shares {
+			total.Add(total, share.Amount)
+		}
+		if total.Int64() != test.reward {
+			t.Errorf("test %d: total mismatch: have %v, want %v", i, total, test.reward)
+		}
+	}
+}
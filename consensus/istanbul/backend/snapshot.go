@@ -173,6 +173,19 @@ func (s *Snapshot) validators() []common.Address {
 	return validators
 }
 
+// weights retrieves the proposer weights of the validators, if the weighted
+// proposer policy is in use.
+func (s *Snapshot) weights() map[common.Address]uint64 {
+	if s.ValSet.Policy().Id != istanbul.Weighted {
+		return nil
+	}
+	weights := make(map[common.Address]uint64, s.ValSet.Size())
+	for _, validator := range s.ValSet.List() {
+		weights[validator.Address()] = s.ValSet.Weight(validator.Address())
+	}
+	return weights
+}
+
 type snapshotJSON struct {
 	Epoch  uint64                   `json:"epoch"`
 	Number uint64                   `json:"number"`
@@ -183,6 +196,7 @@ type snapshotJSON struct {
 	// for validator set
 	Validators []common.Address          `json:"validators"`
 	Policy     istanbul.ProposerPolicyId `json:"policy"`
+	Weights    map[common.Address]uint64 `json:"weights,omitempty"`
 }
 
 func (s *Snapshot) toJSONStruct() *snapshotJSON {
@@ -194,6 +208,7 @@ func (s *Snapshot) toJSONStruct() *snapshotJSON {
 		Tally:      s.Tally,
 		Validators: s.validators(),
 		Policy:     s.ValSet.Policy().Id,
+		Weights:    s.weights(),
 	}
 }
 
@@ -213,6 +228,7 @@ func (s *Snapshot) UnmarshalJSON(b []byte) error {
 	// Setting the By function to ValidatorSortByStringFunc should be fine, as the validator do not change only the order changes
 	pp := istanbul.NewProposerPolicyByIdAndSortFunc(j.Policy, istanbul.ValidatorSortByString())
 	s.ValSet = validator.NewSet(j.Validators, pp)
+	s.ValSet.SetWeights(j.Weights)
 	return nil
 }
 
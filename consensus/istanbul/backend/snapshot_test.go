@@ -462,3 +462,28 @@ func TestSaveAndLoad(t *testing.T) {
 		t.Errorf("validator set mismatch: have %v, want %v", snap1.ValSet, snap.ValSet)
 	}
 }
+
+func TestSaveAndLoadWeights(t *testing.T) {
+	addr1, addr2 := common.StringToAddress("1234567894"), common.StringToAddress("1234567895")
+	valSet := validator.NewSet([]common.Address{addr1, addr2}, istanbul.NewWeightedProposerPolicy())
+	valSet.SetWeights(map[common.Address]uint64{addr1: 5})
+
+	snap := newSnapshot(5, 10, common.HexToHash("1234567890"), valSet)
+	db := rawdb.NewMemoryDatabase()
+	if err := snap.store(db); err != nil {
+		t.Fatalf("store snapshot failed: %v", err)
+	}
+	snap1, err := loadSnapshot(snap.Epoch, db, snap.Hash)
+	if err != nil {
+		t.Fatalf("load snapshot failed: %v", err)
+	}
+	if policy := snap1.ValSet.Policy().Id; policy != istanbul.Weighted {
+		t.Errorf("policy mismatch: have %v, want %v", policy, istanbul.Weighted)
+	}
+	if weight := snap1.ValSet.Weight(addr1); weight != 5 {
+		t.Errorf("weight mismatch: have %v, want %v", weight, 5)
+	}
+	if weight := snap1.ValSet.Weight(addr2); weight != istanbul.DefaultValidatorWeight {
+		t.Errorf("weight mismatch: have %v, want %v", weight, istanbul.DefaultValidatorWeight)
+	}
+}
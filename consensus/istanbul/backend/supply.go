@@ -0,0 +1,297 @@
+// Copyright 2023 Electroneum Ltd
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package backend
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/electroneum/electroneum-sc/common"
+	"github.com/electroneum/electroneum-sc/common/math"
+	"github.com/electroneum/electroneum-sc/consensus"
+	istanbulcommon "github.com/electroneum/electroneum-sc/consensus/istanbul/common"
+	"github.com/electroneum/electroneum-sc/core"
+	"github.com/electroneum/electroneum-sc/core/rawdb"
+	"github.com/electroneum/electroneum-sc/core/types"
+	"github.com/electroneum/electroneum-sc/ethdb"
+)
+
+const (
+	dbKeyBurntPrefix      = "istanbul-burnt"
+	dbKeyBurntIndexPrefix = "istanbul-burnt-index-"
+
+	burntInterval   = checkpointInterval     // Number of blocks in a section of the burnt index
+	burntConfirms   = 64                     // Number of confirmations before a section is indexed
+	burntThrottling = 100 * time.Millisecond // Time to wait between indexing two consecutive sections
+)
+
+var (
+	errMissingReceipts = errors.New("missing block receipts")
+
+	// errBurntNotIndexed is returned if the burnt index lags too far behind the
+	// requested block to serve it.
+	errBurntNotIndexed = errors.New("burnt fees not indexed up to the requested block yet")
+)
+
+// supplyChain is a chain giving access to the block bodies and receipts, which
+// are needed to account for the fees burnt in each block.
+type supplyChain interface {
+	consensus.ChainReader
+	GetReceiptsByHash(hash common.Hash) types.Receipts
+}
+
+// Burnt is the amount of ETN burnt by transaction fees up to a block
+type Burnt struct {
+	Number uint64      `json:"number"`
+	Hash   common.Hash `json:"hash"`
+	Burnt  *big.Int    `json:"burnt"`
+}
+
+// loadBurnt loads an existing burnt snapshot from the database.
+func loadBurnt(hash common.Hash, db ethdb.Database) (*Burnt, error) {
+	blob, err := db.Get(append([]byte(dbKeyBurntPrefix), hash[:]...))
+	if err != nil {
+		return nil, err
+	}
+	burnt := new(Burnt)
+	if err := json.Unmarshal(blob, burnt); err != nil {
+		return nil, err
+	}
+	return burnt, nil
+}
+
+// store inserts the burnt snapshot into the database.
+func (b *Burnt) store(db ethdb.KeyValueWriter) error {
+	blob, err := json.Marshal(b)
+	if err != nil {
+		return err
+	}
+	return db.Put(append([]byte(dbKeyBurntPrefix), b.Hash[:]...), blob)
+}
+
+// blockBurnt returns the fees burnt by the transactions of the block. A
+// transaction burns the base fee for each unit of gas, or its fee cap if lower,
+// as priority transactions may have their fees waived.
+func blockBurnt(block *types.Block, receipts types.Receipts) (*big.Int, error) {
+	burnt := new(big.Int)
+	baseFee := block.BaseFee()
+	if baseFee == nil || len(block.Transactions()) == 0 {
+		return burnt, nil
+	}
+	if len(receipts) != len(block.Transactions()) {
+		return nil, errMissingReceipts
+	}
+	for i, tx := range block.Transactions() {
+		price := math.BigMin(tx.GasFeeCap(), baseFee)
+		burnt.Add(burnt, new(big.Int).Mul(price, new(big.Int).SetUint64(receipts[i].GasUsed)))
+	}
+	return burnt, nil
+}
+
+// burntIndexer implements core.ChainIndexerBackend, storing the fees burnt up
+// to the head of every section of the canonical chain. The sections are indexed
+// as new heads are imported, so that they're available before the bodies and
+// receipts of the blocks are dropped by the history expiry.
+type burntIndexer struct {
+	backend *Backend
+	chain   supplyChain
+	burnt   *Burnt // Fees burnt up to the last processed block
+}
+
+// newBurntIndexer returns a chain indexer that tracks the fees burnt on the
+// canonical chain as new heads are imported.
+func newBurntIndexer(backend *Backend) (*core.ChainIndexer, *burntIndexer) {
+	indexer := &burntIndexer{backend: backend}
+	table := rawdb.NewTable(backend.db, dbKeyBurntIndexPrefix)
+
+	return core.NewChainIndexer(backend.db, table, indexer, burntInterval, burntConfirms, burntThrottling, "burnt"), indexer
+}
+
+// Reset implements core.ChainIndexerBackend, starting a new section from the
+// fees burnt up to the head of the previous one.
+func (b *burntIndexer) Reset(ctx context.Context, section uint64, lastSectionHead common.Hash) error {
+	b.burnt = nil
+	if section == 0 {
+		return nil
+	}
+	burnt, err := loadBurnt(lastSectionHead, b.backend.db)
+	if err != nil {
+		return err
+	}
+	b.burnt = burnt
+	return nil
+}
+
+// Process implements core.ChainIndexerBackend, adding the fees burnt in the block.
+func (b *burntIndexer) Process(ctx context.Context, header *types.Header) error {
+	number, hash := header.Number.Uint64(), header.Hash()
+	if number == 0 {
+		b.burnt = &Burnt{Number: 0, Hash: hash, Burnt: new(big.Int)}
+		return nil
+	}
+	block := b.chain.GetBlock(hash, number)
+	if block == nil {
+		return consensus.ErrUnknownAncestor
+	}
+	burnt, err := blockBurnt(block, b.chain.GetReceiptsByHash(hash))
+	if err != nil {
+		return err
+	}
+	b.burnt = &Burnt{Number: number, Hash: hash, Burnt: burnt.Add(burnt, b.burnt.Burnt)}
+	return nil
+}
+
+// Commit implements core.ChainIndexerBackend, storing the fees burnt up to the
+// head of the section.
+func (b *burntIndexer) Commit() error {
+	return b.burnt.store(b.backend.db)
+}
+
+// Prune returns an empty error since we don't support pruning here.
+func (b *burntIndexer) Prune(threshold uint64) error {
+	return nil
+}
+
+// burnt retrieves the fees burnt up to the given block. It starts from the last
+// indexed section at or below the block and only sums up the fees of the blocks
+// since on demand, which must not have been dropped by the history expiry.
+func (sb *Backend) burnt(chain supplyChain, number uint64, hash common.Hash) (*Burnt, error) {
+	if b, ok := sb.recentsBurnt.Get(hash); ok {
+		return b.(*Burnt), nil
+	}
+	var base *Burnt
+
+	section := (number + 1) / burntInterval
+	if sections, _, _ := sb.burntIndexer.Sections(); sections < section {
+		section = sections
+	}
+	if section > 0 {
+		b, err := loadBurnt(sb.burntIndexer.SectionHead(section-1), sb.db)
+		if err != nil {
+			return nil, err
+		}
+		base = b
+	} else {
+		genesis := chain.GetHeaderByNumber(0)
+		if genesis == nil {
+			return nil, istanbulcommon.ErrUnknownBlock
+		}
+		base = &Burnt{Number: 0, Hash: genesis.Hash(), Burnt: new(big.Int)}
+	}
+	if number-base.Number > burntInterval+burntConfirms {
+		return nil, errBurntNotIndexed
+	}
+	if number > base.Number {
+		if err := sb.checkHistory(base.Number + 1); err != nil {
+			return nil, err
+		}
+	}
+	// Sum up the fees going backwards from the block down to the base
+	total := new(big.Int)
+	for n, h := number, hash; n > base.Number; n-- {
+		block := chain.GetBlock(h, n)
+		if block == nil {
+			return nil, consensus.ErrUnknownAncestor
+		}
+		burnt, err := blockBurnt(block, chain.GetReceiptsByHash(h))
+		if err != nil {
+			return nil, err
+		}
+		total.Add(total, burnt)
+		h = block.ParentHash()
+
+		if n == base.Number+1 && h != base.Hash {
+			return nil, istanbulcommon.ErrInvalidVotingChain
+		}
+	}
+	if number == base.Number && hash != base.Hash {
+		return nil, istanbulcommon.ErrInvalidVotingChain
+	}
+	result := &Burnt{Number: number, Hash: hash, Burnt: total.Add(total, base.Burnt)}
+	sb.recentsBurnt.Add(result.Hash, result)
+	return result, nil
+}
+
+// checkHistory returns an error if the body and receipts of the given block were
+// dropped from the freezer by the history expiry.
+func (sb *Backend) checkHistory(number uint64) error {
+	if tail, err := sb.db.Tail(); err == nil && number < tail {
+		return fmt.Errorf("%w: block %d is below the history tail %d", core.ErrHistoryExpired, number, tail)
+	}
+	return nil
+}
+
+// Supply is the breakdown of the ETN minted and burnt in a block, and up to it
+type Supply struct {
+	Number      uint64      `json:"number"`
+	Hash        common.Hash `json:"hash"`
+	Minted      *big.Int    `json:"minted"`      // Block reward minted in the block, or the genesis ETN
+	Burnt       *big.Int    `json:"burnt"`       // Fees burnt in the block
+	Net         *big.Int    `json:"net"`         // Minted minus burnt in the block
+	TotalMinted *big.Int    `json:"totalMinted"` // All the ETN minted up to the block, including the genesis ETN
+	TotalBurnt  *big.Int    `json:"totalBurnt"`  // All the fees burnt up to the block
+	NetSupply   *big.Int    `json:"netSupply"`   // Total minted minus total burnt
+}
+
+// supply computes the supply breakdown of the given block.
+func (sb *Backend) supply(chain supplyChain, header *types.Header) (*Supply, error) {
+	number, hash := header.Number.Uint64(), header.Hash()
+
+	emission, err := sb.emission(chain, number, hash, nil)
+	if err != nil {
+		return nil, err
+	}
+	minted := new(big.Int).Set(emission.CirculatingSupply)
+	if number > 0 {
+		parent, err := sb.emission(chain, number-1, header.ParentHash, nil)
+		if err != nil {
+			return nil, err
+		}
+		minted.Sub(minted, parent.CirculatingSupply)
+	}
+
+	if err := sb.checkHistory(number); err != nil {
+		return nil, err
+	}
+	block := chain.GetBlock(hash, number)
+	if block == nil {
+		return nil, consensus.ErrUnknownAncestor
+	}
+	burnt, err := blockBurnt(block, chain.GetReceiptsByHash(hash))
+	if err != nil {
+		return nil, err
+	}
+	total, err := sb.burnt(chain, number, hash)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Supply{
+		Number:      number,
+		Hash:        hash,
+		Minted:      minted,
+		Burnt:       burnt,
+		Net:         new(big.Int).Sub(minted, burnt),
+		TotalMinted: new(big.Int).Set(emission.CirculatingSupply),
+		TotalBurnt:  new(big.Int).Set(total.Burnt),
+		NetSupply:   new(big.Int).Sub(emission.CirculatingSupply, total.Burnt),
+	}, nil
+}
@@ -0,0 +1,136 @@
+// Copyright 2023 Electroneum Ltd
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package backend
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/electroneum/electroneum-sc/common"
+	"github.com/electroneum/electroneum-sc/common/math"
+	"github.com/electroneum/electroneum-sc/consensus"
+	"github.com/electroneum/electroneum-sc/core/rawdb"
+	"github.com/electroneum/electroneum-sc/core/types"
+	lru "github.com/hashicorp/golang-lru"
+)
+
+// receiptChain is a chain reader only providing blocks and receipts.
+type receiptChain struct {
+	consensus.ChainReader
+	blocks   map[common.Hash]*types.Block
+	receipts map[common.Hash]types.Receipts
+}
+
+func (c *receiptChain) GetBlock(hash common.Hash, number uint64) *types.Block {
+	return c.blocks[hash]
+}
+
+func (c *receiptChain) GetReceiptsByHash(hash common.Hash) types.Receipts {
+	return c.receipts[hash]
+}
+
+func (c *receiptChain) GetHeaderByNumber(number uint64) *types.Header {
+	for _, block := range c.blocks {
+		if block.NumberU64() == number {
+			return block.Header()
+		}
+	}
+	return nil
+}
+
+func TestBurntAccounting(t *testing.T) {
+	chain := &receiptChain{blocks: make(map[common.Hash]*types.Block), receipts: make(map[common.Hash]types.Receipts)}
+
+	// Every block burns 10 per gas, except odd blocks whose fee cap is below the base fee
+	var (
+		blocks = []*types.Block{types.NewBlockWithHeader(&types.Header{Number: big.NewInt(0)})}
+		totals = []int64{0}
+	)
+	for i := int64(1); i <= checkpointInterval+100; i++ {
+		feeCap := big.NewInt(20)
+		if i%2 == 1 {
+			feeCap = big.NewInt(5)
+		}
+		header := &types.Header{Number: big.NewInt(i), ParentHash: blocks[i-1].Hash(), BaseFee: big.NewInt(10)}
+		tx := types.NewTx(&types.DynamicFeeTx{GasFeeCap: feeCap, GasTipCap: big.NewInt(0), Gas: 21000})
+		block := types.NewBlockWithHeader(header).WithBody([]*types.Transaction{tx}, nil)
+
+		chain.blocks[block.Hash()] = block
+		chain.receipts[block.Hash()] = types.Receipts{{GasUsed: 2}}
+		blocks = append(blocks, block)
+		totals = append(totals, totals[i-1]+2*math.BigMin(feeCap, header.BaseFee).Int64())
+	}
+
+	chain.blocks[blocks[0].Hash()] = blocks[0]
+
+	backend := &Backend{db: rawdb.NewMemoryDatabase()}
+	backend.recentsBurnt, _ = lru.NewARC(inmemoryEmissions)
+	backend.burntIndexer, backend.burntBackend = newBurntIndexer(backend)
+	backend.burntBackend.chain = chain
+	defer backend.burntIndexer.Close()
+
+	// Without any indexed section, the fees are summed up from genesis, but only
+	// as far as an indexed section would have been available
+	number := checkpointInterval / 2
+	burnt, err := backend.burnt(chain, uint64(number), blocks[number].Hash())
+	if err != nil {
+		t.Fatalf("failed to compute burnt fees: %v", err)
+	}
+	if want := totals[number]; burnt.Burnt.Int64() != want {
+		t.Errorf("burnt mismatch: have %v, want %v", burnt.Burnt, want)
+	}
+	head := blocks[len(blocks)-1]
+	if _, err := backend.burnt(chain, head.NumberU64(), head.Hash()); !errors.Is(err, errBurntNotIndexed) {
+		t.Fatalf("unindexed burnt fees error mismatch: have %v, want %v", err, errBurntNotIndexed)
+	}
+
+	// Index the first section, storing the fees burnt up to its head
+	indexer := backend.burntBackend
+	if err := indexer.Reset(context.Background(), 0, common.Hash{}); err != nil {
+		t.Fatalf("failed to reset indexer: %v", err)
+	}
+	for _, block := range blocks[:checkpointInterval] {
+		if err := indexer.Process(context.Background(), block.Header()); err != nil {
+			t.Fatalf("failed to process block %d: %v", block.NumberU64(), err)
+		}
+	}
+	if err := indexer.Commit(); err != nil {
+		t.Fatalf("failed to commit section: %v", err)
+	}
+	sectionHead := blocks[checkpointInterval-1].Hash()
+	backend.burntIndexer.AddCheckpoint(0, sectionHead)
+
+	checkpoint, err := loadBurnt(sectionHead, backend.db)
+	if err != nil {
+		t.Fatalf("failed to load checkpoint: %v", err)
+	}
+	if want := totals[checkpointInterval-1]; checkpoint.Burnt.Int64() != want {
+		t.Errorf("checkpoint burnt mismatch: have %v, want %v", checkpoint.Burnt, want)
+	}
+
+	// Queries start from the indexed section, the blocks below aren't needed
+	delete(chain.blocks, blocks[checkpointInterval-2].Hash())
+	burnt, err = backend.burnt(chain, head.NumberU64(), head.Hash())
+	if err != nil {
+		t.Fatalf("failed to compute burnt fees from checkpoint: %v", err)
+	}
+	if want := totals[len(totals)-1]; burnt.Burnt.Int64() != want {
+		t.Errorf("burnt mismatch: have %v, want %v", burnt.Burnt, want)
+	}
+}
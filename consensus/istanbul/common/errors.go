@@ -95,4 +95,12 @@ var (
 
 	// ErrInvalidCoinbase is returned when the Coinbase address is different from current Proposer's Address
 	ErrInvalidCoinbase = errors.New("coinbase does not match with current proposer")
+
+	// ErrMissingValidatorState is returned when the validators are managed by a contract
+	// but the chain cannot provide the state to read them from.
+	ErrMissingValidatorState = errors.New("state unavailable to read the validator contract")
+
+	// ErrInvalidContractValidators is returned if the validators recorded in a header
+	// do not match the ones of the validator contract.
+	ErrInvalidContractValidators = errors.New("invalid contract validators in extra-data")
 )
@@ -30,11 +30,16 @@ type ProposerPolicyId uint64
 const (
 	RoundRobin ProposerPolicyId = iota
 	Sticky
+	Weighted
 )
 
+// DefaultValidatorWeight is the proposer weight of a validator that has no
+// weight configured under the Weighted policy.
+const DefaultValidatorWeight = 1
+
 // ProposerPolicy represents the Validator Proposer Policy
 type ProposerPolicy struct {
-	Id         ProposerPolicyId    // Could be RoundRobin or Sticky
+	Id         ProposerPolicyId    // Could be RoundRobin, Sticky or Weighted
 	By         ValidatorSortByFunc // func that defines how the ValidatorSet should be sorted
 	registry   []ValidatorSet      // Holds the ValidatorSet for a given block height
 	registryMU *sync.Mutex         // Mutex to lock access to changes to Registry
@@ -50,6 +55,11 @@ func NewStickyProposerPolicy() *ProposerPolicy {
 	return NewProposerPolicy(Sticky)
 }
 
+// NewWeightedProposerPolicy return a Weighted ProposerPolicy with ValidatorSortByString as default sort function
+func NewWeightedProposerPolicy() *ProposerPolicy {
+	return NewProposerPolicy(Weighted)
+}
+
 func NewProposerPolicy(id ProposerPolicyId) *ProposerPolicy {
 	return NewProposerPolicyByIdAndSortFunc(id, ValidatorSortByString())
 }
@@ -128,6 +138,15 @@ type Config struct {
 	AllowedFutureBlockTime             uint64              `toml:",omitempty"` // Max time (in seconds) from current time allowed for blocks, before they're considered future blocks
 	Transitions                        []params.Transition // Transition data
 	PriorityTransactorsContractAddress common.Address      // PriorityTransactors contract address
+	ValidatorContract                  common.Address      `toml:",omitempty"` // Smart contract address for the list of validators
+	ValidatorSelectionMode             string              `toml:",omitempty"` // Select validators from the block header votes or from a contract
+
+	ValidatorWeights map[common.Address]uint64 `toml:",omitempty"` // Proposer weights of the validators for the Weighted policy
+
+	BlockReward         *big.Int `toml:",omitempty"` // Block reward before any halving, defaults to params.ETNBlockReward
+	HalvingPeriod       uint64   `toml:",omitempty"` // Number of blocks after which the block reward is halved, defaults to params.ETNHalvingPeriod
+	EmissionBlockOffset *big.Int `toml:",omitempty"` // Number of blocks added to the block number when counting halvings, defaults to the legacy chain height
+	MaxSupply           *big.Int `toml:",omitempty"` // Max amount of wei that can ever be created, defaults to params.ETNMaxSupply
 }
 
 var DefaultConfig = &Config{
@@ -157,6 +176,33 @@ func (c Config) GetConfig(blockNumber *big.Int) Config {
 		if c.Transitions[i].AllowedFutureBlockTime != 0 {
 			newConfig.AllowedFutureBlockTime = c.Transitions[i].AllowedFutureBlockTime
 		}
+		if c.Transitions[i].ValidatorContractAddress != (common.Address{}) {
+			newConfig.ValidatorContract = c.Transitions[i].ValidatorContractAddress
+		}
+		if c.Transitions[i].ValidatorSelectionMode != "" {
+			newConfig.ValidatorSelectionMode = c.Transitions[i].ValidatorSelectionMode
+		}
+		if c.Transitions[i].ValidatorWeights != nil {
+			newConfig.ValidatorWeights = c.Transitions[i].ValidatorWeights
+		}
+		if c.Transitions[i].BlockReward != nil {
+			newConfig.BlockReward = c.Transitions[i].BlockReward
+		}
+		if c.Transitions[i].HalvingPeriod != 0 {
+			newConfig.HalvingPeriod = c.Transitions[i].HalvingPeriod
+		}
+		if c.Transitions[i].EmissionBlockOffset != nil {
+			newConfig.EmissionBlockOffset = c.Transitions[i].EmissionBlockOffset
+		}
+		if c.Transitions[i].MaxSupply != nil {
+			newConfig.MaxSupply = c.Transitions[i].MaxSupply
+		}
 	}
 	return newConfig
 }
+
+// IsContractValidatorSelection returns whether the validators of the given block
+// are read from the validator contract instead of the block header votes.
+func (c Config) IsContractValidatorSelection(blockNumber *big.Int) bool {
+	return c.GetConfig(blockNumber).ValidatorSelectionMode == params.ContractMode
+}
@@ -21,6 +21,7 @@ import (
 	"reflect"
 	"testing"
 
+	"github.com/electroneum/electroneum-sc/common"
 	"github.com/electroneum/electroneum-sc/params"
 	"github.com/naoina/toml"
 	"github.com/stretchr/testify/assert"
@@ -61,6 +62,10 @@ func TestGetConfig(t *testing.T) {
 	}, {
 		Block:                 big.NewInt(5),
 		RequestTimeoutSeconds: 15,
+	}, {
+		Block:                    big.NewInt(7),
+		ValidatorContractAddress: common.Address{0x1},
+		ValidatorSelectionMode:   params.ContractMode,
 	}}
 	config1 := *DefaultConfig
 	config1.Epoch = 40000
@@ -68,6 +73,9 @@ func TestGetConfig(t *testing.T) {
 	config3.BlockPeriod = 5
 	config5 := config3
 	config5.RequestTimeoutSeconds = 15
+	config7 := config5
+	config7.ValidatorContract = common.Address{0x1}
+	config7.ValidatorSelectionMode = params.ContractMode
 
 	type test struct {
 		blockNumber    int64
@@ -79,8 +87,9 @@ func TestGetConfig(t *testing.T) {
 		{3, config3},
 		{4, config3},
 		{5, config5},
-		{10, config5},
-		{100, config5},
+		{6, config5},
+		{7, config7},
+		{100, config7},
 	}
 
 	for _, test := range tests {
@@ -14,4 +14,7 @@ type Core interface {
 	// pending request is populated right at the preprepare stage so this would give us the earliest verification
 	// to avoid any race condition of coming propagated blocks
 	IsCurrentProposal(blockHash common.Hash) bool
+
+	// Timelines returns the consensus steps observed for the last count heights, oldest first
+	Timelines(count int) []*Timeline
 }
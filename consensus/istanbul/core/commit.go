@@ -35,6 +35,12 @@ func (c *core) broadcastCommit() {
 
 	sub := c.current.Subject()
 
+	// Never sign a COMMIT conflicting with one sent before a restart
+	if err := c.checkJournal(qbfttypes.CommitCode, sub.View.Round, sub.Digest); err != nil {
+		logger.Error("[Consensus]: Refusing to sign COMMIT message", "sub", sub, "err", err)
+		return
+	}
+
 	var header *types.Header
 	if block, ok := c.current.Proposal().(*types.Block); ok {
 		header = block.Header()
@@ -70,6 +76,12 @@ func (c *core) broadcastCommit() {
 		return
 	}
 
+	// Journal message before it leaves the node
+	if err = c.journalMessage(commit.Code(), sub.View.Round, sub.Digest, payload); err != nil {
+		withMsg(logger, commit).Error("[Consensus]: Failed to journal COMMIT message", "err", err)
+		return
+	}
+
 	withMsg(logger, commit).Trace("IBFT: broadcast COMMIT message", "payload", hexutil.Encode(payload))
 	c.cleanLogger.Info("[Consensus]: -> Broadcasting COMMIT message to validators")
 
@@ -108,6 +120,7 @@ func (c *core) handleCommitMsg(commit *qbfttypes.Commit) error {
 	// If we reached thresho
 	if c.current.QBFTCommits.Size() >= c.QuorumSize() {
 		logger.Trace("[Consensus]: Received quorum of COMMIT messages")
+		c.timeline.quorum(commit.Code())
 		c.cleanLogger.Info("[Consensus]: <- Received quorum of COMMIT messages", "count", c.current.QBFTCommits.Size(), "quorum", c.QuorumSize())
 		c.commitQBFT()
 	} else {
@@ -54,6 +54,7 @@ func New(backend istanbul.Backend, config *istanbul.Config) istanbul.Core {
 		pendingRequestsMu:  new(sync.Mutex),
 		consensusTimestamp: time.Time{},
 		currentMutex:       new(sync.Mutex),
+		timeline:           newTimeline(),
 	}
 
 	c.validateFn = c.checkValidatorSignature
@@ -94,6 +95,15 @@ type core struct {
 	pendingRequestsMu *sync.Mutex
 
 	consensusTimestamp time.Time
+
+	// Messages sent in the current sequence, persisted for crash recovery
+	journal *journal
+
+	// Messages received from validators, by sequence, to detect equivocation
+	votes map[uint64]map[voteKey]*vote
+
+	// Consensus steps of the recent heights, for tracing slow blocks
+	timeline *timeline
 }
 
 func (c *core) currentView() *istanbul.View {
@@ -191,9 +201,11 @@ func (c *core) startNewRound(round *big.Int) {
 
 	// New snapshot for new round
 	c.updateRoundState(newView, c.valSet, roundChange)
+	c.journalRound(newView.Sequence, newView.Round)
+	c.timeline.startRound(newView.Sequence.Uint64(), newView.Round.Uint64())
 
 	// Calculate new proposer
-	c.valSet.CalcProposer(lastProposer, newView.Round.Uint64())
+	c.valSet.CalcProposer(lastProposer, newView.Sequence.Uint64(), newView.Round.Uint64())
 	c.setState(StateAcceptRequest)
 
 	c.cleanLogger.Info("[Consensus]: Proposer selected", "proposer", c.valSet.GetProposer().Address())
@@ -259,6 +271,14 @@ func (c *core) stopTimer() {
 func (c *core) newRoundChangeTimer() {
 	c.stopTimer()
 
+	// Blocks sealed on demand may not come for a long time, don't change rounds
+	// until there is something to agree on
+	if c.awaitingProposal() {
+		c.roundChangeTimer = nil
+		c.currentLogger(true, nil).Trace("IBFT: no block proposal yet, ROUND-CHANGE timer not started")
+		return
+	}
+
 	// set timeout based on the round number
 	baseTimeout := time.Duration(c.config.GetConfig(c.current.Sequence()).RequestTimeoutSeconds) * time.Second
 	round := c.current.Round().Uint64()
@@ -297,6 +317,23 @@ func (c *core) newRoundChangeTimer() {
 	})
 }
 
+// awaitingProposal returns whether the first round of a chain sealing blocks on
+// demand (zero block period) is still waiting for a block proposal.
+func (c *core) awaitingProposal() bool {
+	return c.config.GetConfig(c.current.Sequence()).BlockPeriod == 0 &&
+		c.current.Round().Sign() == 0 &&
+		c.current.pendingRequest == nil &&
+		c.current.Preprepare == nil
+}
+
+// startAwaitedRoundChangeTimer starts the ROUND-CHANGE timer held back while
+// waiting for a block proposal.
+func (c *core) startAwaitedRoundChangeTimer() {
+	if c.roundChangeTimer == nil {
+		c.newRoundChangeTimer()
+	}
+}
+
 func (c *core) checkValidatorSignature(data []byte, sig []byte) (common.Address, error) {
 	return istanbul.CheckValidatorSignature(c.valSet, data, sig)
 }
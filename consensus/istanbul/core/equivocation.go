@@ -0,0 +1,126 @@
+// Copyright 2023 Electroneum Ltd
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package core
+
+import (
+	"math/big"
+
+	"github.com/electroneum/electroneum-sc/common"
+	"github.com/electroneum/electroneum-sc/consensus/istanbul"
+	qbfttypes "github.com/electroneum/electroneum-sc/consensus/istanbul/types"
+	metrics "github.com/electroneum/electroneum-sc/metrics"
+)
+
+const (
+	// equivocationWindow is the number of sequences around the current one for
+	// which messages are tracked to detect equivocation
+	equivocationWindow = 16
+
+	// equivocationRoundWindow is the number of rounds around the current one for
+	// which messages are tracked, so that a validator can't grow the tracked
+	// votes without bound by sending messages for arbitrary rounds
+	equivocationRoundWindow = 8
+)
+
+var equivocationMeter = metrics.NewRegisteredMeter("consensus/istanbul/qbft/core/equivocation", nil)
+
+// voteKey identifies a message of a validator for a view
+type voteKey struct {
+	source common.Address
+	code   uint64
+	round  uint64
+}
+
+// vote is the first message received for a voteKey
+type vote struct {
+	digest   common.Hash
+	payload  []byte
+	reported bool
+}
+
+// messageDigest returns the digest of the proposal the message votes for, if
+// the message type can be equivocated on.
+func messageDigest(m qbfttypes.QBFTMessage) (common.Hash, bool) {
+	switch msg := m.(type) {
+	case *qbfttypes.Preprepare:
+		return msg.Proposal.Hash(), true
+	case *qbfttypes.Prepare:
+		return msg.Digest, true
+	case *qbfttypes.Commit:
+		return msg.Digest, true
+	}
+	return common.Hash{}, false
+}
+
+// detectEquivocation records the signed message and reports evidence if its
+// source already sent a different message of the same type for the view.
+func (c *core) detectEquivocation(m qbfttypes.QBFTMessage, payload []byte) {
+	digest, ok := messageDigest(m)
+	if !ok || c.current == nil {
+		return
+	}
+	view := m.View()
+	sequence, current := view.Sequence.Uint64(), c.current.Sequence().Uint64()
+	if sequence+equivocationWindow < current || sequence > current+equivocationWindow {
+		return
+	}
+	round, currentRound := view.Round.Uint64(), c.current.Round().Uint64()
+	if round+equivocationRoundWindow < currentRound || round > currentRound+equivocationRoundWindow {
+		return
+	}
+	if c.votes == nil {
+		c.votes = make(map[uint64]map[voteKey]*vote)
+	}
+	for seq := range c.votes {
+		if seq+equivocationWindow < current {
+			delete(c.votes, seq)
+		}
+	}
+	votes, ok := c.votes[sequence]
+	if !ok {
+		votes = make(map[voteKey]*vote)
+		c.votes[sequence] = votes
+	}
+
+	key := voteKey{source: m.Source(), code: m.Code(), round: round}
+	prev, ok := votes[key]
+	if !ok {
+		votes[key] = &vote{digest: digest, payload: payload}
+		return
+	}
+	if prev.digest == digest || prev.reported {
+		return
+	}
+	prev.reported = true
+
+	evidence := &istanbul.Evidence{
+		Validator: m.Source(),
+		Code:      m.Code(),
+		Sequence:  new(big.Int).Set(view.Sequence),
+		Round:     new(big.Int).Set(view.Round),
+		First:     prev.payload,
+		Second:    payload,
+	}
+	withMsg(c.currentLogger(true, nil), m).Warn("[Consensus]: Validator sent conflicting messages", "first", prev.digest, "second", digest)
+	equivocationMeter.Mark(1)
+
+	if err := c.backend.WriteEvidence(evidence); err != nil {
+		c.logger.Error("IBFT: failed to store equivocation evidence", "err", err)
+	}
+	// Post asynchronously, a slow subscriber must not hold up the consensus loop
+	go c.sendEvent(istanbul.EquivocationEvent{Evidence: evidence})
+}
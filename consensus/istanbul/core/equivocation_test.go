@@ -0,0 +1,104 @@
+package core
+
+import (
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/electroneum/electroneum-sc/common"
+	"github.com/electroneum/electroneum-sc/consensus/istanbul"
+	qbfttypes "github.com/electroneum/electroneum-sc/consensus/istanbul/types"
+	"github.com/electroneum/electroneum-sc/event"
+	"github.com/electroneum/electroneum-sc/log"
+)
+
+// evidenceBackend is an istanbul.Backend only collecting equivocation evidence.
+type evidenceBackend struct {
+	istanbul.Backend
+	mux      *event.TypeMux
+	evidence []*istanbul.Evidence
+}
+
+func (b *evidenceBackend) EventMux() *event.TypeMux {
+	return b.mux
+}
+
+func (b *evidenceBackend) WriteEvidence(evidence *istanbul.Evidence) error {
+	b.evidence = append(b.evidence, evidence)
+	return nil
+}
+
+func TestDetectEquivocation(t *testing.T) {
+	backend := &evidenceBackend{mux: new(event.TypeMux)}
+	sub := backend.mux.Subscribe(istanbul.EquivocationEvent{})
+	defer sub.Unsubscribe()
+
+	sequence, round := big.NewInt(10), big.NewInt(0)
+	c := &core{backend: backend, logger: log.New()}
+	c.current = newRoundState(&istanbul.View{Sequence: sequence, Round: round}, nil, nil, nil, nil, nil, nil)
+
+	source := common.HexToAddress("0x1")
+	prepare := func(digest common.Hash) *qbfttypes.Prepare {
+		return qbfttypes.NewPrepareWithSigAndSource(sequence, round, digest, nil, source)
+	}
+
+	// Same message twice is not an equivocation
+	c.detectEquivocation(prepare(common.HexToHash("0x01")), []byte{0x1})
+	c.detectEquivocation(prepare(common.HexToHash("0x01")), []byte{0x1})
+	if len(backend.evidence) != 0 {
+		t.Fatalf("unexpected evidence: %v", backend.evidence)
+	}
+	// Same digest in a different round or message type is not an equivocation either
+	c.detectEquivocation(qbfttypes.NewPrepareWithSigAndSource(sequence, common.Big1, common.HexToHash("0x02"), nil, source), []byte{0x2})
+	c.detectEquivocation(qbfttypes.NewCommit(sequence, round, common.HexToHash("0x02"), nil), []byte{0x3})
+	if len(backend.evidence) != 0 {
+		t.Fatalf("unexpected evidence: %v", backend.evidence)
+	}
+
+	c.detectEquivocation(prepare(common.HexToHash("0x02")), []byte{0x4})
+	if len(backend.evidence) != 1 {
+		t.Fatalf("evidence count mismatch: have %d, want %d", len(backend.evidence), 1)
+	}
+	evidence := backend.evidence[0]
+	if evidence.Validator != source || evidence.Code != qbfttypes.PrepareCode || evidence.Sequence.Cmp(sequence) != 0 || evidence.Round.Cmp(round) != 0 {
+		t.Errorf("evidence mismatch: have %+v", evidence)
+	}
+	if evidence.First[0] != 0x1 || evidence.Second[0] != 0x4 {
+		t.Errorf("evidence messages mismatch: have %x/%x", evidence.First, evidence.Second)
+	}
+	select {
+	case ev := <-sub.Chan():
+		if ev.Data.(istanbul.EquivocationEvent).Evidence != evidence {
+			t.Errorf("event evidence mismatch")
+		}
+	case <-time.After(time.Second):
+		t.Errorf("equivocation event not posted")
+	}
+	// The conflict is only reported once
+	c.detectEquivocation(prepare(common.HexToHash("0x03")), []byte{0x5})
+	if len(backend.evidence) != 1 {
+		t.Errorf("evidence count mismatch: have %d, want %d", len(backend.evidence), 1)
+	}
+}
+
+// Tests that messages for rounds far from the current one are not tracked, so
+// the tracked votes stay bounded.
+func TestDetectEquivocationRoundWindow(t *testing.T) {
+	backend := &evidenceBackend{mux: new(event.TypeMux)}
+
+	sequence, round := big.NewInt(10), big.NewInt(2)
+	c := &core{backend: backend, logger: log.New()}
+	c.current = newRoundState(&istanbul.View{Sequence: sequence, Round: round}, nil, nil, nil, nil, nil, nil)
+
+	source := common.HexToAddress("0x1")
+	for r := int64(0); r < 1000; r++ {
+		c.detectEquivocation(qbfttypes.NewPrepareWithSigAndSource(sequence, big.NewInt(r), common.HexToHash("0x01"), nil, source), []byte{0x1})
+		c.detectEquivocation(qbfttypes.NewPrepareWithSigAndSource(sequence, big.NewInt(r), common.HexToHash("0x02"), nil, source), []byte{0x2})
+	}
+	if have, want := len(c.votes[sequence.Uint64()]), int(round.Int64())+equivocationRoundWindow+1; have != want {
+		t.Fatalf("tracked vote count mismatch: have %d, want %d", have, want)
+	}
+	if len(backend.evidence) != len(c.votes[sequence.Uint64()]) {
+		t.Fatalf("evidence count mismatch: have %d, want %d", len(backend.evidence), len(c.votes[sequence.Uint64()]))
+	}
+}
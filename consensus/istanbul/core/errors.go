@@ -34,4 +34,6 @@ var (
 	errInvalidSigner = errors.New("message not signed by the sender")
 	// errInvalidPreparedBlock is returned when prepared block is not validated in round change messages
 	errInvalidPreparedBlock = errors.New("invalid prepared block in round change messages")
+	// errConflictingMessage is returned when a different message of the same type was already sent for the round
+	errConflictingMessage = errors.New("conflicting message already sent for the round")
 )
@@ -30,14 +30,20 @@ import (
 // Start implements core.Engine.Start
 func (c *core) Start() error {
 	c.logger.Info("IBFT: start")
+	// Load the journal before any message can be handled, as the handlers
+	// check and record the messages sent against it
+	c.journal = c.loadJournal()
+
 	// Tests will handle events itself, so we have to make subscribeEvents()
 	// be able to call in test.
 	c.subscribeEvents()
 	c.handlerWg.Add(1)
 	go c.handleEvents()
 
-	// Start a new round from last sequence + 1
+	// Start a new round from last sequence + 1, resuming the journaled round
+	// if the node was restarted in the middle of it
 	c.startNewRound(common.Big0)
+	c.replayJournal()
 
 	return nil
 }
@@ -189,6 +195,8 @@ func (c *core) handleEncodedMsg(code uint64, data []byte) error {
 	if err = c.verifySignatures(m); err != nil {
 		return err
 	}
+	c.detectEquivocation(m, data)
+	c.timeline.message(m)
 
 	return c.handleDecodedMessage(m)
 }
@@ -234,6 +242,7 @@ func (c *core) handleTimeoutMsg() {
 	nextRound := new(big.Int).Add(round, common.Big1)
 
 	logger.Warn("[Consensus]: Round reached timeout", "pr", c.current.preparedRound)
+	c.timeline.timeout()
 	c.startNewRound(nextRound)
 	logger.Trace("IBFT: TIMER CHANGED ROUND", "pr", c.current.preparedRound)
 
@@ -0,0 +1,208 @@
+// Copyright 2023 Electroneum Ltd
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package core
+
+import (
+	"math/big"
+
+	"github.com/electroneum/electroneum-sc/common"
+	qbfttypes "github.com/electroneum/electroneum-sc/consensus/istanbul/types"
+	"github.com/electroneum/electroneum-sc/core/types"
+	"github.com/electroneum/electroneum-sc/rlp"
+)
+
+// journalEntry is a message sent by the core
+type journalEntry struct {
+	Code    uint64
+	Round   *big.Int
+	Digest  common.Hash
+	Payload []byte
+}
+
+// journal keeps the messages sent by the core and its prepared state for the
+// current sequence. It is persisted through the backend before any message is
+// broadcast, so that a restarted validator resumes the round it was in and
+// never signs a conflicting message for a round it already voted on.
+type journal struct {
+	Sequence *big.Int
+	Round    *big.Int
+	Entries  []*journalEntry
+
+	Prepared         bool
+	PreparedRound    *big.Int
+	PreparedBlock    *types.Block `rlp:"nil"`
+	PreparedPrepares []*qbfttypes.Prepare
+}
+
+func newJournal(sequence *big.Int) *journal {
+	return &journal{
+		Sequence:      new(big.Int).Set(sequence),
+		Round:         new(big.Int),
+		PreparedRound: new(big.Int),
+	}
+}
+
+// entry returns the journaled message with the given code for the given round.
+func (j *journal) entry(code uint64, round *big.Int) *journalEntry {
+	for _, e := range j.Entries {
+		if e.Code == code && e.Round.Cmp(round) == 0 {
+			return e
+		}
+	}
+	return nil
+}
+
+// loadJournal retrieves the journal persisted by the backend, if any.
+func (c *core) loadJournal() *journal {
+	data, err := c.backend.ReadJournal()
+	if err != nil || len(data) == 0 {
+		return nil
+	}
+	j := new(journal)
+	if err := rlp.DecodeBytes(data, j); err != nil {
+		c.logger.Error("IBFT: failed to decode journal", "err", err)
+		return nil
+	}
+	return j
+}
+
+// storeJournal persists the journal through the backend.
+func (c *core) storeJournal() error {
+	data, err := rlp.EncodeToBytes(c.journal)
+	if err != nil {
+		return err
+	}
+	return c.backend.WriteJournal(data)
+}
+
+// journalRound moves the journal to the given view, dropping the messages of
+// any earlier sequence or round. Messages of earlier rounds are never sent
+// again, so their entries (PRE-PREPARE payloads in particular) are not needed
+// to detect conflicts anymore.
+func (c *core) journalRound(sequence *big.Int, round *big.Int) {
+	switch {
+	case c.journal == nil || c.journal.Sequence.Cmp(sequence) != 0:
+		c.journal = newJournal(sequence)
+		c.journal.Round = new(big.Int).Set(round)
+	case round.Cmp(c.journal.Round) > 0:
+		c.journal.Round = new(big.Int).Set(round)
+
+		entries := c.journal.Entries[:0]
+		for _, e := range c.journal.Entries {
+			if e.Round.Cmp(round) >= 0 {
+				entries = append(entries, e)
+			}
+		}
+		c.journal.Entries = entries
+	default:
+		return
+	}
+	if err := c.storeJournal(); err != nil {
+		c.logger.Error("IBFT: failed to store journal", "err", err)
+	}
+}
+
+// journalMessage records a message before it gets broadcast. It returns
+// errConflictingMessage if a different message with the same code was already
+// sent for the round, in which case the message must not be broadcast.
+func (c *core) journalMessage(code uint64, round *big.Int, digest common.Hash, payload []byte) error {
+	if c.journal == nil {
+		c.journal = newJournal(c.current.Sequence())
+	}
+	if code != qbfttypes.RoundChangeCode {
+		if e := c.journal.entry(code, round); e != nil {
+			if e.Digest != digest {
+				return errConflictingMessage
+			}
+			return nil
+		}
+	} else {
+		// ROUND-CHANGE messages are resent on every timeout and can't conflict,
+		// only the latest one needs to be rebroadcast after a restart
+		entries := c.journal.Entries[:0]
+		for _, e := range c.journal.Entries {
+			if e.Code != qbfttypes.RoundChangeCode {
+				entries = append(entries, e)
+			}
+		}
+		c.journal.Entries = entries
+	}
+	c.journal.Entries = append(c.journal.Entries, &journalEntry{
+		Code:    code,
+		Round:   new(big.Int).Set(round),
+		Digest:  digest,
+		Payload: payload,
+	})
+	return c.storeJournal()
+}
+
+// checkJournal returns errConflictingMessage if a message with the given code
+// but a different digest was already sent for the round.
+func (c *core) checkJournal(code uint64, round *big.Int, digest common.Hash) error {
+	if c.journal == nil {
+		return nil
+	}
+	if e := c.journal.entry(code, round); e != nil && e.Digest != digest {
+		return errConflictingMessage
+	}
+	return nil
+}
+
+// journalPrepared records the prepared round, block and PREPARE certificate.
+func (c *core) journalPrepared() {
+	c.journal.Prepared = true
+	c.journal.PreparedRound = new(big.Int).Set(c.current.preparedRound)
+	c.journal.PreparedBlock = nil
+	if block, ok := c.current.preparedBlock.(*types.Block); ok {
+		c.journal.PreparedBlock = block
+	}
+	c.journal.PreparedPrepares = c.QBFTPreparedPrepares
+	if err := c.storeJournal(); err != nil {
+		c.logger.Error("IBFT: failed to store journal", "err", err)
+	}
+}
+
+// replayJournal restores the round and the prepared state of the journal if
+// it belongs to the current sequence, and re-broadcasts the messages sent in
+// the current round.
+func (c *core) replayJournal() {
+	j := c.journal
+	if j == nil || c.current == nil || j.Sequence.Cmp(c.current.Sequence()) != 0 {
+		return
+	}
+	logger := c.currentLogger(true, nil).New("journal.round", j.Round)
+	logger.Info("IBFT: replaying journal")
+
+	if j.Prepared {
+		c.current.preparedRound = new(big.Int).Set(j.PreparedRound)
+		if j.PreparedBlock != nil {
+			c.current.preparedBlock = j.PreparedBlock
+		}
+		c.QBFTPreparedPrepares = j.PreparedPrepares
+	}
+	if j.Round.Cmp(c.current.Round()) > 0 {
+		c.startNewRound(j.Round)
+	}
+	for _, e := range j.Entries {
+		if e.Round.Cmp(c.current.Round()) < 0 {
+			continue
+		}
+		if err := c.backend.Broadcast(c.valSet, e.Code, e.Payload); err != nil {
+			logger.Error("IBFT: failed to broadcast journaled message", "code", e.Code, "err", err)
+		}
+	}
+}
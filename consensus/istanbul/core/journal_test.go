@@ -0,0 +1,145 @@
+package core
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/electroneum/electroneum-sc/common"
+	"github.com/electroneum/electroneum-sc/consensus/istanbul"
+	qbfttypes "github.com/electroneum/electroneum-sc/consensus/istanbul/types"
+	"github.com/electroneum/electroneum-sc/core/types"
+	"github.com/electroneum/electroneum-sc/log"
+)
+
+// journalBackend is an istanbul.Backend only persisting the journal.
+type journalBackend struct {
+	istanbul.Backend
+	data []byte
+}
+
+func (b *journalBackend) WriteJournal(data []byte) error {
+	b.data = common.CopyBytes(data)
+	return nil
+}
+
+func (b *journalBackend) ReadJournal() ([]byte, error) {
+	if b.data == nil {
+		return nil, errors.New("not found")
+	}
+	return b.data, nil
+}
+
+func TestJournalConflictingMessages(t *testing.T) {
+	backend := &journalBackend{}
+	c := &core{backend: backend, logger: log.New()}
+
+	sequence, round := big.NewInt(5), big.NewInt(1)
+	c.journalRound(sequence, round)
+
+	digest := common.HexToHash("0x01")
+	if err := c.journalMessage(qbfttypes.PrepareCode, round, digest, []byte{0x1}); err != nil {
+		t.Fatalf("failed to journal PREPARE: %v", err)
+	}
+	if err := c.checkJournal(qbfttypes.PrepareCode, round, digest); err != nil {
+		t.Errorf("same PREPARE rejected: %v", err)
+	}
+	if err := c.checkJournal(qbfttypes.PrepareCode, round, common.HexToHash("0x02")); err != errConflictingMessage {
+		t.Errorf("conflicting PREPARE error mismatch: have %v, want %v", err, errConflictingMessage)
+	}
+	if err := c.checkJournal(qbfttypes.PrepareCode, big.NewInt(2), common.HexToHash("0x02")); err != nil {
+		t.Errorf("PREPARE for next round rejected: %v", err)
+	}
+	if err := c.checkJournal(qbfttypes.CommitCode, round, common.HexToHash("0x02")); err != nil {
+		t.Errorf("COMMIT rejected: %v", err)
+	}
+
+	// A restarted core must still refuse the conflicting message
+	restarted := &core{backend: backend, logger: log.New()}
+	restarted.journal = restarted.loadJournal()
+	if restarted.journal == nil {
+		t.Fatal("journal not persisted")
+	}
+	if err := restarted.checkJournal(qbfttypes.PrepareCode, round, common.HexToHash("0x02")); err != errConflictingMessage {
+		t.Errorf("conflicting PREPARE after restart error mismatch: have %v, want %v", err, errConflictingMessage)
+	}
+
+	// Starting the same sequence keeps the journal, the next one resets it
+	restarted.journalRound(sequence, common.Big0)
+	if restarted.journal.Round.Cmp(round) != 0 || len(restarted.journal.Entries) != 1 {
+		t.Errorf("journal of current sequence dropped: round %v, entries %d", restarted.journal.Round, len(restarted.journal.Entries))
+	}
+	restarted.journalRound(big.NewInt(6), common.Big0)
+	if len(restarted.journal.Entries) != 0 {
+		t.Errorf("journal of previous sequence kept: entries %d", len(restarted.journal.Entries))
+	}
+}
+
+func TestJournalPreparedState(t *testing.T) {
+	backend := &journalBackend{}
+	c := &core{backend: backend, logger: log.New()}
+
+	block := types.NewBlockWithHeader(&types.Header{Number: big.NewInt(5), Difficulty: common.Big1})
+	c.journalRound(block.Number(), common.Big1)
+	c.current = &roundState{preparedRound: big.NewInt(1), preparedBlock: block}
+	c.QBFTPreparedPrepares = []*qbfttypes.Prepare{
+		qbfttypes.NewPrepareWithSigAndSource(block.Number(), common.Big1, block.Hash(), []byte{0x1}, common.HexToAddress("0x1")),
+	}
+	c.journalPrepared()
+
+	j := c.loadJournal()
+	if j == nil || !j.Prepared {
+		t.Fatal("prepared state not persisted")
+	}
+	if j.PreparedRound.Cmp(common.Big1) != 0 {
+		t.Errorf("prepared round mismatch: have %v, want %v", j.PreparedRound, common.Big1)
+	}
+	if j.PreparedBlock == nil || j.PreparedBlock.Hash() != block.Hash() {
+		t.Errorf("prepared block mismatch")
+	}
+	if len(j.PreparedPrepares) != 1 || j.PreparedPrepares[0].Digest != block.Hash() {
+		t.Errorf("prepared certificate mismatch: have %v", j.PreparedPrepares)
+	}
+}
+
+func TestJournalEntriesBounded(t *testing.T) {
+	backend := &journalBackend{}
+	c := &core{backend: backend, logger: log.New()}
+
+	sequence := big.NewInt(5)
+	c.journalRound(sequence, common.Big0)
+
+	// Only the latest ROUND-CHANGE message is kept
+	for round := int64(1); round <= 10; round++ {
+		if err := c.journalMessage(qbfttypes.RoundChangeCode, big.NewInt(round), common.Hash{}, []byte{byte(round)}); err != nil {
+			t.Fatalf("failed to journal ROUND-CHANGE: %v", err)
+		}
+	}
+	if len(c.journal.Entries) != 1 || c.journal.Entries[0].Round.Int64() != 10 {
+		t.Fatalf("ROUND-CHANGE entries not bounded: %d entries", len(c.journal.Entries))
+	}
+	// Moving to a new round drops the messages of the earlier rounds
+	if err := c.journalMessage(qbfttypes.PreprepareCode, common.Big0, common.HexToHash("0x01"), []byte{0x1}); err != nil {
+		t.Fatalf("failed to journal PRE-PREPARE: %v", err)
+	}
+	c.journalRound(sequence, big.NewInt(3))
+	if len(c.journal.Entries) != 1 || c.journal.entry(qbfttypes.PreprepareCode, common.Big0) != nil {
+		t.Fatalf("entries of earlier rounds kept: %d entries", len(c.journal.Entries))
+	}
+}
+
+func TestJournalStartBeforeLoad(t *testing.T) {
+	c := &core{backend: &journalBackend{}, logger: log.New()}
+	c.current = newRoundState(&istanbul.View{Sequence: big.NewInt(5), Round: common.Big0}, nil, nil, nil, nil, nil, nil)
+
+	// Messages handled before any round was journaled must not crash the core
+	if err := c.checkJournal(qbfttypes.PrepareCode, common.Big0, common.HexToHash("0x01")); err != nil {
+		t.Fatalf("PREPARE rejected without journal: %v", err)
+	}
+	if err := c.journalMessage(qbfttypes.PrepareCode, common.Big0, common.HexToHash("0x01"), []byte{0x1}); err != nil {
+		t.Fatalf("failed to journal PREPARE: %v", err)
+	}
+	if c.journal == nil || c.journal.Sequence.Int64() != 5 {
+		t.Fatalf("journal not created for the current sequence")
+	}
+}
@@ -35,6 +35,12 @@ func (c *core) broadcastPrepare() {
 	prepare := qbfttypes.NewPrepare(sub.View.Sequence, sub.View.Round, sub.Digest)
 	prepare.SetSource(c.Address())
 
+	// Never sign a PREPARE conflicting with one sent before a restart
+	if err := c.checkJournal(prepare.Code(), sub.View.Round, sub.Digest); err != nil {
+		withMsg(logger, prepare).Error("[Consensus]: Refusing to sign PREPARE message", "err", err)
+		return
+	}
+
 	// Sign Message
 	encodedPayload, err := prepare.EncodePayloadForSigning()
 	if err != nil {
@@ -55,6 +61,12 @@ func (c *core) broadcastPrepare() {
 		return
 	}
 
+	// Journal message before it leaves the node
+	if err = c.journalMessage(prepare.Code(), sub.View.Round, sub.Digest, payload); err != nil {
+		withMsg(logger, prepare).Error("[Consensus]: Failed to journal PREPARE message", "err", err)
+		return
+	}
+
 	withMsg(logger, prepare).Trace("IBFT: broadcast PREPARE message", "payload", hexutil.Encode(payload))
 	c.cleanLogger.Info("[Consensus]: -> Broadcasting PREPARE message to validators")
 
@@ -94,6 +106,7 @@ func (c *core) handlePrepare(prepare *qbfttypes.Prepare) error {
 	// and we are in earlier state than "Prepared"
 	if (c.current.QBFTPrepares.Size() >= c.QuorumSize()) && c.state.Cmp(StatePrepared) < 0 {
 		logger.Trace("[Consensus]: Received quorum of PREPARE messages")
+		c.timeline.quorum(prepare.Code())
 		c.cleanLogger.Info("[Consensus]: <- Received quorum of PREPARE messages", "count", c.current.QBFTPrepares.Size(), "quorum", c.QuorumSize())
 
 		// Accumulates PREPARE messages
@@ -111,6 +124,7 @@ func (c *core) handlePrepare(prepare *qbfttypes.Prepare) error {
 			c.current.preparedBlock = c.current.Proposal()
 		}
 
+		c.journalPrepared()
 		c.setState(StatePrepared)
 		c.broadcastCommit()
 	} else {
@@ -47,6 +47,12 @@ func (c *core) sendPreprepareMsg(request *Request) {
 		preprepare := qbfttypes.NewPreprepare(curView.Sequence, curView.Round, request.Proposal)
 		preprepare.SetSource(c.Address())
 
+		// Never propose a block conflicting with one proposed before a restart
+		if err := c.checkJournal(preprepare.Code(), curView.Round, request.Proposal.Hash()); err != nil {
+			withMsg(logger, preprepare).Error("IBFT: refusing to sign PRE-PREPARE message", "err", err)
+			return
+		}
+
 		c.logger.Info("[Consensus]: Proposing new block", "sequence", curView.Sequence.Uint64())
 
 		// Sign payload
@@ -87,6 +93,12 @@ func (c *core) sendPreprepareMsg(request *Request) {
 
 		logger = withMsg(logger, preprepare).New("block.number", preprepare.Proposal.Number().Uint64(), "block.hash", preprepare.Proposal.Hash().String())
 
+		// Journal message before it leaves the node
+		if err = c.journalMessage(preprepare.Code(), curView.Round, request.Proposal.Hash(), payload); err != nil {
+			logger.Error("IBFT: failed to journal PRE-PREPARE message", "err", err)
+			return
+		}
+
 		logger.Trace("IBFT: broadcast PRE-PREPARE message", "payload", hexutil.Encode(payload))
 
 		// Broadcast RLP-encoded message
@@ -158,6 +170,7 @@ func (c *core) handlePreprepareMsg(preprepare *qbfttypes.Preprepare) error {
 
 		// Update current state
 		c.current.SetPreprepare(preprepare)
+		c.startAwaitedRoundChangeTimer()
 		c.setState(StatePreprepared)
 
 		c.cleanLogger.Info("[Consensus]: <- Received PRE-PREPARE message from proposer", "author", preprepare.Source())
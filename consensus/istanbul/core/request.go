@@ -39,6 +39,7 @@ func (c *core) handleRequest(request *Request) error {
 	}
 
 	c.current.pendingRequest = request
+	c.startAwaitedRoundChangeTimer()
 	if c.state == StateAcceptRequest {
 		// Send PRE-PREPARE message to other validators
 		c.sendPreprepareMsg(request)
@@ -88,6 +88,12 @@ func (c *core) broadcastRoundChange(round *big.Int) {
 		return
 	}
 
+	// Journal message before it leaves the node
+	if err = c.journalMessage(roundChange.Code(), round, common.Hash{}, data); err != nil {
+		withMsg(logger, roundChange).Error("[Consensus]: Failed to journal ROUND-CHANGE message", "err", err)
+		return
+	}
+
 	withMsg(logger, roundChange).Trace("[Consensus]: Broadcast ROUND-CHANGE message", "payload", hexutil.Encode(data))
 	c.cleanLogger.Info("[Consensus]: -> Broadcasting ROUND-CHANGE message to validators")
 
@@ -152,6 +158,7 @@ func (c *core) handleRoundChange(roundChange *qbfttypes.RoundChange) error {
 		c.broadcastRoundChange(newRound)
 	} else if currentRoundMessages >= c.QuorumSize() && c.IsProposer() && c.current.preprepareSent.Cmp(currentRound) < 0 {
 		logger.Trace("[Consensus]: Received quorum of ROUND-CHANGE messages")
+		c.timeline.quorum(roundChange.Code())
 		c.cleanLogger.Info("[Consensus]: <- Received quorum of ROUND-CHANGE messages", "count", currentRoundMessages, "quorum", c.QuorumSize())
 
 		// We received quorum of ROUND-CHANGE for current round and we are proposer
@@ -0,0 +1,190 @@
+// Copyright 2023 Electroneum Ltd
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package core
+
+import (
+	"sync"
+	"time"
+
+	"github.com/electroneum/electroneum-sc/consensus/istanbul"
+	qbfttypes "github.com/electroneum/electroneum-sc/consensus/istanbul/types"
+	metrics "github.com/electroneum/electroneum-sc/metrics"
+)
+
+const (
+	// timelineLimit is the number of heights for which the consensus timeline is kept
+	timelineLimit = 128
+
+	// timelineEventLimit is the number of events kept per height, so that the
+	// validators can't grow the timeline without bound by flooding messages
+	timelineEventLimit = 2048
+)
+
+// Timeline event types not related to a single message
+const (
+	timelineRoundStart = "ROUND-START"
+	timelineTimeout    = "TIMEOUT"
+)
+
+var messageNames = map[uint64]string{
+	qbfttypes.PreprepareCode:  "PRE-PREPARE",
+	qbfttypes.PrepareCode:     "PREPARE",
+	qbfttypes.CommitCode:      "COMMIT",
+	qbfttypes.RoundChangeCode: "ROUND-CHANGE",
+}
+
+var (
+	// Delays in milliseconds since the start of the round
+	messageHistograms = map[uint64]metrics.Histogram{
+		qbfttypes.PreprepareCode:  newTimelineHistogram("preprepare"),
+		qbfttypes.PrepareCode:     newTimelineHistogram("prepare"),
+		qbfttypes.CommitCode:      newTimelineHistogram("commit"),
+		qbfttypes.RoundChangeCode: newTimelineHistogram("roundchange"),
+	}
+	quorumHistograms = map[uint64]metrics.Histogram{
+		qbfttypes.PrepareCode:     newTimelineHistogram("prepare/quorum"),
+		qbfttypes.CommitCode:      newTimelineHistogram("commit/quorum"),
+		qbfttypes.RoundChangeCode: newTimelineHistogram("roundchange/quorum"),
+	}
+	timeoutHistogram = newTimelineHistogram("timeout")
+)
+
+func newTimelineHistogram(name string) metrics.Histogram {
+	return metrics.NewRegisteredHistogram("consensus/istanbul/qbft/core/timeline/"+name, nil, metrics.NewExpDecaySample(1028, 0.015))
+}
+
+// timeline records when the consensus steps of the recent heights happened.
+// It is written by the core event loop and read concurrently through the API.
+type timeline struct {
+	lock    sync.RWMutex
+	heights []*istanbul.Timeline // Ordered by sequence
+
+	sequence   uint64
+	round      uint64
+	roundStart time.Time
+	quorums    map[uint64]struct{} // Message codes for which quorum was reached in the current round
+}
+
+func newTimeline() *timeline {
+	return &timeline{quorums: make(map[uint64]struct{})}
+}
+
+// add appends the event to the timeline of the sequence, creating it for the
+// current and next sequences only.
+func (t *timeline) add(sequence uint64, ev istanbul.TimelineEvent) {
+	for i := len(t.heights) - 1; i >= 0; i-- {
+		if h := t.heights[i]; h.Sequence == sequence {
+			if len(h.Events) < timelineEventLimit {
+				h.Events = append(h.Events, ev)
+			}
+			return
+		} else if h.Sequence < sequence {
+			break
+		}
+	}
+	if sequence > t.sequence+1 || (len(t.heights) > 0 && sequence < t.heights[len(t.heights)-1].Sequence) {
+		return
+	}
+	t.heights = append(t.heights, &istanbul.Timeline{Sequence: sequence, Events: []istanbul.TimelineEvent{ev}})
+	if len(t.heights) > timelineLimit {
+		t.heights = t.heights[len(t.heights)-timelineLimit:]
+	}
+}
+
+// startRound records the start of a round, the reference for the delays
+// exported as metrics.
+func (t *timeline) startRound(sequence, round uint64) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	t.sequence, t.round, t.roundStart = sequence, round, time.Now()
+	t.quorums = make(map[uint64]struct{})
+	t.add(sequence, istanbul.TimelineEvent{Type: timelineRoundStart, Round: round, Time: t.roundStart})
+}
+
+// message records the arrival of a validated message.
+func (t *timeline) message(m qbfttypes.QBFTMessage) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	view, source, now := m.View(), m.Source(), time.Now()
+	sequence, round := view.Sequence.Uint64(), view.Round.Uint64()
+	// Only record the rounds around the current one, like the equivocation
+	// detection, rounds of the next height being counted from zero
+	var currentRound uint64
+	if sequence == t.sequence {
+		currentRound = t.round
+	}
+	if round+equivocationRoundWindow < currentRound || round > currentRound+equivocationRoundWindow {
+		return
+	}
+	if sequence == t.sequence && round == t.round && !t.roundStart.IsZero() {
+		messageHistograms[m.Code()].Update(now.Sub(t.roundStart).Milliseconds())
+	}
+	t.add(sequence, istanbul.TimelineEvent{Type: messageNames[m.Code()], Round: round, Source: &source, Time: now})
+}
+
+// quorum records that quorum of messages with the code was reached in the
+// current round. Only the first time is recorded.
+func (t *timeline) quorum(code uint64) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	if _, ok := t.quorums[code]; ok {
+		return
+	}
+	t.quorums[code] = struct{}{}
+
+	now := time.Now()
+	quorumHistograms[code].Update(now.Sub(t.roundStart).Milliseconds())
+	t.add(t.sequence, istanbul.TimelineEvent{Type: messageNames[code] + "-QUORUM", Round: t.round, Time: now})
+}
+
+// timeout records that the round timer fired.
+func (t *timeline) timeout() {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	now := time.Now()
+	timeoutHistogram.Update(now.Sub(t.roundStart).Milliseconds())
+	t.add(t.sequence, istanbul.TimelineEvent{Type: timelineTimeout, Round: t.round, Time: now})
+}
+
+// last returns a copy of the timelines of the last count heights.
+func (t *timeline) last(count int) []*istanbul.Timeline {
+	t.lock.RLock()
+	defer t.lock.RUnlock()
+
+	if count < 0 {
+		count = 0
+	} else if count > len(t.heights) {
+		count = len(t.heights)
+	}
+	result := make([]*istanbul.Timeline, 0, count)
+	for _, h := range t.heights[len(t.heights)-count:] {
+		result = append(result, &istanbul.Timeline{
+			Sequence: h.Sequence,
+			Events:   append([]istanbul.TimelineEvent(nil), h.Events...),
+		})
+	}
+	return result
+}
+
+// Timelines implements istanbul.Core.Timelines
+func (c *core) Timelines(count int) []*istanbul.Timeline {
+	return c.timeline.last(count)
+}
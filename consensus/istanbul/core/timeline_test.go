@@ -0,0 +1,76 @@
+package core
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/electroneum/electroneum-sc/common"
+	qbfttypes "github.com/electroneum/electroneum-sc/consensus/istanbul/types"
+)
+
+func TestTimeline(t *testing.T) {
+	tl := newTimeline()
+	source := common.HexToAddress("0x1")
+	prepare := func(sequence, round int64) *qbfttypes.Prepare {
+		return qbfttypes.NewPrepareWithSigAndSource(big.NewInt(sequence), big.NewInt(round), common.Hash{}, nil, source)
+	}
+
+	tl.startRound(10, 0)
+	tl.message(prepare(10, 0))
+	tl.quorum(qbfttypes.PrepareCode)
+	tl.quorum(qbfttypes.PrepareCode)
+	tl.timeout()
+	tl.startRound(10, 1)
+
+	// Messages for the next height are kept, later ones are not
+	tl.message(prepare(11, 0))
+	tl.message(prepare(12, 0))
+
+	timelines := tl.last(10)
+	if len(timelines) != 2 {
+		t.Fatalf("timeline count mismatch: have %d, want %d", len(timelines), 2)
+	}
+	want := []string{timelineRoundStart, "PREPARE", "PREPARE-QUORUM", timelineTimeout, timelineRoundStart}
+	if have := timelines[0]; have.Sequence != 10 || len(have.Events) != len(want) {
+		t.Fatalf("timeline mismatch: have %+v", have)
+	}
+	for i, ev := range timelines[0].Events {
+		if ev.Type != want[i] {
+			t.Errorf("event %d type mismatch: have %s, want %s", i, ev.Type, want[i])
+		}
+	}
+	if ev := timelines[0].Events[1]; ev.Source == nil || *ev.Source != source {
+		t.Errorf("event source mismatch: have %v, want %v", ev.Source, source)
+	}
+	if ev := timelines[0].Events[4]; ev.Round != 1 {
+		t.Errorf("event round mismatch: have %d, want %d", ev.Round, 1)
+	}
+	if have := timelines[1]; have.Sequence != 11 || len(have.Events) != 1 {
+		t.Errorf("timeline mismatch: have %+v", have)
+	}
+
+	// Messages of rounds far from the current one are not kept
+	tl.message(prepare(10, 1+equivocationRoundWindow+1))
+	tl.message(prepare(11, equivocationRoundWindow+1))
+	if have := tl.last(2); len(have[0].Events) != len(want) || len(have[1].Events) != 1 {
+		t.Errorf("events of far rounds kept: have %d and %d events", len(have[0].Events), len(have[1].Events))
+	}
+	// The events of a height are capped
+	for i := 0; i < timelineEventLimit; i++ {
+		tl.message(prepare(10, 1))
+	}
+	if have := tl.last(2)[0]; len(have.Events) != timelineEventLimit {
+		t.Errorf("event count mismatch: have %d, want %d", len(have.Events), timelineEventLimit)
+	}
+
+	// Only the most recent heights are kept
+	for seq := uint64(11); seq < 11+timelineLimit; seq++ {
+		tl.startRound(seq, 0)
+	}
+	if have := len(tl.last(2 * timelineLimit)); have != timelineLimit {
+		t.Errorf("timeline count mismatch: have %d, want %d", have, timelineLimit)
+	}
+	if have := tl.last(1); have[0].Sequence != 10+timelineLimit {
+		t.Errorf("last timeline mismatch: have %d, want %d", have[0].Sequence, 10+timelineLimit)
+	}
+}
@@ -14,6 +14,7 @@ type Engine interface {
 	Address() common.Address
 	Author(header *types.Header) (common.Address, error)
 	Validators(header *types.Header) ([]common.Address, error)
+	ValidatorWeights(header *types.Header) (map[common.Address]uint64, error)
 	Signers(header *types.Header) ([]common.Address, error)
 	CommitHeader(header *types.Header, seals [][]byte, round *big.Int) error
 	VerifyBlockProposal(chain consensus.ChainHeaderReader, block *types.Block, validators ValidatorSet) (time.Duration, error)
@@ -27,5 +28,6 @@ type Engine interface {
 	SealHash(header *types.Header) common.Hash
 	CalcDifficulty(chain consensus.ChainHeaderReader, time uint64, parent *types.Header) *big.Int
 	WriteVote(header *types.Header, candidate common.Address, authorize bool) error
+	WriteValidators(header *types.Header, validators []common.Address, weights []uint64) error
 	ReadVote(header *types.Header) (candidate common.Address, authorize bool, err error)
 }
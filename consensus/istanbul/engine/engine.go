@@ -339,6 +339,28 @@ func WriteValidators(validators []common.Address) ApplyQBFTExtra {
 	}
 }
 
+// WriteValidatorWeights writes the proposer weights of the validators, which must
+// be in the same order as the validators of the extra-data.
+func WriteValidatorWeights(weights []uint64) ApplyQBFTExtra {
+	return func(qbftExtra *types.QBFTExtra) error {
+		if len(weights) > 0 && len(weights) != len(qbftExtra.Validators) {
+			return istanbulcommon.ErrInvalidExtraDataFormat
+		}
+		qbftExtra.ValidatorWeights = weights
+		return nil
+	}
+}
+
+// WriteValidators replaces the validators of the header extra-data, together with
+// their proposer weights if any.
+func (e *Engine) WriteValidators(header *types.Header, validators []common.Address, weights []uint64) error {
+	return ApplyHeaderQBFTExtra(
+		header,
+		WriteValidators(validators),
+		WriteValidatorWeights(weights),
+	)
+}
+
 // Finalize runs any post-transaction state modifications (e.g. block rewards)
 // and assembles the final block.
 //
@@ -394,6 +416,26 @@ func (e *Engine) Validators(header *types.Header) ([]common.Address, error) {
 	return extra.Validators, nil
 }
 
+// ValidatorWeights returns the proposer weights of the validators in the header
+// extra-data, or nil if the header carries none.
+func (e *Engine) ValidatorWeights(header *types.Header) (map[common.Address]uint64, error) {
+	extra, err := types.ExtractQBFTExtra(header)
+	if err != nil {
+		return nil, err
+	}
+	if len(extra.ValidatorWeights) == 0 {
+		return nil, nil
+	}
+	if len(extra.ValidatorWeights) != len(extra.Validators) {
+		return nil, istanbulcommon.ErrInvalidExtraDataFormat
+	}
+	weights := make(map[common.Address]uint64, len(extra.Validators))
+	for i, addr := range extra.Validators {
+		weights[addr] = extra.ValidatorWeights[i]
+	}
+	return weights, nil
+}
+
 func (e *Engine) Signers(header *types.Header) ([]common.Address, error) {
 	extra, err := types.ExtractQBFTExtra(header)
 	if err != nil {
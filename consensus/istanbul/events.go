@@ -30,3 +30,8 @@ type MessageEvent struct {
 // FinalCommittedEvent is posted when a proposal is committed
 type FinalCommittedEvent struct {
 }
+
+// EquivocationEvent is posted when a validator is caught sending conflicting messages
+type EquivocationEvent struct {
+	Evidence *Evidence
+}
@@ -20,8 +20,10 @@ import (
 	"fmt"
 	"io"
 	"math/big"
+	"time"
 
 	"github.com/electroneum/electroneum-sc/common"
+	"github.com/electroneum/electroneum-sc/common/hexutil"
 	"github.com/electroneum/electroneum-sc/core/types"
 	"github.com/electroneum/electroneum-sc/rlp"
 )
@@ -146,3 +148,30 @@ func (b *Subject) DecodeRLP(s *rlp.Stream) error {
 func (b *Subject) String() string {
 	return fmt.Sprintf("{View: %v, Digest: %v}", b.View, b.Digest.String())
 }
+
+// Evidence is the proof of a validator sending two conflicting messages of the
+// same type for the same view. The messages are kept RLP-encoded as received,
+// so their signatures can be verified by anyone.
+type Evidence struct {
+	Validator common.Address `json:"validator"`
+	Code      uint64         `json:"code"`
+	Sequence  *big.Int       `json:"sequence"`
+	Round     *big.Int       `json:"round"`
+	First     hexutil.Bytes  `json:"first"`
+	Second    hexutil.Bytes  `json:"second"`
+}
+
+// TimelineEvent is a consensus step observed by the node while agreeing on a
+// block, such as a message received from a validator or a quorum reached.
+type TimelineEvent struct {
+	Type   string          `json:"type"`
+	Round  uint64          `json:"round"`
+	Source *common.Address `json:"source,omitempty"`
+	Time   time.Time       `json:"time"`
+}
+
+// Timeline is the ordered list of consensus steps observed for a block height.
+type Timeline struct {
+	Sequence uint64          `json:"sequence"`
+	Events   []TimelineEvent `json:"events"`
+}
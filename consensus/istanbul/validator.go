@@ -78,8 +78,8 @@ func (by ValidatorSortByFunc) Sort(validators []Validator) {
 // ----------------------------------------------------------------------------
 
 type ValidatorSet interface {
-	// Calculate the proposer
-	CalcProposer(lastProposer common.Address, round uint64)
+	// Calculate the proposer of the given block sequence and round
+	CalcProposer(lastProposer common.Address, sequence uint64, round uint64)
 	// Return the validator size
 	Size() int
 	// Return the validator array
@@ -102,6 +102,10 @@ type ValidatorSet interface {
 	F() int
 	// Get proposer policy
 	Policy() ProposerPolicy
+	// Get the proposer weight of the validator with given address
+	Weight(address common.Address) uint64
+	// Set the proposer weights of the validators
+	SetWeights(weights map[common.Address]uint64)
 
 	// SortValidators sorts the validators based on the configured By function
 	SortValidators()
@@ -109,4 +113,4 @@ type ValidatorSet interface {
 
 // ----------------------------------------------------------------------------
 
-type ProposalSelector func(ValidatorSet, common.Address, uint64) Validator
+type ProposalSelector func(ValidatorSet, common.Address, uint64, uint64) Validator
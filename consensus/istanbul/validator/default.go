@@ -17,12 +17,14 @@
 package validator
 
 import (
+	"encoding/binary"
 	"math"
 	"reflect"
 	"sync"
 
 	"github.com/electroneum/electroneum-sc/common"
 	"github.com/electroneum/electroneum-sc/consensus/istanbul"
+	"github.com/electroneum/electroneum-sc/crypto"
 )
 
 type defaultValidator struct {
@@ -42,6 +44,7 @@ func (val *defaultValidator) String() string {
 type defaultSet struct {
 	validators istanbul.Validators
 	policy     *istanbul.ProposerPolicy
+	weights    map[common.Address]uint64
 
 	proposer    istanbul.Validator
 	validatorMu sync.RWMutex
@@ -63,9 +66,13 @@ func newDefaultSet(addrs []common.Address, policy *istanbul.ProposerPolicy) *def
 	if valSet.Size() > 0 {
 		valSet.proposer = valSet.GetByIndex(0)
 	}
-	valSet.selector = roundRobinProposer
-	if policy.Id == istanbul.Sticky {
+	switch policy.Id {
+	case istanbul.Sticky:
 		valSet.selector = stickyProposer
+	case istanbul.Weighted:
+		valSet.selector = weightedProposer
+	default:
+		valSet.selector = roundRobinProposer
 	}
 
 	policy.RegisterValidatorSet(valSet)
@@ -112,10 +119,10 @@ func (valSet *defaultSet) IsProposer(address common.Address) bool {
 	return reflect.DeepEqual(valSet.GetProposer(), val)
 }
 
-func (valSet *defaultSet) CalcProposer(lastProposer common.Address, round uint64) {
+func (valSet *defaultSet) CalcProposer(lastProposer common.Address, sequence uint64, round uint64) {
 	valSet.validatorMu.RLock()
 	defer valSet.validatorMu.RUnlock()
-	valSet.proposer = valSet.selector(valSet, lastProposer, round)
+	valSet.proposer = valSet.selector(valSet, lastProposer, sequence, round)
 }
 
 // ValidatorSetSorter sorts the validators based on the configured By function
@@ -135,7 +142,7 @@ func emptyAddress(addr common.Address) bool {
 	return addr == common.Address{}
 }
 
-func roundRobinProposer(valSet istanbul.ValidatorSet, proposer common.Address, round uint64) istanbul.Validator {
+func roundRobinProposer(valSet istanbul.ValidatorSet, proposer common.Address, sequence uint64, round uint64) istanbul.Validator {
 	if valSet.Size() == 0 {
 		return nil
 	}
@@ -149,7 +156,7 @@ func roundRobinProposer(valSet istanbul.ValidatorSet, proposer common.Address, r
 	return valSet.GetByIndex(pick)
 }
 
-func stickyProposer(valSet istanbul.ValidatorSet, proposer common.Address, round uint64) istanbul.Validator {
+func stickyProposer(valSet istanbul.ValidatorSet, proposer common.Address, sequence uint64, round uint64) istanbul.Validator {
 	if valSet.Size() == 0 {
 		return nil
 	}
@@ -163,6 +170,40 @@ func stickyProposer(valSet istanbul.ValidatorSet, proposer common.Address, round
 	return valSet.GetByIndex(pick)
 }
 
+// weightedSeed derives the deterministic seed of the weighted proposer selection
+// from the block sequence and round.
+func weightedSeed(sequence uint64, round uint64) uint64 {
+	var buf [16]byte
+	binary.BigEndian.PutUint64(buf[:8], sequence)
+	binary.BigEndian.PutUint64(buf[8:], round)
+	return binary.BigEndian.Uint64(crypto.Keccak256(buf[:])[:8])
+}
+
+// weightedProposer picks the proposer with a probability proportional to its
+// weight. Every node derives the same pick from the block sequence and round.
+func weightedProposer(valSet istanbul.ValidatorSet, proposer common.Address, sequence uint64, round uint64) istanbul.Validator {
+	if valSet.Size() == 0 {
+		return nil
+	}
+	var total uint64
+	for _, val := range valSet.List() {
+		total += valSet.Weight(val.Address())
+	}
+	// Without any weight to go by, fall back to taking turns
+	if total == 0 {
+		return roundRobinProposer(valSet, proposer, sequence, round)
+	}
+	pick := weightedSeed(sequence, round) % total
+	for _, val := range valSet.List() {
+		weight := valSet.Weight(val.Address())
+		if pick < weight {
+			return val
+		}
+		pick -= weight
+	}
+	return nil
+}
+
 func (valSet *defaultSet) AddValidator(address common.Address) bool {
 	valSet.validatorMu.Lock()
 	defer valSet.validatorMu.Unlock()
@@ -199,9 +240,36 @@ func (valSet *defaultSet) Copy() istanbul.ValidatorSet {
 	for _, v := range valSet.validators {
 		addresses = append(addresses, v.Address())
 	}
-	return NewSet(addresses, valSet.policy)
+	cpy := NewSet(addresses, valSet.policy)
+	cpy.SetWeights(valSet.weights)
+	return cpy
 }
 
 func (valSet *defaultSet) F() int { return int(math.Ceil(float64(valSet.Size())/3)) - 1 }
 
 func (valSet *defaultSet) Policy() istanbul.ProposerPolicy { return *valSet.policy }
+
+// Weight returns the proposer weight of the given validator, which defaults to
+// DefaultValidatorWeight if none was set.
+func (valSet *defaultSet) Weight(address common.Address) uint64 {
+	valSet.validatorMu.RLock()
+	defer valSet.validatorMu.RUnlock()
+	if weight, ok := valSet.weights[address]; ok {
+		return weight
+	}
+	return istanbul.DefaultValidatorWeight
+}
+
+// SetWeights replaces the proposer weights of the validators.
+func (valSet *defaultSet) SetWeights(weights map[common.Address]uint64) {
+	valSet.validatorMu.Lock()
+	defer valSet.validatorMu.Unlock()
+	if len(weights) == 0 {
+		valSet.weights = nil
+		return
+	}
+	valSet.weights = make(map[common.Address]uint64, len(weights))
+	for addr, weight := range weights {
+		valSet.weights[addr] = weight
+	}
+}
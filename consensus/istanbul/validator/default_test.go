@@ -37,6 +37,7 @@ func TestValidatorSet(t *testing.T) {
 	testNormalValSet(t)
 	testEmptyValSet(t)
 	testStickyProposer(t)
+	testWeightedProposer(t)
 	testAddAndRemoveValidator(t)
 }
 
@@ -112,17 +113,17 @@ func testNormalValSet(t *testing.T) {
 	}
 	// test calculate proposer
 	lastProposer := addr1
-	valSet.CalcProposer(lastProposer, uint64(0))
+	valSet.CalcProposer(lastProposer, 0, uint64(0))
 	if val := valSet.GetProposer(); !reflect.DeepEqual(val, val2) {
 		t.Errorf("proposer mismatch: have %v, want %v", val, val2)
 	}
-	valSet.CalcProposer(lastProposer, uint64(3))
+	valSet.CalcProposer(lastProposer, 0, uint64(3))
 	if val := valSet.GetProposer(); !reflect.DeepEqual(val, val1) {
 		t.Errorf("proposer mismatch: have %v, want %v", val, val1)
 	}
 	// test empty last proposer
 	lastProposer = common.Address{}
-	valSet.CalcProposer(lastProposer, uint64(3))
+	valSet.CalcProposer(lastProposer, 0, uint64(3))
 	if val := valSet.GetProposer(); !reflect.DeepEqual(val, val2) {
 		t.Errorf("proposer mismatch: have %v, want %v", val, val2)
 	}
@@ -191,19 +192,64 @@ func testStickyProposer(t *testing.T) {
 	}
 	// test calculate proposer
 	lastProposer := addr1
-	valSet.CalcProposer(lastProposer, uint64(0))
+	valSet.CalcProposer(lastProposer, 0, uint64(0))
 	if val := valSet.GetProposer(); !reflect.DeepEqual(val, val1) {
 		t.Errorf("proposer mismatch: have %v, want %v", val, val1)
 	}
 
-	valSet.CalcProposer(lastProposer, uint64(1))
+	valSet.CalcProposer(lastProposer, 0, uint64(1))
 	if val := valSet.GetProposer(); !reflect.DeepEqual(val, val2) {
 		t.Errorf("proposer mismatch: have %v, want %v", val, val2)
 	}
 	// test empty last proposer
 	lastProposer = common.Address{}
-	valSet.CalcProposer(lastProposer, uint64(3))
+	valSet.CalcProposer(lastProposer, 0, uint64(3))
 	if val := valSet.GetProposer(); !reflect.DeepEqual(val, val2) {
 		t.Errorf("proposer mismatch: have %v, want %v", val, val2)
 	}
 }
+
+func testWeightedProposer(t *testing.T) {
+	addr1 := common.BytesToAddress(common.Hex2Bytes(testAddress))
+	addr2 := common.BytesToAddress(common.Hex2Bytes(testAddress2))
+	addr3 := common.HexToAddress("0x9535b2e7faaba5288511d89341d94a38063a349b")
+
+	valSet := newDefaultSet([]common.Address{addr1, addr2, addr3}, istanbul.NewWeightedProposerPolicy())
+	valSet.SetWeights(map[common.Address]uint64{addr1: 6, addr2: 3, addr3: 0})
+
+	// test the selection is deterministic for a sequence and round
+	valSet.CalcProposer(addr1, 10, 2)
+	proposer := valSet.GetProposer()
+	valSet.CalcProposer(addr2, 10, 2)
+	if val := valSet.GetProposer(); !reflect.DeepEqual(val, proposer) {
+		t.Errorf("proposer mismatch: have %v, want %v", val, proposer)
+	}
+
+	// test the proposers are picked in proportion to their weights
+	counts := make(map[common.Address]int)
+	for seq := uint64(0); seq < 9000; seq++ {
+		valSet.CalcProposer(common.Address{}, seq, 0)
+		counts[valSet.GetProposer().Address()]++
+	}
+	if counts[addr3] != 0 {
+		t.Errorf("zero weight validator picked %d times", counts[addr3])
+	}
+	if counts[addr1] < 5600 || counts[addr1] > 6400 {
+		t.Errorf("proposer count mismatch: have %d, want ~6000", counts[addr1])
+	}
+	if counts[addr2] < 2600 || counts[addr2] > 3400 {
+		t.Errorf("proposer count mismatch: have %d, want ~3000", counts[addr2])
+	}
+
+	// test the weights survive a copy
+	if weight := valSet.Copy().Weight(addr1); weight != 6 {
+		t.Errorf("weight mismatch: have %v, want %v", weight, 6)
+	}
+
+	// test falling back to round robin without any weight
+	valSet.SetWeights(map[common.Address]uint64{addr1: 0, addr2: 0, addr3: 0})
+	valSet.CalcProposer(common.Address{}, 0, 1)
+	if val := valSet.GetProposer(); !reflect.DeepEqual(val, valSet.GetByIndex(1)) {
+		t.Errorf("proposer mismatch: have %v, want %v", val, valSet.GetByIndex(1))
+	}
+}
@@ -29,6 +29,7 @@ import (
 	"github.com/electroneum/electroneum-sc/consensus/ethash"
 	"github.com/electroneum/electroneum-sc/console/prompt"
 	"github.com/electroneum/electroneum-sc/core"
+	"github.com/electroneum/electroneum-sc/crypto"
 	"github.com/electroneum/electroneum-sc/eth"
 	"github.com/electroneum/electroneum-sc/eth/ethconfig"
 	"github.com/electroneum/electroneum-sc/internal/jsre"
@@ -95,7 +96,7 @@ func newTester(t *testing.T, confOverride func(*ethconfig.Config)) *tester {
 		t.Fatalf("failed to create node: %v", err)
 	}
 	ethConf := &ethconfig.Config{
-		Genesis: core.DeveloperGenesisBlock(15, 11_500_000, common.Address{}),
+		Genesis: core.DeveloperGenesisBlock(15, 11_500_000, common.Address{}, crypto.PubkeyToAddress(stack.GetNodeKey().PublicKey), nil),
 		Miner: miner.Config{
 			Etherbase: common.HexToAddress(testAddress),
 		},
@@ -170,7 +171,9 @@ func TestWelcome(t *testing.T) {
 	if want := fmt.Sprintf("instance: %s", testInstance); !strings.Contains(output, want) {
 		t.Fatalf("console output missing instance: have\n%s\nwant also %s", output, want)
 	}
-	if want := fmt.Sprintf("coinbase: %s", testAddress); !strings.Contains(output, want) {
+	// The developer chain is sealed by the node key, reported as the coinbase
+	validator := crypto.PubkeyToAddress(tester.stack.GetNodeKey().PublicKey)
+	if want := fmt.Sprintf("coinbase: %s", strings.ToLower(validator.Hex())); !strings.Contains(output, want) {
 		t.Fatalf("console output missing coinbase: have\n%s\nwant also %s", output, want)
 	}
 	if want := "at block: 0"; !strings.Contains(output, want) {
@@ -0,0 +1,217 @@
+// Code generated - DO NOT EDIT.
+// This file is a generated binding and any manual changes will be lost.
+
+package accountpermissions
+
+import (
+	"errors"
+	"math/big"
+	"strings"
+
+	electroneum "github.com/electroneum/electroneum-sc"
+	"github.com/electroneum/electroneum-sc/accounts/abi"
+	"github.com/electroneum/electroneum-sc/accounts/abi/bind"
+	"github.com/electroneum/electroneum-sc/common"
+	"github.com/electroneum/electroneum-sc/core/types"
+	"github.com/electroneum/electroneum-sc/event"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var (
+	_ = errors.New
+	_ = big.NewInt
+	_ = strings.NewReader
+	_ = electroneum.NotFound
+	_ = bind.Bind
+	_ = common.Big1
+	_ = types.BloomLookup
+	_ = event.NewSubscription
+)
+
+// ETNAccountPermissionsInterfaceAccountPermissions is an auto generated low-level Go binding around an user-defined struct.
+type ETNAccountPermissionsInterfaceAccountPermissions struct {
+	CanTransact bool
+	CanDeploy   bool
+}
+
+// ETNAccountPermissionsInterfaceMetaData contains all meta data concerning the ETNAccountPermissionsInterface contract.
+var ETNAccountPermissionsInterfaceMetaData = &bind.MetaData{
+	ABI: "[{\"inputs\":[{\"internalType\":\"address\",\"name\":\"_account\",\"type\":\"address\"}],\"name\":\"getAccountPermissions\",\"outputs\":[{\"components\":[{\"internalType\":\"bool\",\"name\":\"canTransact\",\"type\":\"bool\"},{\"internalType\":\"bool\",\"name\":\"canDeploy\",\"type\":\"bool\"}],\"internalType\":\"structETNAccountPermissionsInterface.AccountPermissions\",\"name\":\"\",\"type\":\"tuple\"}],\"stateMutability\":\"view\",\"type\":\"function\"}]",
+}
+
+// ETNAccountPermissionsInterfaceABI is the input ABI used to generate the binding from.
+// Deprecated: Use ETNAccountPermissionsInterfaceMetaData.ABI instead.
+var ETNAccountPermissionsInterfaceABI = ETNAccountPermissionsInterfaceMetaData.ABI
+
+// ETNAccountPermissionsInterface is an auto generated Go binding around an Ethereum contract.
+type ETNAccountPermissionsInterface struct {
+	ETNAccountPermissionsInterfaceCaller     // Read-only binding to the contract
+	ETNAccountPermissionsInterfaceTransactor // Write-only binding to the contract
+	ETNAccountPermissionsInterfaceFilterer   // Log filterer for contract events
+}
+
+// ETNAccountPermissionsInterfaceCaller is an auto generated read-only Go binding around an Ethereum contract.
+type ETNAccountPermissionsInterfaceCaller struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// ETNAccountPermissionsInterfaceTransactor is an auto generated write-only Go binding around an Ethereum contract.
+type ETNAccountPermissionsInterfaceTransactor struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// ETNAccountPermissionsInterfaceFilterer is an auto generated log filtering Go binding around an Ethereum contract events.
+type ETNAccountPermissionsInterfaceFilterer struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// ETNAccountPermissionsInterfaceSession is an auto generated Go binding around an Ethereum contract,
+// with pre-set call and transact options.
+type ETNAccountPermissionsInterfaceSession struct {
+	Contract     *ETNAccountPermissionsInterface // Generic contract binding to set the session for
+	CallOpts     bind.CallOpts                   // Call options to use throughout this session
+	TransactOpts bind.TransactOpts               // Transaction auth options to use throughout this session
+}
+
+// ETNAccountPermissionsInterfaceCallerSession is an auto generated read-only Go binding around an Ethereum contract,
+// with pre-set call options.
+type ETNAccountPermissionsInterfaceCallerSession struct {
+	Contract *ETNAccountPermissionsInterfaceCaller // Generic contract caller binding to set the session for
+	CallOpts bind.CallOpts                         // Call options to use throughout this session
+}
+
+// ETNAccountPermissionsInterfaceTransactorSession is an auto generated write-only Go binding around an Ethereum contract,
+// with pre-set transact options.
+type ETNAccountPermissionsInterfaceTransactorSession struct {
+	Contract     *ETNAccountPermissionsInterfaceTransactor // Generic contract transactor binding to set the session for
+	TransactOpts bind.TransactOpts                         // Transaction auth options to use throughout this session
+}
+
+// ETNAccountPermissionsInterfaceRaw is an auto generated low-level Go binding around an Ethereum contract.
+type ETNAccountPermissionsInterfaceRaw struct {
+	Contract *ETNAccountPermissionsInterface // Generic contract binding to access the raw methods on
+}
+
+// ETNAccountPermissionsInterfaceCallerRaw is an auto generated low-level read-only Go binding around an Ethereum contract.
+type ETNAccountPermissionsInterfaceCallerRaw struct {
+	Contract *ETNAccountPermissionsInterfaceCaller // Generic read-only contract binding to access the raw methods on
+}
+
+// ETNAccountPermissionsInterfaceTransactorRaw is an auto generated low-level write-only Go binding around an Ethereum contract.
+type ETNAccountPermissionsInterfaceTransactorRaw struct {
+	Contract *ETNAccountPermissionsInterfaceTransactor // Generic write-only contract binding to access the raw methods on
+}
+
+// NewETNAccountPermissionsInterface creates a new instance of ETNAccountPermissionsInterface, bound to a specific deployed contract.
+func NewETNAccountPermissionsInterface(address common.Address, backend bind.ContractBackend) (*ETNAccountPermissionsInterface, error) {
+	contract, err := bindETNAccountPermissionsInterface(address, backend, backend, backend)
+	if err != nil {
+		return nil, err
+	}
+	return &ETNAccountPermissionsInterface{ETNAccountPermissionsInterfaceCaller: ETNAccountPermissionsInterfaceCaller{contract: contract}, ETNAccountPermissionsInterfaceTransactor: ETNAccountPermissionsInterfaceTransactor{contract: contract}, ETNAccountPermissionsInterfaceFilterer: ETNAccountPermissionsInterfaceFilterer{contract: contract}}, nil
+}
+
+// NewETNAccountPermissionsInterfaceCaller creates a new read-only instance of ETNAccountPermissionsInterface, bound to a specific deployed contract.
+func NewETNAccountPermissionsInterfaceCaller(address common.Address, caller bind.ContractCaller) (*ETNAccountPermissionsInterfaceCaller, error) {
+	contract, err := bindETNAccountPermissionsInterface(address, caller, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &ETNAccountPermissionsInterfaceCaller{contract: contract}, nil
+}
+
+// NewETNAccountPermissionsInterfaceTransactor creates a new write-only instance of ETNAccountPermissionsInterface, bound to a specific deployed contract.
+func NewETNAccountPermissionsInterfaceTransactor(address common.Address, transactor bind.ContractTransactor) (*ETNAccountPermissionsInterfaceTransactor, error) {
+	contract, err := bindETNAccountPermissionsInterface(address, nil, transactor, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &ETNAccountPermissionsInterfaceTransactor{contract: contract}, nil
+}
+
+// NewETNAccountPermissionsInterfaceFilterer creates a new log filterer instance of ETNAccountPermissionsInterface, bound to a specific deployed contract.
+func NewETNAccountPermissionsInterfaceFilterer(address common.Address, filterer bind.ContractFilterer) (*ETNAccountPermissionsInterfaceFilterer, error) {
+	contract, err := bindETNAccountPermissionsInterface(address, nil, nil, filterer)
+	if err != nil {
+		return nil, err
+	}
+	return &ETNAccountPermissionsInterfaceFilterer{contract: contract}, nil
+}
+
+// bindETNAccountPermissionsInterface binds a generic wrapper to an already deployed contract.
+func bindETNAccountPermissionsInterface(address common.Address, caller bind.ContractCaller, transactor bind.ContractTransactor, filterer bind.ContractFilterer) (*bind.BoundContract, error) {
+	parsed, err := abi.JSON(strings.NewReader(ETNAccountPermissionsInterfaceABI))
+	if err != nil {
+		return nil, err
+	}
+	return bind.NewBoundContract(address, parsed, caller, transactor, filterer), nil
+}
+
+// Call invokes the (constant) contract method with params as input values and
+// sets the output to result. The result type might be a single field for simple
+// returns, a slice of interfaces for anonymous returns and a struct for named
+// returns.
+func (_ETNAccountPermissionsInterface *ETNAccountPermissionsInterfaceRaw) Call(opts *bind.CallOpts, result *[]interface{}, method string, params ...interface{}) error {
+	return _ETNAccountPermissionsInterface.Contract.ETNAccountPermissionsInterfaceCaller.contract.Call(opts, result, method, params...)
+}
+
+// Transfer initiates a plain transaction to move funds to the contract, calling
+// its default method if one is available.
+func (_ETNAccountPermissionsInterface *ETNAccountPermissionsInterfaceRaw) Transfer(opts *bind.TransactOpts) (*types.Transaction, error) {
+	return _ETNAccountPermissionsInterface.Contract.ETNAccountPermissionsInterfaceTransactor.contract.Transfer(opts)
+}
+
+// Transact invokes the (paid) contract method with params as input values.
+func (_ETNAccountPermissionsInterface *ETNAccountPermissionsInterfaceRaw) Transact(opts *bind.TransactOpts, method string, params ...interface{}) (*types.Transaction, error) {
+	return _ETNAccountPermissionsInterface.Contract.ETNAccountPermissionsInterfaceTransactor.contract.Transact(opts, method, params...)
+}
+
+// Call invokes the (constant) contract method with params as input values and
+// sets the output to result. The result type might be a single field for simple
+// returns, a slice of interfaces for anonymous returns and a struct for named
+// returns.
+func (_ETNAccountPermissionsInterface *ETNAccountPermissionsInterfaceCallerRaw) Call(opts *bind.CallOpts, result *[]interface{}, method string, params ...interface{}) error {
+	return _ETNAccountPermissionsInterface.Contract.contract.Call(opts, result, method, params...)
+}
+
+// Transfer initiates a plain transaction to move funds to the contract, calling
+// its default method if one is available.
+func (_ETNAccountPermissionsInterface *ETNAccountPermissionsInterfaceTransactorRaw) Transfer(opts *bind.TransactOpts) (*types.Transaction, error) {
+	return _ETNAccountPermissionsInterface.Contract.contract.Transfer(opts)
+}
+
+// Transact invokes the (paid) contract method with params as input values.
+func (_ETNAccountPermissionsInterface *ETNAccountPermissionsInterfaceTransactorRaw) Transact(opts *bind.TransactOpts, method string, params ...interface{}) (*types.Transaction, error) {
+	return _ETNAccountPermissionsInterface.Contract.contract.Transact(opts, method, params...)
+}
+
+// GetAccountPermissions is a free data retrieval call binding the contract method 0xc62e72d3.
+//
+// Solidity: function getAccountPermissions(address _account) view returns((bool,bool))
+func (_ETNAccountPermissionsInterface *ETNAccountPermissionsInterfaceCaller) GetAccountPermissions(opts *bind.CallOpts, _account common.Address) (ETNAccountPermissionsInterfaceAccountPermissions, error) {
+	var out []interface{}
+	err := _ETNAccountPermissionsInterface.contract.Call(opts, &out, "getAccountPermissions", _account)
+
+	if err != nil {
+		return *new(ETNAccountPermissionsInterfaceAccountPermissions), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(ETNAccountPermissionsInterfaceAccountPermissions)).(*ETNAccountPermissionsInterfaceAccountPermissions)
+
+	return out0, err
+
+}
+
+// GetAccountPermissions is a free data retrieval call binding the contract method 0xc62e72d3.
+//
+// Solidity: function getAccountPermissions(address _account) view returns((bool,bool))
+func (_ETNAccountPermissionsInterface *ETNAccountPermissionsInterfaceSession) GetAccountPermissions(_account common.Address) (ETNAccountPermissionsInterfaceAccountPermissions, error) {
+	return _ETNAccountPermissionsInterface.Contract.GetAccountPermissions(&_ETNAccountPermissionsInterface.CallOpts, _account)
+}
+
+// GetAccountPermissions is a free data retrieval call binding the contract method 0xc62e72d3.
+//
+// Solidity: function getAccountPermissions(address _account) view returns((bool,bool))
+func (_ETNAccountPermissionsInterface *ETNAccountPermissionsInterfaceCallerSession) GetAccountPermissions(_account common.Address) (ETNAccountPermissionsInterfaceAccountPermissions, error) {
+	return _ETNAccountPermissionsInterface.Contract.GetAccountPermissions(&_ETNAccountPermissionsInterface.CallOpts, _account)
+}
@@ -0,0 +1,12 @@
+// contracts/ETNAccountPermissionsInterface.sol
+// SPDX-License-Identifier: MIT
+pragma solidity ^0.8.16;
+
+interface ETNAccountPermissionsInterface {
+    struct AccountPermissions {
+        bool canTransact;
+        bool canDeploy;
+    }
+
+    function getAccountPermissions(address _account) external view returns (AccountPermissions memory);
+}
@@ -0,0 +1,23 @@
+// Copyright 2023 Electroneum Ltd
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+// Package accountpermissions is the on-chain registry of the accounts allowed to
+// transact and deploy contracts on a permissioned network.
+package accountpermissions
+
+//go:generate solc --abi -o . --overwrite ./contract/ETNAccountPermissionsInterface.sol
+//go:generate go run ../../cmd/abigen -pkg accountpermissions -abi ./ETNAccountPermissionsInterface.abi -type ETNAccountPermissionsInterface -out ./accountpermissions.go
+//go:generate rm ETNAccountPermissionsInterface.abi
@@ -0,0 +1,13 @@
+// contracts/ETNNodeRegistryInterface.sol
+// SPDX-License-Identifier: MIT
+pragma solidity ^0.8.16;
+
+interface ETNNodeRegistryInterface {
+    struct NodeMeta {
+        string publicKey;
+        string name;
+    }
+
+    function getNodes() external view returns (NodeMeta[] memory);
+    function getNodeByKey(string memory _publicKey) external view returns (NodeMeta memory);
+}
@@ -0,0 +1,23 @@
+// Copyright 2023 Electroneum Ltd
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+// Package noderegistry is the on-chain registry of the nodes allowed to connect
+// to a permissioned network.
+package noderegistry
+
+//go:generate solc --abi -o . --overwrite ./contract/ETNNodeRegistryInterface.sol
+//go:generate go run ../../cmd/abigen -pkg noderegistry -abi ./ETNNodeRegistryInterface.abi -type ETNNodeRegistryInterface -out ./noderegistry.go
+//go:generate rm ETNNodeRegistryInterface.abi
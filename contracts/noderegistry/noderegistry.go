@@ -0,0 +1,248 @@
+// Code generated - DO NOT EDIT.
+// This file is a generated binding and any manual changes will be lost.
+
+package noderegistry
+
+import (
+	"errors"
+	"math/big"
+	"strings"
+
+	electroneum "github.com/electroneum/electroneum-sc"
+	"github.com/electroneum/electroneum-sc/accounts/abi"
+	"github.com/electroneum/electroneum-sc/accounts/abi/bind"
+	"github.com/electroneum/electroneum-sc/common"
+	"github.com/electroneum/electroneum-sc/core/types"
+	"github.com/electroneum/electroneum-sc/event"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var (
+	_ = errors.New
+	_ = big.NewInt
+	_ = strings.NewReader
+	_ = electroneum.NotFound
+	_ = bind.Bind
+	_ = common.Big1
+	_ = types.BloomLookup
+	_ = event.NewSubscription
+)
+
+// ETNNodeRegistryInterfaceNodeMeta is an auto generated low-level Go binding around an user-defined struct.
+type ETNNodeRegistryInterfaceNodeMeta struct {
+	PublicKey string
+	Name      string
+}
+
+// ETNNodeRegistryInterfaceMetaData contains all meta data concerning the ETNNodeRegistryInterface contract.
+var ETNNodeRegistryInterfaceMetaData = &bind.MetaData{
+	ABI: "[{\"inputs\":[{\"internalType\":\"string\",\"name\":\"_publicKey\",\"type\":\"string\"}],\"name\":\"getNodeByKey\",\"outputs\":[{\"components\":[{\"internalType\":\"string\",\"name\":\"publicKey\",\"type\":\"string\"},{\"internalType\":\"string\",\"name\":\"name\",\"type\":\"string\"}],\"internalType\":\"structETNNodeRegistryInterface.NodeMeta\",\"name\":\"\",\"type\":\"tuple\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"getNodes\",\"outputs\":[{\"components\":[{\"internalType\":\"string\",\"name\":\"publicKey\",\"type\":\"string\"},{\"internalType\":\"string\",\"name\":\"name\",\"type\":\"string\"}],\"internalType\":\"structETNNodeRegistryInterface.NodeMeta[]\",\"name\":\"\",\"type\":\"tuple[]\"}],\"stateMutability\":\"view\",\"type\":\"function\"}]",
+}
+
+// ETNNodeRegistryInterfaceABI is the input ABI used to generate the binding from.
+// Deprecated: Use ETNNodeRegistryInterfaceMetaData.ABI instead.
+var ETNNodeRegistryInterfaceABI = ETNNodeRegistryInterfaceMetaData.ABI
+
+// ETNNodeRegistryInterface is an auto generated Go binding around an Ethereum contract.
+type ETNNodeRegistryInterface struct {
+	ETNNodeRegistryInterfaceCaller     // Read-only binding to the contract
+	ETNNodeRegistryInterfaceTransactor // Write-only binding to the contract
+	ETNNodeRegistryInterfaceFilterer   // Log filterer for contract events
+}
+
+// ETNNodeRegistryInterfaceCaller is an auto generated read-only Go binding around an Ethereum contract.
+type ETNNodeRegistryInterfaceCaller struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// ETNNodeRegistryInterfaceTransactor is an auto generated write-only Go binding around an Ethereum contract.
+type ETNNodeRegistryInterfaceTransactor struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// ETNNodeRegistryInterfaceFilterer is an auto generated log filtering Go binding around an Ethereum contract events.
+type ETNNodeRegistryInterfaceFilterer struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// ETNNodeRegistryInterfaceSession is an auto generated Go binding around an Ethereum contract,
+// with pre-set call and transact options.
+type ETNNodeRegistryInterfaceSession struct {
+	Contract     *ETNNodeRegistryInterface // Generic contract binding to set the session for
+	CallOpts     bind.CallOpts             // Call options to use throughout this session
+	TransactOpts bind.TransactOpts         // Transaction auth options to use throughout this session
+}
+
+// ETNNodeRegistryInterfaceCallerSession is an auto generated read-only Go binding around an Ethereum contract,
+// with pre-set call options.
+type ETNNodeRegistryInterfaceCallerSession struct {
+	Contract *ETNNodeRegistryInterfaceCaller // Generic contract caller binding to set the session for
+	CallOpts bind.CallOpts                   // Call options to use throughout this session
+}
+
+// ETNNodeRegistryInterfaceTransactorSession is an auto generated write-only Go binding around an Ethereum contract,
+// with pre-set transact options.
+type ETNNodeRegistryInterfaceTransactorSession struct {
+	Contract     *ETNNodeRegistryInterfaceTransactor // Generic contract transactor binding to set the session for
+	TransactOpts bind.TransactOpts                   // Transaction auth options to use throughout this session
+}
+
+// ETNNodeRegistryInterfaceRaw is an auto generated low-level Go binding around an Ethereum contract.
+type ETNNodeRegistryInterfaceRaw struct {
+	Contract *ETNNodeRegistryInterface // Generic contract binding to access the raw methods on
+}
+
+// ETNNodeRegistryInterfaceCallerRaw is an auto generated low-level read-only Go binding around an Ethereum contract.
+type ETNNodeRegistryInterfaceCallerRaw struct {
+	Contract *ETNNodeRegistryInterfaceCaller // Generic read-only contract binding to access the raw methods on
+}
+
+// ETNNodeRegistryInterfaceTransactorRaw is an auto generated low-level write-only Go binding around an Ethereum contract.
+type ETNNodeRegistryInterfaceTransactorRaw struct {
+	Contract *ETNNodeRegistryInterfaceTransactor // Generic write-only contract binding to access the raw methods on
+}
+
+// NewETNNodeRegistryInterface creates a new instance of ETNNodeRegistryInterface, bound to a specific deployed contract.
+func NewETNNodeRegistryInterface(address common.Address, backend bind.ContractBackend) (*ETNNodeRegistryInterface, error) {
+	contract, err := bindETNNodeRegistryInterface(address, backend, backend, backend)
+	if err != nil {
+		return nil, err
+	}
+	return &ETNNodeRegistryInterface{ETNNodeRegistryInterfaceCaller: ETNNodeRegistryInterfaceCaller{contract: contract}, ETNNodeRegistryInterfaceTransactor: ETNNodeRegistryInterfaceTransactor{contract: contract}, ETNNodeRegistryInterfaceFilterer: ETNNodeRegistryInterfaceFilterer{contract: contract}}, nil
+}
+
+// NewETNNodeRegistryInterfaceCaller creates a new read-only instance of ETNNodeRegistryInterface, bound to a specific deployed contract.
+func NewETNNodeRegistryInterfaceCaller(address common.Address, caller bind.ContractCaller) (*ETNNodeRegistryInterfaceCaller, error) {
+	contract, err := bindETNNodeRegistryInterface(address, caller, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &ETNNodeRegistryInterfaceCaller{contract: contract}, nil
+}
+
+// NewETNNodeRegistryInterfaceTransactor creates a new write-only instance of ETNNodeRegistryInterface, bound to a specific deployed contract.
+func NewETNNodeRegistryInterfaceTransactor(address common.Address, transactor bind.ContractTransactor) (*ETNNodeRegistryInterfaceTransactor, error) {
+	contract, err := bindETNNodeRegistryInterface(address, nil, transactor, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &ETNNodeRegistryInterfaceTransactor{contract: contract}, nil
+}
+
+// NewETNNodeRegistryInterfaceFilterer creates a new log filterer instance of ETNNodeRegistryInterface, bound to a specific deployed contract.
+func NewETNNodeRegistryInterfaceFilterer(address common.Address, filterer bind.ContractFilterer) (*ETNNodeRegistryInterfaceFilterer, error) {
+	contract, err := bindETNNodeRegistryInterface(address, nil, nil, filterer)
+	if err != nil {
+		return nil, err
+	}
+	return &ETNNodeRegistryInterfaceFilterer{contract: contract}, nil
+}
+
+// bindETNNodeRegistryInterface binds a generic wrapper to an already deployed contract.
+func bindETNNodeRegistryInterface(address common.Address, caller bind.ContractCaller, transactor bind.ContractTransactor, filterer bind.ContractFilterer) (*bind.BoundContract, error) {
+	parsed, err := abi.JSON(strings.NewReader(ETNNodeRegistryInterfaceABI))
+	if err != nil {
+		return nil, err
+	}
+	return bind.NewBoundContract(address, parsed, caller, transactor, filterer), nil
+}
+
+// Call invokes the (constant) contract method with params as input values and
+// sets the output to result. The result type might be a single field for simple
+// returns, a slice of interfaces for anonymous returns and a struct for named
+// returns.
+func (_ETNNodeRegistryInterface *ETNNodeRegistryInterfaceRaw) Call(opts *bind.CallOpts, result *[]interface{}, method string, params ...interface{}) error {
+	return _ETNNodeRegistryInterface.Contract.ETNNodeRegistryInterfaceCaller.contract.Call(opts, result, method, params...)
+}
+
+// Transfer initiates a plain transaction to move funds to the contract, calling
+// its default method if one is available.
+func (_ETNNodeRegistryInterface *ETNNodeRegistryInterfaceRaw) Transfer(opts *bind.TransactOpts) (*types.Transaction, error) {
+	return _ETNNodeRegistryInterface.Contract.ETNNodeRegistryInterfaceTransactor.contract.Transfer(opts)
+}
+
+// Transact invokes the (paid) contract method with params as input values.
+func (_ETNNodeRegistryInterface *ETNNodeRegistryInterfaceRaw) Transact(opts *bind.TransactOpts, method string, params ...interface{}) (*types.Transaction, error) {
+	return _ETNNodeRegistryInterface.Contract.ETNNodeRegistryInterfaceTransactor.contract.Transact(opts, method, params...)
+}
+
+// Call invokes the (constant) contract method with params as input values and
+// sets the output to result. The result type might be a single field for simple
+// returns, a slice of interfaces for anonymous returns and a struct for named
+// returns.
+func (_ETNNodeRegistryInterface *ETNNodeRegistryInterfaceCallerRaw) Call(opts *bind.CallOpts, result *[]interface{}, method string, params ...interface{}) error {
+	return _ETNNodeRegistryInterface.Contract.contract.Call(opts, result, method, params...)
+}
+
+// Transfer initiates a plain transaction to move funds to the contract, calling
+// its default method if one is available.
+func (_ETNNodeRegistryInterface *ETNNodeRegistryInterfaceTransactorRaw) Transfer(opts *bind.TransactOpts) (*types.Transaction, error) {
+	return _ETNNodeRegistryInterface.Contract.contract.Transfer(opts)
+}
+
+// Transact invokes the (paid) contract method with params as input values.
+func (_ETNNodeRegistryInterface *ETNNodeRegistryInterfaceTransactorRaw) Transact(opts *bind.TransactOpts, method string, params ...interface{}) (*types.Transaction, error) {
+	return _ETNNodeRegistryInterface.Contract.contract.Transact(opts, method, params...)
+}
+
+// GetNodeByKey is a free data retrieval call binding the contract method 0x049006df.
+//
+// Solidity: function getNodeByKey(string _publicKey) view returns((string,string))
+func (_ETNNodeRegistryInterface *ETNNodeRegistryInterfaceCaller) GetNodeByKey(opts *bind.CallOpts, _publicKey string) (ETNNodeRegistryInterfaceNodeMeta, error) {
+	var out []interface{}
+	err := _ETNNodeRegistryInterface.contract.Call(opts, &out, "getNodeByKey", _publicKey)
+
+	if err != nil {
+		return *new(ETNNodeRegistryInterfaceNodeMeta), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(ETNNodeRegistryInterfaceNodeMeta)).(*ETNNodeRegistryInterfaceNodeMeta)
+
+	return out0, err
+
+}
+
+// GetNodeByKey is a free data retrieval call binding the contract method 0x049006df.
+//
+// Solidity: function getNodeByKey(string _publicKey) view returns((string,string))
+func (_ETNNodeRegistryInterface *ETNNodeRegistryInterfaceSession) GetNodeByKey(_publicKey string) (ETNNodeRegistryInterfaceNodeMeta, error) {
+	return _ETNNodeRegistryInterface.Contract.GetNodeByKey(&_ETNNodeRegistryInterface.CallOpts, _publicKey)
+}
+
+// GetNodeByKey is a free data retrieval call binding the contract method 0x049006df.
+//
+// Solidity: function getNodeByKey(string _publicKey) view returns((string,string))
+func (_ETNNodeRegistryInterface *ETNNodeRegistryInterfaceCallerSession) GetNodeByKey(_publicKey string) (ETNNodeRegistryInterfaceNodeMeta, error) {
+	return _ETNNodeRegistryInterface.Contract.GetNodeByKey(&_ETNNodeRegistryInterface.CallOpts, _publicKey)
+}
+
+// GetNodes is a free data retrieval call binding the contract method 0xe29581aa.
+//
+// Solidity: function getNodes() view returns((string,string)[])
+func (_ETNNodeRegistryInterface *ETNNodeRegistryInterfaceCaller) GetNodes(opts *bind.CallOpts) ([]ETNNodeRegistryInterfaceNodeMeta, error) {
+	var out []interface{}
+	err := _ETNNodeRegistryInterface.contract.Call(opts, &out, "getNodes")
+
+	if err != nil {
+		return *new([]ETNNodeRegistryInterfaceNodeMeta), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new([]ETNNodeRegistryInterfaceNodeMeta)).(*[]ETNNodeRegistryInterfaceNodeMeta)
+
+	return out0, err
+
+}
+
+// GetNodes is a free data retrieval call binding the contract method 0xe29581aa.
+//
+// Solidity: function getNodes() view returns((string,string)[])
+func (_ETNNodeRegistryInterface *ETNNodeRegistryInterfaceSession) GetNodes() ([]ETNNodeRegistryInterfaceNodeMeta, error) {
+	return _ETNNodeRegistryInterface.Contract.GetNodes(&_ETNNodeRegistryInterface.CallOpts)
+}
+
+// GetNodes is a free data retrieval call binding the contract method 0xe29581aa.
+//
+// Solidity: function getNodes() view returns((string,string)[])
+func (_ETNNodeRegistryInterface *ETNNodeRegistryInterfaceCallerSession) GetNodes() ([]ETNNodeRegistryInterfaceNodeMeta, error) {
+	return _ETNNodeRegistryInterface.Contract.GetNodes(&_ETNNodeRegistryInterface.CallOpts)
+}
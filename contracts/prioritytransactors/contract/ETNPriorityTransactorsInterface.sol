@@ -9,6 +9,20 @@ interface ETNPriorityTransactorsInterface {
         string name;
     }
 
+    struct TransactorLimits {
+        string publicKey;
+        uint64 maxGasPerBlock;
+        uint64 maxPendingTxs;
+        uint64 maxWaivedGasPerEpoch;
+    }
+
+    struct EntityThreshold {
+        string name;
+        uint64 threshold;
+    }
+
     function getTransactors() external view returns (TransactorMeta[] memory);
     function getTransactorByKey(string memory _publicKey) external view returns (TransactorMeta memory);
+    function getTransactorLimits() external view returns (TransactorLimits[] memory);
+    function getEntityThresholds() external view returns (EntityThreshold[] memory);
 }
\ No newline at end of file
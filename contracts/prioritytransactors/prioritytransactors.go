@@ -28,6 +28,20 @@ var (
 	_ = event.NewSubscription
 )
 
+// ETNPriorityTransactorsInterfaceEntityThreshold is an auto generated low-level Go binding around an user-defined struct.
+type ETNPriorityTransactorsInterfaceEntityThreshold struct {
+	Name      string
+	Threshold uint64
+}
+
+// ETNPriorityTransactorsInterfaceTransactorLimits is an auto generated low-level Go binding around an user-defined struct.
+type ETNPriorityTransactorsInterfaceTransactorLimits struct {
+	PublicKey            string
+	MaxGasPerBlock       uint64
+	MaxPendingTxs        uint64
+	MaxWaivedGasPerEpoch uint64
+}
+
 // ETNPriorityTransactorsInterfaceTransactorMeta is an auto generated low-level Go binding around an user-defined struct.
 type ETNPriorityTransactorsInterfaceTransactorMeta struct {
 	IsGasPriceWaiver bool
@@ -37,7 +51,7 @@ type ETNPriorityTransactorsInterfaceTransactorMeta struct {
 
 // ETNPriorityTransactorsInterfaceMetaData contains all meta data concerning the ETNPriorityTransactorsInterface contract.
 var ETNPriorityTransactorsInterfaceMetaData = &bind.MetaData{
-	ABI: "[{\"inputs\":[{\"internalType\":\"string\",\"name\":\"_publicKey\",\"type\":\"string\"}],\"name\":\"getTransactorByKey\",\"outputs\":[{\"components\":[{\"internalType\":\"bool\",\"name\":\"isGasPriceWaiver\",\"type\":\"bool\"},{\"internalType\":\"string\",\"name\":\"publicKey\",\"type\":\"string\"},{\"internalType\":\"string\",\"name\":\"name\",\"type\":\"string\"}],\"internalType\":\"structETNPriorityTransactorsInterface.TransactorMeta\",\"name\":\"\",\"type\":\"tuple\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"getTransactors\",\"outputs\":[{\"components\":[{\"internalType\":\"bool\",\"name\":\"isGasPriceWaiver\",\"type\":\"bool\"},{\"internalType\":\"string\",\"name\":\"publicKey\",\"type\":\"string\"},{\"internalType\":\"string\",\"name\":\"name\",\"type\":\"string\"}],\"internalType\":\"structETNPriorityTransactorsInterface.TransactorMeta[]\",\"name\":\"\",\"type\":\"tuple[]\"}],\"stateMutability\":\"view\",\"type\":\"function\"}]",
+	ABI: "[{\"inputs\":[],\"name\":\"getEntityThresholds\",\"outputs\":[{\"components\":[{\"internalType\":\"string\",\"name\":\"name\",\"type\":\"string\"},{\"internalType\":\"uint64\",\"name\":\"threshold\",\"type\":\"uint64\"}],\"internalType\":\"structETNPriorityTransactorsInterface.EntityThreshold[]\",\"name\":\"\",\"type\":\"tuple[]\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"string\",\"name\":\"_publicKey\",\"type\":\"string\"}],\"name\":\"getTransactorByKey\",\"outputs\":[{\"components\":[{\"internalType\":\"bool\",\"name\":\"isGasPriceWaiver\",\"type\":\"bool\"},{\"internalType\":\"string\",\"name\":\"publicKey\",\"type\":\"string\"},{\"internalType\":\"string\",\"name\":\"name\",\"type\":\"string\"}],\"internalType\":\"structETNPriorityTransactorsInterface.TransactorMeta\",\"name\":\"\",\"type\":\"tuple\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"getTransactorLimits\",\"outputs\":[{\"components\":[{\"internalType\":\"string\",\"name\":\"publicKey\",\"type\":\"string\"},{\"internalType\":\"uint64\",\"name\":\"maxGasPerBlock\",\"type\":\"uint64\"},{\"internalType\":\"uint64\",\"name\":\"maxPendingTxs\",\"type\":\"uint64\"},{\"internalType\":\"uint64\",\"name\":\"maxWaivedGasPerEpoch\",\"type\":\"uint64\"}],\"internalType\":\"structETNPriorityTransactorsInterface.TransactorLimits[]\",\"name\":\"\",\"type\":\"tuple[]\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"getTransactors\",\"outputs\":[{\"components\":[{\"internalType\":\"bool\",\"name\":\"isGasPriceWaiver\",\"type\":\"bool\"},{\"internalType\":\"string\",\"name\":\"publicKey\",\"type\":\"string\"},{\"internalType\":\"string\",\"name\":\"name\",\"type\":\"string\"}],\"internalType\":\"structETNPriorityTransactorsInterface.TransactorMeta[]\",\"name\":\"\",\"type\":\"tuple[]\"}],\"stateMutability\":\"view\",\"type\":\"function\"}]",
 }
 
 // ETNPriorityTransactorsInterface is an auto generated Go binding around an Ethereum contract.
@@ -182,6 +196,37 @@ func (_ETNPriorityTransactorsInterface *ETNPriorityTransactorsInterfaceTransacto
 	return _ETNPriorityTransactorsInterface.Contract.contract.Transact(opts, method, params...)
 }
 
+// GetEntityThresholds is a free data retrieval call binding the contract method 0x7338cf69.
+//
+// Solidity: function getEntityThresholds() view returns((string,uint64)[])
+func (_ETNPriorityTransactorsInterface *ETNPriorityTransactorsInterfaceCaller) GetEntityThresholds(opts *bind.CallOpts) ([]ETNPriorityTransactorsInterfaceEntityThreshold, error) {
+	var out []interface{}
+	err := _ETNPriorityTransactorsInterface.contract.Call(opts, &out, "getEntityThresholds")
+
+	if err != nil {
+		return *new([]ETNPriorityTransactorsInterfaceEntityThreshold), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new([]ETNPriorityTransactorsInterfaceEntityThreshold)).(*[]ETNPriorityTransactorsInterfaceEntityThreshold)
+
+	return out0, err
+
+}
+
+// GetEntityThresholds is a free data retrieval call binding the contract method 0x7338cf69.
+//
+// Solidity: function getEntityThresholds() view returns((string,uint64)[])
+func (_ETNPriorityTransactorsInterface *ETNPriorityTransactorsInterfaceSession) GetEntityThresholds() ([]ETNPriorityTransactorsInterfaceEntityThreshold, error) {
+	return _ETNPriorityTransactorsInterface.Contract.GetEntityThresholds(&_ETNPriorityTransactorsInterface.CallOpts)
+}
+
+// GetEntityThresholds is a free data retrieval call binding the contract method 0x7338cf69.
+//
+// Solidity: function getEntityThresholds() view returns((string,uint64)[])
+func (_ETNPriorityTransactorsInterface *ETNPriorityTransactorsInterfaceCallerSession) GetEntityThresholds() ([]ETNPriorityTransactorsInterfaceEntityThreshold, error) {
+	return _ETNPriorityTransactorsInterface.Contract.GetEntityThresholds(&_ETNPriorityTransactorsInterface.CallOpts)
+}
+
 // GetTransactorByKey is a free data retrieval call binding the contract method 0x41e2829b.
 //
 // Solidity: function getTransactorByKey(string _publicKey) view returns((uint64,uint64,bool,string,string))
@@ -213,6 +258,37 @@ func (_ETNPriorityTransactorsInterface *ETNPriorityTransactorsInterfaceCallerSes
 	return _ETNPriorityTransactorsInterface.Contract.GetTransactorByKey(&_ETNPriorityTransactorsInterface.CallOpts, _publicKey)
 }
 
+// GetTransactorLimits is a free data retrieval call binding the contract method 0xb3d19686.
+//
+// Solidity: function getTransactorLimits() view returns((string,uint64,uint64,uint64)[])
+func (_ETNPriorityTransactorsInterface *ETNPriorityTransactorsInterfaceCaller) GetTransactorLimits(opts *bind.CallOpts) ([]ETNPriorityTransactorsInterfaceTransactorLimits, error) {
+	var out []interface{}
+	err := _ETNPriorityTransactorsInterface.contract.Call(opts, &out, "getTransactorLimits")
+
+	if err != nil {
+		return *new([]ETNPriorityTransactorsInterfaceTransactorLimits), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new([]ETNPriorityTransactorsInterfaceTransactorLimits)).(*[]ETNPriorityTransactorsInterfaceTransactorLimits)
+
+	return out0, err
+
+}
+
+// GetTransactorLimits is a free data retrieval call binding the contract method 0xb3d19686.
+//
+// Solidity: function getTransactorLimits() view returns((string,uint64,uint64,uint64)[])
+func (_ETNPriorityTransactorsInterface *ETNPriorityTransactorsInterfaceSession) GetTransactorLimits() ([]ETNPriorityTransactorsInterfaceTransactorLimits, error) {
+	return _ETNPriorityTransactorsInterface.Contract.GetTransactorLimits(&_ETNPriorityTransactorsInterface.CallOpts)
+}
+
+// GetTransactorLimits is a free data retrieval call binding the contract method 0xb3d19686.
+//
+// Solidity: function getTransactorLimits() view returns((string,uint64,uint64,uint64)[])
+func (_ETNPriorityTransactorsInterface *ETNPriorityTransactorsInterfaceCallerSession) GetTransactorLimits() ([]ETNPriorityTransactorsInterfaceTransactorLimits, error) {
+	return _ETNPriorityTransactorsInterface.Contract.GetTransactorLimits(&_ETNPriorityTransactorsInterface.CallOpts)
+}
+
 // GetTransactors is a free data retrieval call binding the contract method 0x2d26b309.
 //
 // Solidity: function getTransactors() view returns((uint64,uint64,bool,string,string)[])
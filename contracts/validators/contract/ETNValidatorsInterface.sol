@@ -0,0 +1,8 @@
+// contracts/ETNValidatorsInterface.sol
+// SPDX-License-Identifier: MIT
+pragma solidity ^0.8.16;
+
+interface ETNValidatorsInterface {
+    function getValidators() external view returns (address[] memory);
+    function getValidatorWeights() external view returns (uint256[] memory);
+}
@@ -0,0 +1,211 @@
+// Code generated - DO NOT EDIT.
+// This file is a generated binding and any manual changes will be lost.
+
+package validators
+
+import (
+	"errors"
+	"math/big"
+	"strings"
+
+	electroneum "github.com/electroneum/electroneum-sc"
+	"github.com/electroneum/electroneum-sc/accounts/abi"
+	"github.com/electroneum/electroneum-sc/accounts/abi/bind"
+	"github.com/electroneum/electroneum-sc/common"
+	"github.com/electroneum/electroneum-sc/core/types"
+	"github.com/electroneum/electroneum-sc/event"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var (
+	_ = errors.New
+	_ = big.NewInt
+	_ = strings.NewReader
+	_ = electroneum.NotFound
+	_ = bind.Bind
+	_ = common.Big1
+	_ = types.BloomLookup
+	_ = event.NewSubscription
+)
+
+// ETNValidatorsInterfaceMetaData contains all meta data concerning the ETNValidatorsInterface contract.
+var ETNValidatorsInterfaceMetaData = &bind.MetaData{
+	ABI: "[{\"inputs\":[],\"name\":\"getValidators\",\"outputs\":[{\"internalType\":\"address[]\",\"name\":\"\",\"type\":\"address[]\"}],\"stateMutability\":\"view\",\"type\":\"function\"}]",
+}
+
+// ETNValidatorsInterfaceABI is the input ABI used to generate the binding from.
+// Deprecated: Use ETNValidatorsInterfaceMetaData.ABI instead.
+var ETNValidatorsInterfaceABI = ETNValidatorsInterfaceMetaData.ABI
+
+// ETNValidatorsInterface is an auto generated Go binding around an Ethereum contract.
+type ETNValidatorsInterface struct {
+	ETNValidatorsInterfaceCaller     // Read-only binding to the contract
+	ETNValidatorsInterfaceTransactor // Write-only binding to the contract
+	ETNValidatorsInterfaceFilterer   // Log filterer for contract events
+}
+
+// ETNValidatorsInterfaceCaller is an auto generated read-only Go binding around an Ethereum contract.
+type ETNValidatorsInterfaceCaller struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// ETNValidatorsInterfaceTransactor is an auto generated write-only Go binding around an Ethereum contract.
+type ETNValidatorsInterfaceTransactor struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// ETNValidatorsInterfaceFilterer is an auto generated log filtering Go binding around an Ethereum contract events.
+type ETNValidatorsInterfaceFilterer struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// ETNValidatorsInterfaceSession is an auto generated Go binding around an Ethereum contract,
+// with pre-set call and transact options.
+type ETNValidatorsInterfaceSession struct {
+	Contract     *ETNValidatorsInterface // Generic contract binding to set the session for
+	CallOpts     bind.CallOpts           // Call options to use throughout this session
+	TransactOpts bind.TransactOpts       // Transaction auth options to use throughout this session
+}
+
+// ETNValidatorsInterfaceCallerSession is an auto generated read-only Go binding around an Ethereum contract,
+// with pre-set call options.
+type ETNValidatorsInterfaceCallerSession struct {
+	Contract *ETNValidatorsInterfaceCaller // Generic contract caller binding to set the session for
+	CallOpts bind.CallOpts                 // Call options to use throughout this session
+}
+
+// ETNValidatorsInterfaceTransactorSession is an auto generated write-only Go binding around an Ethereum contract,
+// with pre-set transact options.
+type ETNValidatorsInterfaceTransactorSession struct {
+	Contract     *ETNValidatorsInterfaceTransactor // Generic contract transactor binding to set the session for
+	TransactOpts bind.TransactOpts                 // Transaction auth options to use throughout this session
+}
+
+// ETNValidatorsInterfaceRaw is an auto generated low-level Go binding around an Ethereum contract.
+type ETNValidatorsInterfaceRaw struct {
+	Contract *ETNValidatorsInterface // Generic contract binding to access the raw methods on
+}
+
+// ETNValidatorsInterfaceCallerRaw is an auto generated low-level read-only Go binding around an Ethereum contract.
+type ETNValidatorsInterfaceCallerRaw struct {
+	Contract *ETNValidatorsInterfaceCaller // Generic read-only contract binding to access the raw methods on
+}
+
+// ETNValidatorsInterfaceTransactorRaw is an auto generated low-level write-only Go binding around an Ethereum contract.
+type ETNValidatorsInterfaceTransactorRaw struct {
+	Contract *ETNValidatorsInterfaceTransactor // Generic write-only contract binding to access the raw methods on
+}
+
+// NewETNValidatorsInterface creates a new instance of ETNValidatorsInterface, bound to a specific deployed contract.
+func NewETNValidatorsInterface(address common.Address, backend bind.ContractBackend) (*ETNValidatorsInterface, error) {
+	contract, err := bindETNValidatorsInterface(address, backend, backend, backend)
+	if err != nil {
+		return nil, err
+	}
+	return &ETNValidatorsInterface{ETNValidatorsInterfaceCaller: ETNValidatorsInterfaceCaller{contract: contract}, ETNValidatorsInterfaceTransactor: ETNValidatorsInterfaceTransactor{contract: contract}, ETNValidatorsInterfaceFilterer: ETNValidatorsInterfaceFilterer{contract: contract}}, nil
+}
+
+// NewETNValidatorsInterfaceCaller creates a new read-only instance of ETNValidatorsInterface, bound to a specific deployed contract.
+func NewETNValidatorsInterfaceCaller(address common.Address, caller bind.ContractCaller) (*ETNValidatorsInterfaceCaller, error) {
+	contract, err := bindETNValidatorsInterface(address, caller, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &ETNValidatorsInterfaceCaller{contract: contract}, nil
+}
+
+// NewETNValidatorsInterfaceTransactor creates a new write-only instance of ETNValidatorsInterface, bound to a specific deployed contract.
+func NewETNValidatorsInterfaceTransactor(address common.Address, transactor bind.ContractTransactor) (*ETNValidatorsInterfaceTransactor, error) {
+	contract, err := bindETNValidatorsInterface(address, nil, transactor, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &ETNValidatorsInterfaceTransactor{contract: contract}, nil
+}
+
+// NewETNValidatorsInterfaceFilterer creates a new log filterer instance of ETNValidatorsInterface, bound to a specific deployed contract.
+func NewETNValidatorsInterfaceFilterer(address common.Address, filterer bind.ContractFilterer) (*ETNValidatorsInterfaceFilterer, error) {
+	contract, err := bindETNValidatorsInterface(address, nil, nil, filterer)
+	if err != nil {
+		return nil, err
+	}
+	return &ETNValidatorsInterfaceFilterer{contract: contract}, nil
+}
+
+// bindETNValidatorsInterface binds a generic wrapper to an already deployed contract.
+func bindETNValidatorsInterface(address common.Address, caller bind.ContractCaller, transactor bind.ContractTransactor, filterer bind.ContractFilterer) (*bind.BoundContract, error) {
+	parsed, err := abi.JSON(strings.NewReader(ETNValidatorsInterfaceABI))
+	if err != nil {
+		return nil, err
+	}
+	return bind.NewBoundContract(address, parsed, caller, transactor, filterer), nil
+}
+
+// Call invokes the (constant) contract method with params as input values and
+// sets the output to result. The result type might be a single field for simple
+// returns, a slice of interfaces for anonymous returns and a struct for named
+// returns.
+func (_ETNValidatorsInterface *ETNValidatorsInterfaceRaw) Call(opts *bind.CallOpts, result *[]interface{}, method string, params ...interface{}) error {
+	return _ETNValidatorsInterface.Contract.ETNValidatorsInterfaceCaller.contract.Call(opts, result, method, params...)
+}
+
+// Transfer initiates a plain transaction to move funds to the contract, calling
+// its default method if one is available.
+func (_ETNValidatorsInterface *ETNValidatorsInterfaceRaw) Transfer(opts *bind.TransactOpts) (*types.Transaction, error) {
+	return _ETNValidatorsInterface.Contract.ETNValidatorsInterfaceTransactor.contract.Transfer(opts)
+}
+
+// Transact invokes the (paid) contract method with params as input values.
+func (_ETNValidatorsInterface *ETNValidatorsInterfaceRaw) Transact(opts *bind.TransactOpts, method string, params ...interface{}) (*types.Transaction, error) {
+	return _ETNValidatorsInterface.Contract.ETNValidatorsInterfaceTransactor.contract.Transact(opts, method, params...)
+}
+
+// Call invokes the (constant) contract method with params as input values and
+// sets the output to result. The result type might be a single field for simple
+// returns, a slice of interfaces for anonymous returns and a struct for named
+// returns.
+func (_ETNValidatorsInterface *ETNValidatorsInterfaceCallerRaw) Call(opts *bind.CallOpts, result *[]interface{}, method string, params ...interface{}) error {
+	return _ETNValidatorsInterface.Contract.contract.Call(opts, result, method, params...)
+}
+
+// Transfer initiates a plain transaction to move funds to the contract, calling
+// its default method if one is available.
+func (_ETNValidatorsInterface *ETNValidatorsInterfaceTransactorRaw) Transfer(opts *bind.TransactOpts) (*types.Transaction, error) {
+	return _ETNValidatorsInterface.Contract.contract.Transfer(opts)
+}
+
+// Transact invokes the (paid) contract method with params as input values.
+func (_ETNValidatorsInterface *ETNValidatorsInterfaceTransactorRaw) Transact(opts *bind.TransactOpts, method string, params ...interface{}) (*types.Transaction, error) {
+	return _ETNValidatorsInterface.Contract.contract.Transact(opts, method, params...)
+}
+
+// GetValidators is a free data retrieval call binding the contract method 0xb7ab4db5.
+//
+// Solidity: function getValidators() view returns(address[])
+func (_ETNValidatorsInterface *ETNValidatorsInterfaceCaller) GetValidators(opts *bind.CallOpts) ([]common.Address, error) {
+	var out []interface{}
+	err := _ETNValidatorsInterface.contract.Call(opts, &out, "getValidators")
+
+	if err != nil {
+		return *new([]common.Address), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new([]common.Address)).(*[]common.Address)
+
+	return out0, err
+
+}
+
+// GetValidators is a free data retrieval call binding the contract method 0xb7ab4db5.
+//
+// Solidity: function getValidators() view returns(address[])
+func (_ETNValidatorsInterface *ETNValidatorsInterfaceSession) GetValidators() ([]common.Address, error) {
+	return _ETNValidatorsInterface.Contract.GetValidators(&_ETNValidatorsInterface.CallOpts)
+}
+
+// GetValidators is a free data retrieval call binding the contract method 0xb7ab4db5.
+//
+// Solidity: function getValidators() view returns(address[])
+func (_ETNValidatorsInterface *ETNValidatorsInterfaceCallerSession) GetValidators() ([]common.Address, error) {
+	return _ETNValidatorsInterface.Contract.GetValidators(&_ETNValidatorsInterface.CallOpts)
+}
@@ -0,0 +1,87 @@
+// Copyright 2023 Electroneum Ltd
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package core
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/electroneum/electroneum-sc/accounts/abi"
+	"github.com/electroneum/electroneum-sc/common"
+	"github.com/electroneum/electroneum-sc/contracts/accountpermissions"
+	"github.com/electroneum/electroneum-sc/core/vm"
+	"github.com/electroneum/electroneum-sc/params"
+)
+
+// AccountPermissions is what an account is allowed to do by the account
+// permissions contract.
+type AccountPermissions struct {
+	CanTransact bool // Whether the account may send transactions at all
+	CanDeploy   bool // Whether the account may send contract creations
+}
+
+// allAccountPermissions are the permissions of every account on chains, or at
+// blocks, without an account permissions contract.
+var allAccountPermissions = AccountPermissions{CanTransact: true, CanDeploy: true}
+
+// Check returns the error, if any, of an account with these permissions sending
+// a transaction, or a contract creation if create is set.
+func (p AccountPermissions) Check(create bool) error {
+	if !p.CanTransact {
+		return ErrAccountNotPermitted
+	}
+	if create && !p.CanDeploy {
+		return ErrDeployNotPermitted
+	}
+	return nil
+}
+
+// GetAccountPermissions returns the permissions of the given account in the
+// current state using the account permissions contract address for the block
+// number passed. Every account is fully permitted without a contract.
+func GetAccountPermissions(evm *vm.EVM, account common.Address) (AccountPermissions, error) {
+	var (
+		address = evm.ChainConfig().GetAccountPermissionsContractAddress(evm.Context.BlockNumber)
+		method  = "getAccountPermissions"
+	)
+	if address == (common.Address{}) || len(evm.StateDB.GetCode(address)) == 0 {
+		return allAccountPermissions, nil
+	}
+	contractABI, _ := abi.JSON(strings.NewReader(accountpermissions.ETNAccountPermissionsInterfaceMetaData.ABI))
+	input, _ := contractABI.Pack(method, account)
+	output, _, err := evm.StaticCall(vm.AccountRef(address), address, input, params.MaxGasLimit)
+	if err != nil {
+		return AccountPermissions{}, fmt.Errorf("error getting the account permissions from the EVM/contract: %s", err)
+	}
+	unpackResult, err := contractABI.Unpack(method, output)
+	if err != nil {
+		return AccountPermissions{}, fmt.Errorf("error getting the account permissions from the EVM/contract: %s", err)
+	}
+	permissions := abi.ConvertType(unpackResult[0], new(accountpermissions.ETNAccountPermissionsInterfaceAccountPermissions)).(*accountpermissions.ETNAccountPermissionsInterfaceAccountPermissions)
+	return AccountPermissions{CanTransact: permissions.CanTransact, CanDeploy: permissions.CanDeploy}, nil
+}
+
+// CheckAccountPermissions returns the error, if any, of the given account not
+// being permitted to send a transaction, or a contract creation if create is
+// set, by the account permissions contract.
+func CheckAccountPermissions(evm *vm.EVM, account common.Address, create bool) error {
+	permissions, err := GetAccountPermissions(evm, account)
+	if err != nil {
+		return err
+	}
+	return permissions.Check(create)
+}
@@ -99,6 +99,12 @@ func (v *BlockValidator) ValidateState(block *types.Block, statedb *state.StateD
 	if root := statedb.IntermediateRoot(v.config.IsEIP158(header.Number)); header.Root != root {
 		return fmt.Errorf("invalid merkle root (remote: %x local: %x)", header.Root, root)
 	}
+	// Let the engine check any header field derived from the state
+	if verifier, ok := v.engine.(consensus.StateVerifier); ok {
+		if err := verifier.VerifyState(v.bc, header, statedb); err != nil {
+			return err
+		}
+	}
 	return nil
 }
 
@@ -90,6 +90,7 @@ const (
 	txLookupCacheLimit  = 1024
 	maxFutureBlocks     = 256
 	maxTimeFutureBlocks = 30
+	historyExpiryBatch  = 1024 // Minimum number of blocks to expire from the freezer at once
 	TriesInMemory       = 128
 
 	// BlockChainVersion ensures that an incompatible database forces a resync from scratch.
@@ -130,6 +131,7 @@ type CacheConfig struct {
 	TrieTimeLimit       time.Duration // Time limit after which to flush the current in-memory trie to disk
 	SnapshotLimit       int           // Memory allowance (MB) to use for caching snapshot entries in memory
 	Preimages           bool          // Whether to store preimage of trie key to the disk
+	HistoryExpiry       uint64        // Number of recent blocks to keep bodies and receipts of in the freezer (0 = entire chain)
 
 	SnapshotWait bool // Wait for snapshot construction on startup. TODO(karalabe): This is a dirty hack for testing, nuke it
 }
@@ -220,6 +222,10 @@ func NewBlockChain(db ethdb.Database, cacheConfig *CacheConfig, chainConfig *par
 	if cacheConfig == nil {
 		cacheConfig = defaultCacheConfig
 	}
+	scheme := rawdb.ReadStateScheme(db)
+	if scheme == rawdb.PathScheme && cacheConfig.TrieDirtyDisabled {
+		return nil, errors.New("archive mode is not supported by the path-based state scheme")
+	}
 	bodyCache, _ := lru.New(bodyCacheLimit)
 	bodyRLPCache, _ := lru.New(bodyCacheLimit)
 	receiptsCache, _ := lru.New(receiptsCacheLimit)
@@ -236,6 +242,8 @@ func NewBlockChain(db ethdb.Database, cacheConfig *CacheConfig, chainConfig *par
 			Cache:     cacheConfig.TrieCleanLimit,
 			Journal:   cacheConfig.TrieCleanJournal,
 			Preimages: cacheConfig.Preimages,
+			Scheme:    scheme,
+			Dirties:   cacheConfig.TrieDirtyLimit,
 		}),
 		quit:          make(chan struct{}),
 		chainmu:       syncx.NewClosableMutex(),
@@ -393,6 +401,12 @@ func NewBlockChain(db ethdb.Database, cacheConfig *CacheConfig, chainConfig *par
 		go bc.maintainTxIndex(txIndexBlock)
 	}
 
+	// Start ancient history expiry.
+	if bc.cacheConfig.HistoryExpiry > 0 {
+		bc.wg.Add(1)
+		go bc.maintainHistory()
+	}
+
 	// If periodic cache journal is required, spin it up.
 	if bc.cacheConfig.TrieCleanRejournal > 0 {
 		if bc.cacheConfig.TrieCleanRejournal < time.Minute {
@@ -573,7 +587,13 @@ func (bc *BlockChain) setHeadBeyondRoot(head uint64, root common.Hash, repair bo
 							// if the historical chain pruning is enabled. In that case the logic
 							// needs to be improved here.
 							if !bc.HasState(bc.genesisBlock.Root()) {
-								if err := CommitGenesisState(bc.db, bc.genesisBlock.Hash()); err != nil {
+								// The path-based state only holds recent states, drop them
+								// all before rebuilding the genesis state from scratch.
+								triedb := bc.stateCache.TrieDB()
+								if err := triedb.Reset(); err != nil {
+									log.Crit("Failed to reset state", "err", err)
+								}
+								if err := CommitGenesisState(bc.db, triedb, bc.genesisBlock.Hash()); err != nil {
 									log.Crit("Failed to commit genesis state", "err", err)
 								}
 								log.Debug("Recommitted genesis state to disk")
@@ -827,20 +847,26 @@ func (bc *BlockChain) Stop() {
 		}
 	}
 
-	// Ensure the state of a recent block is also stored to disk before exiting.
-	// We're writing three different states to catch different restart scenarios:
-	//  - HEAD:     So we don't need to reprocess any blocks in the general case
-	//  - HEAD-1:   So we don't do large reorgs if our HEAD becomes an uncle
-	//  - HEAD-127: So we have a hard limit on the number of blocks reexecuted
-	if !bc.cacheConfig.TrieDirtyDisabled {
+	// The path-based state keeps the recent states in memory on top of the one
+	// stored on disk, journal them so they survive the restart.
+	if triedb := bc.stateCache.TrieDB(); triedb.Scheme() == rawdb.PathScheme {
+		if err := triedb.Journal(bc.CurrentBlock().Root()); err != nil {
+			log.Error("Failed to journal state", "err", err)
+		}
+	} else if !bc.cacheConfig.TrieDirtyDisabled {
+		// Ensure the state of a recent block is also stored to disk before exiting.
+		// We're writing three different states to catch different restart scenarios:
+		//  - HEAD:     So we don't need to reprocess any blocks in the general case
+		//  - HEAD-1:   So we don't do large reorgs if our HEAD becomes an uncle
+		//  - HEAD-127: So we have a hard limit on the number of blocks reexecuted
 		triedb := bc.stateCache.TrieDB()
 
 		for _, offset := range []uint64{0, 1, TriesInMemory - 1} {
 			if number := bc.CurrentBlock().NumberU64(); number > offset {
-				recent := bc.GetBlockByNumber(number - offset)
+				recent := bc.GetHeaderByNumber(number - offset)
 
-				log.Info("Writing cached state to disk", "block", recent.Number(), "hash", recent.Hash(), "root", recent.Root())
-				if err := triedb.Commit(recent.Root(), true, nil); err != nil {
+				log.Info("Writing cached state to disk", "block", recent.Number, "hash", recent.Hash(), "root", recent.Root)
+				if err := triedb.Commit(recent.Root, true, nil); err != nil {
 					log.Error("Failed to commit recent state trie", "err", err)
 				}
 			}
@@ -1237,6 +1263,11 @@ func (bc *BlockChain) writeBlockWithState(block *types.Block, receipts []*types.
 	}
 	triedb := bc.stateCache.TrieDB()
 
+	// The path-based state overwrites stale nodes in place, the trie database
+	// caps the recent states kept in memory by itself
+	if triedb.Scheme() == rawdb.PathScheme {
+		return nil
+	}
 	// If we're running an archive node, always flush
 	if bc.cacheConfig.TrieDirtyDisabled {
 		return triedb.Commit(root, false, nil)
@@ -2309,6 +2340,87 @@ func (bc *BlockChain) maintainTxIndex(ancients uint64) {
 	}
 }
 
+// maintainHistory is responsible for dropping the bodies and receipts of the
+// ancient blocks beyond the history expiry from the freezer. Headers are kept.
+//
+// Blocks only expire once their transactions are no longer indexed, so the
+// `txlookuplimit` should not exceed the history expiry.
+func (bc *BlockChain) maintainHistory() {
+	defer bc.wg.Done()
+
+	var (
+		done   chan struct{}                  // Non-nil if background expiry routine is active.
+		headCh = make(chan ChainHeadEvent, 1) // Buffered to avoid locking up the event feed
+	)
+	sub := bc.SubscribeChainHeadEvent(headCh)
+	if sub == nil {
+		return
+	}
+	defer sub.Unsubscribe()
+
+	for {
+		select {
+		case head := <-headCh:
+			if done == nil {
+				done = make(chan struct{})
+				go bc.expireHistory(head.Block.NumberU64(), done)
+			}
+		case <-done:
+			done = nil
+		case <-bc.quit:
+			if done != nil {
+				log.Info("Waiting background history expiry to exit")
+				<-done
+			}
+			return
+		}
+	}
+}
+
+// expireHistory drops the bodies and receipts of the ancient blocks beyond the
+// history expiry of the given head, in batches as truncating the freezer tail
+// rewrites its indexes.
+func (bc *BlockChain) expireHistory(head uint64, done chan struct{}) {
+	defer func() { done <- struct{}{} }()
+
+	if head < bc.cacheConfig.HistoryExpiry {
+		return
+	}
+	// Keep the recent blocks, the ones not frozen yet and the ones whose
+	// transactions are still indexed
+	target := head - bc.cacheConfig.HistoryExpiry + 1
+	frozen, err := bc.db.Ancients()
+	if err != nil {
+		return
+	}
+	if target > frozen {
+		target = frozen
+	}
+	indexed := rawdb.ReadTxIndexTail(bc.db)
+	if indexed == nil {
+		return
+	}
+	if target > *indexed {
+		target = *indexed
+	}
+	tail, err := bc.db.Tail()
+	if err != nil || target < tail+min(historyExpiryBatch, bc.cacheConfig.HistoryExpiry) {
+		return
+	}
+	start := time.Now()
+	if err := bc.db.TruncateTail(target); err != nil {
+		log.Error("Failed to expire block history", "tail", target, "err", err)
+		return
+	}
+	// Drop the cached bodies and receipts, which might be expired
+	bc.bodyCache.Purge()
+	bc.bodyRLPCache.Purge()
+	bc.receiptsCache.Purge()
+	bc.blockCache.Purge()
+
+	log.Info("Expired block history", "from", tail, "to", target, "elapsed", common.PrettyDuration(time.Since(start)))
+}
+
 // reportBlock logs a bad block error.
 func (bc *BlockChain) reportBlock(block *types.Block, receipts types.Receipts, err error) {
 	rawdb.WriteBadBlock(bc.db, block)
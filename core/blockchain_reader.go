@@ -367,6 +367,13 @@ func (bc *BlockChain) TxLookupLimit() uint64 {
 	return bc.txLookupLimit
 }
 
+// HistoryExpired returns whether the body and receipts of the given canonical
+// block were dropped from the freezer by the history expiry.
+func (bc *BlockChain) HistoryExpired(number uint64) bool {
+	tail, err := bc.db.Tail()
+	return err == nil && number < tail
+}
+
 // MustGetPriorityTransactorsForState receives the priority transactor list appropriate for the current state using the contra
 func (bc *BlockChain) MustGetPriorityTransactorsForState(header *types.Header, state *state.StateDB) common.PriorityTransactorMap {
 	blockContext := NewEVMBlockContext(header, bc, nil)
@@ -374,6 +381,28 @@ func (bc *BlockChain) MustGetPriorityTransactorsForState(header *types.Header, s
 	return MustGetPriorityTransactors(vmenv)
 }
 
+// GetRegisteredNodesForState returns the nodes allowed to connect to the network
+// by the node registry contract, in the given state.
+func (bc *BlockChain) GetRegisteredNodesForState(header *types.Header, state *state.StateDB) (map[common.PublicKey]string, error) {
+	blockContext := NewEVMBlockContext(header, bc, nil)
+	vmenv := vm.NewEVM(blockContext, vm.TxContext{}, state, bc.chainConfig, bc.vmConfig)
+	return GetRegisteredNodes(vmenv)
+}
+
+// GetAccountPermissionsForState returns the permissions of the given account by
+// the account permissions contract, in the given state, for transactions of the
+// block following the given header.
+func (bc *BlockChain) GetAccountPermissionsForState(header *types.Header, state *state.StateDB, account common.Address) (AccountPermissions, error) {
+	next := new(big.Int).Add(header.Number, common.Big1)
+	if bc.chainConfig.GetAccountPermissionsContractAddress(next) == (common.Address{}) {
+		return allAccountPermissions, nil
+	}
+	blockContext := NewEVMBlockContext(header, bc, nil)
+	blockContext.BlockNumber = next
+	vmenv := vm.NewEVM(blockContext, vm.TxContext{}, state, bc.chainConfig, bc.vmConfig)
+	return GetAccountPermissions(vmenv, account)
+}
+
 // SubscribeRemovedLogsEvent registers a subscription of RemovedLogsEvent.
 func (bc *BlockChain) SubscribeRemovedLogsEvent(ch chan<- RemovedLogsEvent) event.Subscription {
 	return bc.scope.Track(bc.rmLogsFeed.Subscribe(ch))
@@ -40,6 +40,7 @@ import (
 	"github.com/electroneum/electroneum-sc/ethdb"
 	"github.com/electroneum/electroneum-sc/params"
 	"github.com/electroneum/electroneum-sc/trie"
+	"github.com/electroneum/electroneum-sc/trie/pathdb"
 )
 
 // So we can deterministically seed different blockchains
@@ -2938,6 +2939,11 @@ func TestDeleteCreateRevert(t *testing.T) {
 // Expected outcome is that _all_ slots are cleared from A, due to the selfdestruct,
 // and then the new slots exist
 func TestDeleteRecreateSlots(t *testing.T) {
+	t.Run("hash", func(t *testing.T) { testDeleteRecreateSlots(t, rawdb.HashScheme) })
+	t.Run("path", func(t *testing.T) { testDeleteRecreateSlots(t, rawdb.PathScheme) })
+}
+
+func testDeleteRecreateSlots(t *testing.T, scheme string) {
 	var (
 		// Generate a canonical chain to act as the main dataset
 		engine = ethash.NewFaker()
@@ -3032,6 +3038,7 @@ func TestDeleteRecreateSlots(t *testing.T) {
 	})
 	// Import the canonical chain
 	diskdb := rawdb.NewMemoryDatabase()
+	rawdb.WriteStateScheme(diskdb, scheme)
 	gspec.MustCommit(diskdb)
 	chain, err := NewBlockChain(diskdb, nil, params.TestChainConfig, engine, vm.Config{
 		Debug:  true,
@@ -3142,6 +3149,11 @@ func TestDeleteRecreateAccount(t *testing.T) {
 // Expected outcome is that _all_ slots are cleared from A, due to the selfdestruct,
 // and then the new slots exist
 func TestDeleteRecreateSlotsAcrossManyBlocks(t *testing.T) {
+	t.Run("hash", func(t *testing.T) { testDeleteRecreateSlotsAcrossManyBlocks(t, rawdb.HashScheme) })
+	t.Run("path", func(t *testing.T) { testDeleteRecreateSlotsAcrossManyBlocks(t, rawdb.PathScheme) })
+}
+
+func testDeleteRecreateSlotsAcrossManyBlocks(t *testing.T, scheme string) {
 	var (
 		// Generate a canonical chain to act as the main dataset
 		engine = ethash.NewFaker()
@@ -3286,6 +3298,7 @@ func TestDeleteRecreateSlotsAcrossManyBlocks(t *testing.T) {
 	})
 	// Import the canonical chain
 	diskdb := rawdb.NewMemoryDatabase()
+	rawdb.WriteStateScheme(diskdb, scheme)
 	gspec.MustCommit(diskdb)
 	chain, err := NewBlockChain(diskdb, nil, params.TestChainConfig, engine, vm.Config{
 		// Debug:  true,
@@ -3763,3 +3776,241 @@ func TestSetCanonical(t *testing.T) {
 	chain.SetCanonical(canon[TriesInMemory-1])
 	verify(canon[TriesInMemory-1])
 }
+
+// Tests that the bodies and receipts of the blocks beyond the history expiry are
+// dropped from the freezer once their transactions are unindexed, while their
+// headers are kept.
+func TestHistoryExpiry(t *testing.T) {
+	var (
+		gendb   = rawdb.NewMemoryDatabase()
+		key, _  = crypto.HexToECDSA("b71c71a67e1177ad4e901695e1b4b9ee17ae16c6668d313eac2f96dbcda3f291")
+		address = crypto.PubkeyToAddress(key.PublicKey)
+		funds   = big.NewInt(100000000000000000)
+		gspec   = &Genesis{
+			Config:  params.TestChainConfig,
+			Alloc:   GenesisAlloc{address: {Balance: funds}},
+			BaseFee: big.NewInt(params.InitialBaseFee),
+		}
+		genesis = gspec.MustCommit(gendb)
+		signer  = types.LatestSigner(gspec.Config)
+	)
+	blocks, receipts := GenerateChain(gspec.Config, genesis, ethash.NewFaker(), gendb, 128, func(i int, block *BlockGen) {
+		tx, err := types.SignTx(types.NewTransaction(block.TxNonce(address), common.Address{0x00}, big.NewInt(1000), params.TxGas, block.header.BaseFee, nil), signer, key)
+		if err != nil {
+			panic(err)
+		}
+		block.AddTx(tx)
+	})
+	blocks2, _ := GenerateChain(gspec.Config, blocks[len(blocks)-1], ethash.NewFaker(), gendb, 1, nil)
+
+	ancientDb, err := rawdb.NewDatabaseWithFreezer(rawdb.NewMemoryDatabase(), t.TempDir(), "", false)
+	if err != nil {
+		t.Fatalf("failed to create temp freezer db: %v", err)
+	}
+	defer ancientDb.Close()
+	gspec.MustCommit(ancientDb)
+
+	var (
+		limit  = uint64(32)
+		config = *defaultCacheConfig
+	)
+	config.HistoryExpiry = limit
+	chain, err := NewBlockChain(ancientDb, &config, params.TestChainConfig, ethash.NewFaker(), vm.Config{}, nil, &limit)
+	if err != nil {
+		t.Fatalf("failed to create tester chain: %v", err)
+	}
+	defer chain.Stop()
+
+	headers := make([]*types.Header, len(blocks))
+	for i, block := range blocks {
+		headers[i] = block.Header()
+	}
+	if n, err := chain.InsertHeaderChain(headers, 0); err != nil {
+		t.Fatalf("failed to insert header %d: %v", n, err)
+	}
+	if n, err := chain.InsertReceiptChain(blocks, receipts, 128); err != nil {
+		t.Fatalf("block %d: failed to insert into chain: %v", n, err)
+	}
+	if n, err := chain.InsertChain(blocks2); err != nil {
+		t.Fatalf("block %d: failed to insert into chain: %v", n, err)
+	}
+	// Wait for the transactions to be unindexed, and expire the history
+	head := chain.CurrentBlock().NumberU64()
+	want := head - limit + 1
+	for i := 0; ; i++ {
+		if tail := rawdb.ReadTxIndexTail(ancientDb); tail != nil && *tail == want {
+			break
+		}
+		if i == 100 {
+			t.Fatalf("transactions not unindexed")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	chain.expireHistory(head, make(chan struct{}, 1))
+
+	if tail, _ := ancientDb.Tail(); tail != want {
+		t.Fatalf("freezer tail mismatch: have %d, want %d", tail, want)
+	}
+	for _, block := range blocks {
+		number := block.NumberU64()
+		if chain.GetHeaderByNumber(number) == nil {
+			t.Fatalf("header %d missing", number)
+		}
+		expired := number < want
+		if have := chain.GetBlockByNumber(number) == nil; have != expired {
+			t.Errorf("block %d expiry mismatch: have %t, want %t", number, have, expired)
+		}
+		if have := chain.GetReceiptsByHash(block.Hash()) == nil; have != expired {
+			t.Errorf("receipts %d expiry mismatch: have %t, want %t", number, have, expired)
+		}
+		if have := chain.HistoryExpired(number); have != expired {
+			t.Errorf("history %d expired mismatch: have %t, want %t", number, have, expired)
+		}
+	}
+}
+
+// Tests that a chain with the path-based state scheme keeps the recent states
+// in memory, dropping the older ones, and restores them from the journal after
+// a restart.
+func TestPathSchemeStates(t *testing.T) {
+	var (
+		gendb   = rawdb.NewMemoryDatabase()
+		key, _  = crypto.HexToECDSA("b71c71a67e1177ad4e901695e1b4b9ee17ae16c6668d313eac2f96dbcda3f291")
+		address = crypto.PubkeyToAddress(key.PublicKey)
+		funds   = big.NewInt(100000000000000000)
+		gspec   = &Genesis{
+			Config:  params.TestChainConfig,
+			Alloc:   GenesisAlloc{address: {Balance: funds}},
+			BaseFee: big.NewInt(params.InitialBaseFee),
+		}
+		genesis = gspec.MustCommit(gendb)
+		signer  = types.LatestSigner(gspec.Config)
+	)
+	height := 208
+	blocks, _ := GenerateChain(gspec.Config, genesis, ethash.NewFaker(), gendb, height, func(i int, block *BlockGen) {
+		tx, err := types.SignTx(types.NewTransaction(block.TxNonce(address), common.Address{0xaa, byte(i % 16)}, big.NewInt(1000), params.TxGas, block.header.BaseFee, nil), signer, key)
+		if err != nil {
+			panic(err)
+		}
+		block.AddTx(tx)
+	})
+	diskdb := rawdb.NewMemoryDatabase()
+	rawdb.WriteStateScheme(diskdb, rawdb.PathScheme)
+	gspec.MustCommit(diskdb)
+
+	config := *defaultCacheConfig
+	config.SnapshotLimit = 0
+
+	chain, err := NewBlockChain(diskdb, &config, params.TestChainConfig, ethash.NewFaker(), vm.Config{}, nil, nil)
+	if err != nil {
+		t.Fatalf("failed to create tester chain: %v", err)
+	}
+	if n, err := chain.InsertChain(blocks); err != nil {
+		t.Fatalf("block %d: failed to insert into chain: %v", n, err)
+	}
+	check := func(chain *BlockChain) {
+		t.Helper()
+
+		for i, block := range blocks {
+			_, err := chain.StateAt(block.Root())
+			if available := i >= height-129; available != (err == nil) {
+				t.Fatalf("block %d: state availability mismatch: have %v, want %t", block.NumberU64(), err, available)
+			}
+			if err != nil && !errors.Is(err, pathdb.ErrStateUnavailable) {
+				t.Fatalf("block %d: unavailable state error mismatch: have %v, want %v", block.NumberU64(), err, pathdb.ErrStateUnavailable)
+			}
+		}
+		statedb, err := chain.State()
+		if err != nil {
+			t.Fatalf("failed to retrieve head state: %v", err)
+		}
+		for i := 0; i < 16; i++ {
+			if balance := statedb.GetBalance(common.Address{0xaa, byte(i)}); balance.Cmp(big.NewInt(int64(1000*height/16))) != 0 {
+				t.Fatalf("account %d: balance mismatch: have %v, want %v", i, balance, 1000*height/16)
+			}
+		}
+	}
+	check(chain)
+
+	// Restart the chain, the in-memory states are loaded from the journal
+	chain.Stop()
+	chain, err = NewBlockChain(diskdb, &config, params.TestChainConfig, ethash.NewFaker(), vm.Config{}, nil, nil)
+	if err != nil {
+		t.Fatalf("failed to recreate tester chain: %v", err)
+	}
+	defer chain.Stop()
+
+	if head := chain.CurrentBlock().NumberU64(); head != uint64(height) {
+		t.Fatalf("head block mismatch: have %d, want %d", head, height)
+	}
+	check(chain)
+}
+
+// Tests that a block whose state was processed on a copy of the parent state,
+// like the miner does, can be written with the path-based state scheme.
+func TestPathSchemeCopiedState(t *testing.T) {
+	var (
+		gendb   = rawdb.NewMemoryDatabase()
+		key, _  = crypto.HexToECDSA("b71c71a67e1177ad4e901695e1b4b9ee17ae16c6668d313eac2f96dbcda3f291")
+		address = crypto.PubkeyToAddress(key.PublicKey)
+		funds   = big.NewInt(100000000000000000)
+		gspec   = &Genesis{
+			Config:  params.TestChainConfig,
+			Alloc:   GenesisAlloc{address: {Balance: funds}},
+			BaseFee: big.NewInt(params.InitialBaseFee),
+		}
+		genesis = gspec.MustCommit(gendb)
+		signer  = types.LatestSigner(gspec.Config)
+	)
+	blocks, _ := GenerateChain(gspec.Config, genesis, ethash.NewFaker(), gendb, 2, func(i int, block *BlockGen) {
+		tx, err := types.SignTx(types.NewTransaction(block.TxNonce(address), common.Address{0xaa}, big.NewInt(1000), params.TxGas, block.header.BaseFee, nil), signer, key)
+		if err != nil {
+			panic(err)
+		}
+		block.AddTx(tx)
+	})
+	diskdb := rawdb.NewMemoryDatabase()
+	rawdb.WriteStateScheme(diskdb, rawdb.PathScheme)
+	gspec.MustCommit(diskdb)
+
+	config := *defaultCacheConfig
+	config.SnapshotLimit = 0
+
+	chain, err := NewBlockChain(diskdb, &config, params.TestChainConfig, ethash.NewFaker(), vm.Config{}, nil, nil)
+	if err != nil {
+		t.Fatalf("failed to create tester chain: %v", err)
+	}
+	defer chain.Stop()
+
+	if n, err := chain.InsertChain(blocks[:1]); err != nil {
+		t.Fatalf("block %d: failed to insert into chain: %v", n, err)
+	}
+	statedb, err := chain.StateAt(blocks[0].Root())
+	if err != nil {
+		t.Fatalf("failed to retrieve parent state: %v", err)
+	}
+	work := statedb.Copy()
+	receipts, logs, _, err := chain.Processor().Process(blocks[1], work, vm.Config{})
+	if err != nil {
+		t.Fatalf("failed to process block: %v", err)
+	}
+	if _, err := chain.WriteBlockAndSetHead(blocks[1], receipts, logs, work, false); err != nil {
+		t.Fatalf("failed to write block: %v", err)
+	}
+	if _, err := chain.StateAt(blocks[1].Root()); err != nil {
+		t.Fatalf("failed to retrieve written state: %v", err)
+	}
+}
+
+// Tests that archive mode is refused by the path-based state scheme.
+func TestPathSchemeArchive(t *testing.T) {
+	db := rawdb.NewMemoryDatabase()
+	rawdb.WriteStateScheme(db, rawdb.PathScheme)
+	(&Genesis{Config: params.TestChainConfig, BaseFee: big.NewInt(params.InitialBaseFee)}).MustCommit(db)
+
+	config := *defaultCacheConfig
+	config.TrieDirtyDisabled = true
+	if _, err := NewBlockChain(db, &config, params.TestChainConfig, ethash.NewFaker(), vm.Config{}, nil, nil); err == nil {
+		t.Fatalf("archive mode accepted by the path scheme")
+	}
+}
@@ -104,6 +104,10 @@ func (b *BlockGen) AddTxWithChain(bc *BlockChain, tx *types.Transaction) {
 	if b.gasPool == nil {
 		b.SetCoinbase(common.Address{})
 	}
+	if tx.IsPriority() {
+		vmenv := vm.NewEVM(NewEVMBlockContext(b.header, bc, &b.header.Coinbase), vm.TxContext{}, b.statedb, b.config, vm.Config{})
+		b.statedb.SetPriorityTransactors(MustGetPriorityTransactors(vmenv))
+	}
 	b.statedb.Prepare(tx.Hash(), len(b.txs))
 	receipt, err := ApplyTransaction(b.config, bc, &b.header.Coinbase, b.gasPool, b.statedb, b.header, tx, &b.header.GasUsed, vm.Config{})
 	if err != nil {
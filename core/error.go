@@ -32,6 +32,10 @@ var (
 	// ErrNoGenesis is returned when there is no Genesis Block.
 	ErrNoGenesis = errors.New("genesis not found in chain")
 
+	// ErrHistoryExpired is returned when the body or receipts of a block were
+	// dropped by the history expiry.
+	ErrHistoryExpired = errors.New("block history expired")
+
 	errSideChainReceipts = errors.New("side blocks can't be accepted as ancient chain data")
 
 	errBadPrioritySignature = errors.New("priority transaction has an invalid signature")
@@ -39,6 +43,12 @@ var (
 	errBadPriorityKey = errors.New("priority transaction uses a pubkey that is not permitted for priority sending")
 
 	errNoGasPriceWaiver = errors.New("priority sender does not have a gas price waiver but sent a tx with zero gas fee and tip")
+
+	errPriorityEntityMismatch = errors.New("priority transaction co-signed by pubkeys of different priority transactor entities")
+
+	errPriorityThreshold = errors.New("priority transaction co-signed by fewer pubkeys than the signature threshold of the entity")
+
+	errMultiPriorityNotActive = errors.New("multi-priority transactions are not active yet")
 )
 
 // List of evm-call-message pre-checking errors. All state transition messages will
@@ -102,4 +112,12 @@ var (
 
 	// ErrSenderNoEOA is returned if the sender of a transaction is a contract.
 	ErrSenderNoEOA = errors.New("sender not an eoa")
+
+	// ErrAccountNotPermitted is returned if the sender of a transaction is not
+	// allowed to transact by the account permissions contract.
+	ErrAccountNotPermitted = errors.New("sender not permitted to transact")
+
+	// ErrDeployNotPermitted is returned if the sender of a contract creation is
+	// not allowed to deploy contracts by the account permissions contract.
+	ErrDeployNotPermitted = errors.New("sender not permitted to deploy contracts")
 )
@@ -41,3 +41,7 @@ type ChainSideEvent struct {
 }
 
 type ChainHeadEvent struct{ Block *types.Block }
+
+// DropTxsEvent is posted when a batch of transactions is rejected or evicted by
+// the transaction pool.
+type DropTxsEvent struct{ Drops []*TxDrop }
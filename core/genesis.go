@@ -81,9 +81,9 @@ func (ga *GenesisAlloc) UnmarshalJSON(data []byte) error {
 }
 
 // flush adds allocated genesis accounts into a fresh new statedb and
-// commit the state changes into the given database handler.
-func (ga *GenesisAlloc) flush(db ethdb.Database) (common.Hash, error) {
-	statedb, err := state.New(common.Hash{}, state.NewDatabase(db), nil)
+// commit the state changes into the given trie database.
+func (ga *GenesisAlloc) flush(db ethdb.Database, triedb *trie.Database) (common.Hash, error) {
+	statedb, err := state.New(common.Hash{}, state.NewDatabaseWithNodeDB(db, triedb), nil)
 	if err != nil {
 		return common.Hash{}, err
 	}
@@ -118,8 +118,8 @@ func (ga *GenesisAlloc) write(db ethdb.KeyValueWriter, hash common.Hash) error {
 }
 
 // CommitGenesisState loads the stored genesis state with the given block
-// hash and commits them into the given database handler.
-func CommitGenesisState(db ethdb.Database, hash common.Hash) error {
+// hash and commits them into the given trie database.
+func CommitGenesisState(db ethdb.Database, triedb *trie.Database, hash common.Hash) error {
 	var alloc GenesisAlloc
 	blob := rawdb.ReadGenesisState(db, hash)
 	if len(blob) != 0 {
@@ -147,7 +147,7 @@ func CommitGenesisState(db ethdb.Database, hash common.Hash) error {
 			return errors.New("not found")
 		}
 	}
-	_, err := alloc.flush(db)
+	_, err := alloc.flush(db, triedb)
 	return err
 }
 
@@ -251,7 +251,7 @@ func SetupGenesisBlockWithOverride(db ethdb.Database, genesis *Genesis, override
 	// We have the genesis block in database(perhaps in ancient database)
 	// but the corresponding state is missing.
 	header := rawdb.ReadHeader(db, stored, 0)
-	if _, err := state.New(header.Root, state.NewDatabaseWithConfig(db, nil), nil); err != nil {
+	if !trie.NewDatabaseWithConfig(db, genesisTrieConfig(db)).Initialized(header.Root) {
 		if genesis == nil {
 			genesis = DefaultGenesisBlock()
 		}
@@ -333,13 +333,19 @@ func (g *Genesis) configOrDefault(ghash common.Hash) *params.ChainConfig {
 	}
 }
 
+// genesisTrieConfig returns the trie database configuration for committing the
+// genesis state, using the state scheme the database was initialized with.
+func genesisTrieConfig(db ethdb.Database) *trie.Config {
+	return &trie.Config{Preimages: true, Scheme: rawdb.ReadStateScheme(db)}
+}
+
 // ToBlock creates the genesis block and writes state of a genesis specification
 // to the given database (or discards it if nil).
 func (g *Genesis) ToBlock(db ethdb.Database) *types.Block {
 	if db == nil {
 		db = rawdb.NewMemoryDatabase()
 	}
-	root, err := g.Alloc.flush(db)
+	root, err := g.Alloc.flush(db, trie.NewDatabaseWithConfig(db, genesisTrieConfig(db)))
 	if err != nil {
 		panic(err)
 	}
@@ -521,33 +527,52 @@ func DefaultStagenetGenesisBlock() *Genesis {
 	}
 }
 
-// DeveloperGenesisBlock returns the 'geth --dev' genesis block.
-func DeveloperGenesisBlock(period uint64, gasLimit uint64, faucet common.Address) *Genesis {
+// developerPriorityTransactorsAddress is the address the priority transactors
+// contract of the developer chain is deployed at.
+var developerPriorityTransactorsAddress = common.HexToAddress("0x0000000000000000000000000000000000001001")
+
+// DeveloperGenesisBlock returns the 'etn-sc --dev' genesis block, a QBFT chain
+// sealed by the single given validator, with the priority transactors contract
+// pre-deployed to serve the given transactors. A zero period seals blocks on
+// demand, as soon as transactions arrive.
+func DeveloperGenesisBlock(period uint64, gasLimit uint64, faucet common.Address, validator common.Address, transactors common.PriorityTransactorMap) *Genesis {
 	// Override the default period to the user requested one
-	config := *params.AllCliqueProtocolChanges
-	config.Clique = &params.CliqueConfig{
-		Period: period,
-		Epoch:  config.Clique.Epoch,
+	config := *params.AllEthashProtocolChanges
+	config.Ethash = nil
+	config.IBFT = &params.IBFTConfig{
+		BlockPeriodSeconds:       period,
+		EpochLength:              30000,
+		RequestTimeoutSeconds:    10,
+		MaxRequestTimeoutSeconds: 60,
+		AllowedFutureBlockTime:   5,
+		EmissionBlockOffset:      big.NewInt(0),
+	}
+	config.PriorityTransactorsContractAddress = developerPriorityTransactorsAddress
+
+	code, err := PriorityTransactorsCode(transactors)
+	if err != nil {
+		panic(fmt.Sprintf("failed to create developer priority transactors contract: %v", err))
 	}
-
 	// Assemble and return the genesis with the precompiles and faucet pre-funded
 	return &Genesis{
 		Config:     &config,
-		ExtraData:  append(append(make([]byte, 32), faucet[:]...), make([]byte, crypto.SignatureLength)...),
+		ExtraData:  GenerateGenesisExtraDataForIBFTValSet([]common.Address{validator}),
 		GasLimit:   gasLimit,
 		BaseFee:    big.NewInt(params.InitialBaseFee),
 		Difficulty: big.NewInt(1),
+		Mixhash:    types.IstanbulDigest,
 		Alloc: map[common.Address]GenesisAccount{
-			common.BytesToAddress([]byte{1}): {Balance: big.NewInt(1)}, // ECRecover
-			common.BytesToAddress([]byte{2}): {Balance: big.NewInt(1)}, // SHA256
-			common.BytesToAddress([]byte{3}): {Balance: big.NewInt(1)}, // RIPEMD
-			common.BytesToAddress([]byte{4}): {Balance: big.NewInt(1)}, // Identity
-			common.BytesToAddress([]byte{5}): {Balance: big.NewInt(1)}, // ModExp
-			common.BytesToAddress([]byte{6}): {Balance: big.NewInt(1)}, // ECAdd
-			common.BytesToAddress([]byte{7}): {Balance: big.NewInt(1)}, // ECScalarMul
-			common.BytesToAddress([]byte{8}): {Balance: big.NewInt(1)}, // ECPairing
-			common.BytesToAddress([]byte{9}): {Balance: big.NewInt(1)}, // BLAKE2b
-			faucet:                           {Balance: new(big.Int).Sub(new(big.Int).Lsh(big.NewInt(1), 256), big.NewInt(9))},
+			common.BytesToAddress([]byte{1}):    {Balance: big.NewInt(1)}, // ECRecover
+			common.BytesToAddress([]byte{2}):    {Balance: big.NewInt(1)}, // SHA256
+			common.BytesToAddress([]byte{3}):    {Balance: big.NewInt(1)}, // RIPEMD
+			common.BytesToAddress([]byte{4}):    {Balance: big.NewInt(1)}, // Identity
+			common.BytesToAddress([]byte{5}):    {Balance: big.NewInt(1)}, // ModExp
+			common.BytesToAddress([]byte{6}):    {Balance: big.NewInt(1)}, // ECAdd
+			common.BytesToAddress([]byte{7}):    {Balance: big.NewInt(1)}, // ECScalarMul
+			common.BytesToAddress([]byte{8}):    {Balance: big.NewInt(1)}, // ECPairing
+			common.BytesToAddress([]byte{9}):    {Balance: big.NewInt(1)}, // BLAKE2b
+			developerPriorityTransactorsAddress: {Code: code, Balance: new(big.Int)},
+			faucet:                              {Balance: new(big.Int).Sub(new(big.Int).Lsh(big.NewInt(1), 256), big.NewInt(9))},
 		},
 	}
 }
@@ -0,0 +1,63 @@
+// Copyright 2023 Electroneum Ltd
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package core
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/electroneum/electroneum-sc/accounts/abi"
+	"github.com/electroneum/electroneum-sc/common"
+	"github.com/electroneum/electroneum-sc/contracts/noderegistry"
+	"github.com/electroneum/electroneum-sc/core/vm"
+	"github.com/electroneum/electroneum-sc/params"
+)
+
+// ErrNoNodeRegistry is returned when reading the registered nodes of a chain
+// without a node registry contract at the given block.
+var ErrNoNodeRegistry = errors.New("no node registry contract")
+
+// GetRegisteredNodes returns the public keys of the nodes allowed to connect to
+// the network by the node registry contract, along with their names, in the
+// current state using the registry contract address for the block number passed.
+func GetRegisteredNodes(evm *vm.EVM) (map[common.PublicKey]string, error) {
+	var (
+		address = evm.ChainConfig().GetNodeRegistryContractAddress(evm.Context.BlockNumber)
+		method  = "getNodes"
+		result  = make(map[common.PublicKey]string)
+	)
+	// Chains not permissioned on-chain, or not yet, have no registry to read
+	if address == (common.Address{}) || len(evm.StateDB.GetCode(address)) == 0 {
+		return nil, ErrNoNodeRegistry
+	}
+	contractABI, _ := abi.JSON(strings.NewReader(noderegistry.ETNNodeRegistryInterfaceMetaData.ABI))
+	input, _ := contractABI.Pack(method)
+	output, _, err := evm.StaticCall(vm.AccountRef(address), address, input, params.MaxGasLimit)
+	if err != nil {
+		return nil, fmt.Errorf("error getting the registered nodes from the EVM/contract: %s", err)
+	}
+	unpackResult, err := contractABI.Unpack(method, output)
+	if err != nil {
+		return nil, fmt.Errorf("error getting the registered nodes from the EVM/contract: %s", err)
+	}
+	nodes := abi.ConvertType(unpackResult[0], new([]noderegistry.ETNNodeRegistryInterfaceNodeMeta)).(*[]noderegistry.ETNNodeRegistryInterfaceNodeMeta)
+	for _, n := range *nodes {
+		result[common.HexToPublicKey(n.PublicKey)] = n.Name
+	}
+	return result, nil
+}
@@ -0,0 +1,139 @@
+// Copyright 2023 Electroneum Ltd
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package core
+
+import (
+	"math/big"
+
+	"github.com/electroneum/electroneum-sc/common"
+	"github.com/electroneum/electroneum-sc/core/types"
+	"github.com/electroneum/electroneum-sc/params"
+)
+
+// defaultPriorityQuotaEpoch is the length of the epoch over which the waived gas
+// of the priority transactors is limited on chains without an IBFT epoch.
+const defaultPriorityQuotaEpoch = 17280
+
+// PriorityQuotaEpoch returns the first block of the epoch the given block belongs
+// to, as far as the waived gas quotas of the priority transactors are concerned.
+func PriorityQuotaEpoch(config *params.ChainConfig, number uint64) uint64 {
+	length := config.GetEpochLength(new(big.Int).SetUint64(number))
+	if length == 0 {
+		length = defaultPriorityQuotaEpoch
+	}
+	return number - number%length
+}
+
+// BlockWaivedGas returns the gas used by the zero fee priority transactions of the
+// block, grouped by the priority key that signed them.
+func BlockWaivedGas(signer types.Signer, block *types.Block, receipts types.Receipts) map[common.PublicKey]uint64 {
+	waived := make(map[common.PublicKey]uint64)
+	if len(receipts) != len(block.Transactions()) {
+		return waived
+	}
+	for i, tx := range block.Transactions() {
+		if !tx.IsPriority() || !tx.HasZeroFee() {
+			continue
+		}
+		pubkey, err := types.PrioritySender(signer, tx)
+		if err != nil {
+			continue
+		}
+		waived[pubkey] += receipts[i].GasUsed
+	}
+	return waived
+}
+
+// priorityQuotas tracks the gas waived for each priority transactor since the
+// start of the epoch of the chain head. A tracker is never modified once built,
+// so the pool can move it to a new head without holding its lock.
+type priorityQuotas struct {
+	epoch  uint64      // First block of the tracked epoch
+	number uint64      // Number of the last block accounted for
+	head   common.Hash // Last block accounted for
+	waived map[common.PublicKey]uint64
+}
+
+// advance returns the tracker moved to the new head. If the head shares an
+// ancestor with the last block accounted for within the epoch, only the blocks
+// reorged out and in are accounted for, otherwise the epoch is replayed from its
+// first block.
+func (q priorityQuotas) advance(chain blockChain, config *params.ChainConfig, signer types.Signer, head *types.Header) priorityQuotas {
+	number := head.Number.Uint64()
+	epoch := PriorityQuotaEpoch(config, number)
+	if q.waived != nil && q.epoch == epoch {
+		if next, ok := q.reorg(chain, signer, head); ok {
+			return next
+		}
+	}
+	next := priorityQuotas{epoch: epoch, number: number, head: head.Hash(), waived: make(map[common.PublicKey]uint64)}
+
+	// Blocks missing from the database (e.g. after a snap sync) are skipped
+	hash := head.Hash()
+	for n := number; n >= epoch && n > 0; n-- {
+		block := chain.GetBlock(hash, n)
+		if block == nil {
+			break
+		}
+		for pubkey, gas := range BlockWaivedGas(signer, block, chain.GetReceiptsByHash(hash)) {
+			next.waived[pubkey] += gas
+		}
+		hash = block.ParentHash()
+	}
+	return next
+}
+
+// reorg returns the tracker moved to the new head by removing the waived gas of
+// the blocks no longer canonical and adding the one of the new blocks. It fails
+// if the common ancestor lies before the tracked epoch or a block is missing.
+func (q priorityQuotas) reorg(chain blockChain, signer types.Signer, head *types.Header) (priorityQuotas, bool) {
+	if q.head == head.Hash() {
+		return q, true
+	}
+	waived := make(map[common.PublicKey]uint64, len(q.waived))
+	for pubkey, gas := range q.waived {
+		waived[pubkey] = gas
+	}
+	var (
+		old = chain.GetBlock(q.head, q.number)
+		add = chain.GetBlock(head.Hash(), head.Number.Uint64())
+	)
+	for old != nil && add != nil && old.Hash() != add.Hash() {
+		if old.NumberU64() >= add.NumberU64() {
+			if old.NumberU64() < q.epoch {
+				return priorityQuotas{}, false
+			}
+			for pubkey, gas := range BlockWaivedGas(signer, old, chain.GetReceiptsByHash(old.Hash())) {
+				if waived[pubkey] <= gas {
+					delete(waived, pubkey)
+				} else {
+					waived[pubkey] -= gas
+				}
+			}
+			old = chain.GetBlock(old.ParentHash(), old.NumberU64()-1)
+		} else {
+			for pubkey, gas := range BlockWaivedGas(signer, add, chain.GetReceiptsByHash(add.Hash())) {
+				waived[pubkey] += gas
+			}
+			add = chain.GetBlock(add.ParentHash(), add.NumberU64()-1)
+		}
+	}
+	if old == nil || add == nil {
+		return priorityQuotas{}, false
+	}
+	return priorityQuotas{epoch: q.epoch, number: head.Number.Uint64(), head: head.Hash(), waived: waived}, true
+}
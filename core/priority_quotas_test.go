@@ -0,0 +1,116 @@
+// Copyright 2023 Electroneum Ltd
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package core
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/electroneum/electroneum-sc/common"
+	"github.com/electroneum/electroneum-sc/core/types"
+	"github.com/electroneum/electroneum-sc/crypto"
+	"github.com/electroneum/electroneum-sc/params"
+	"github.com/electroneum/electroneum-sc/trie"
+)
+
+// quotaTestChain is a block chain made of the blocks given to it, for testing the
+// waived gas tracker.
+type quotaTestChain struct {
+	*testBlockChain
+	blocks   map[common.Hash]*types.Block
+	receipts map[common.Hash]types.Receipts
+}
+
+func (bc *quotaTestChain) GetBlock(hash common.Hash, number uint64) *types.Block {
+	if block := bc.blocks[hash]; block != nil && block.NumberU64() == number {
+		return block
+	}
+	return nil
+}
+
+func (bc *quotaTestChain) GetReceiptsByHash(hash common.Hash) types.Receipts {
+	return bc.receipts[hash]
+}
+
+// add stores a block on top of the parent, waiving the given gas for each of the
+// transactions.
+func (bc *quotaTestChain) add(parent *types.Block, extra byte, txs []*types.Transaction, gas []uint64) *types.Block {
+	receipts := make(types.Receipts, len(txs))
+	for i := range txs {
+		receipts[i] = &types.Receipt{GasUsed: gas[i]}
+	}
+	header := &types.Header{ParentHash: parent.Hash(), Number: new(big.Int).Add(parent.Number(), common.Big1), Extra: []byte{extra}}
+	block := types.NewBlock(header, txs, nil, receipts, trie.NewStackTrie(nil))
+	bc.blocks[block.Hash()], bc.receipts[block.Hash()] = block, receipts
+	return block
+}
+
+// Tests that the waived gas tracker follows the chain head across reorgs, without
+// modifying the trackers it was moved from.
+func TestPriorityQuotasReorg(t *testing.T) {
+	var (
+		signer   = types.LatestSigner(params.TestChainConfig)
+		key, _   = crypto.GenerateKey()
+		txs      = make([]*types.Transaction, 3)
+		genesis  = types.NewBlock(&types.Header{Number: common.Big0}, nil, nil, nil, trie.NewStackTrie(nil))
+		chain    = &quotaTestChain{blocks: map[common.Hash]*types.Block{genesis.Hash(): genesis}, receipts: make(map[common.Hash]types.Receipts)}
+		pubkey   common.PublicKey
+		waivedAt = func(q priorityQuotas) uint64 { return q.waived[pubkey] }
+	)
+	for i := range txs {
+		txs[i] = priorityTx(uint64(i), 50000, big.NewInt(0), big.NewInt(0), key, priorityPrivateKeys[0])
+	}
+	pubkey, _ = types.PrioritySender(signer, txs[0])
+
+	// genesis -> 1 -> 2a
+	//              -> 2b -> 3b
+	var (
+		block1  = chain.add(genesis, 0, txs[:1], []uint64{1000})
+		block2a = chain.add(block1, 'a', txs[1:2], []uint64{2000})
+		block2b = chain.add(block1, 'b', txs[1:2], []uint64{3000})
+		block3b = chain.add(block2b, 'b', txs[2:3], []uint64{4000})
+	)
+	var q priorityQuotas
+	q1 := q.advance(chain, params.TestChainConfig, signer, block1.Header())
+	if have := waivedAt(q1); have != 1000 {
+		t.Fatalf("waived gas mismatch at block 1: have %d, want %d", have, 1000)
+	}
+	q2a := q1.advance(chain, params.TestChainConfig, signer, block2a.Header())
+	if have := waivedAt(q2a); have != 3000 {
+		t.Fatalf("waived gas mismatch at block 2a: have %d, want %d", have, 3000)
+	}
+	q3b := q2a.advance(chain, params.TestChainConfig, signer, block3b.Header())
+	if have := waivedAt(q3b); have != 8000 {
+		t.Fatalf("waived gas mismatch at block 3b: have %d, want %d", have, 8000)
+	}
+	// Reorging back to a shorter chain drops the waived gas of the removed blocks
+	if have := waivedAt(q3b.advance(chain, params.TestChainConfig, signer, block2a.Header())); have != 3000 {
+		t.Fatalf("waived gas mismatch back at block 2a: have %d, want %d", have, 3000)
+	}
+	// The trackers moved from are left untouched
+	if have := waivedAt(q1); have != 1000 {
+		t.Errorf("waived gas at block 1 modified: have %d, want %d", have, 1000)
+	}
+	if have := waivedAt(q2a); have != 3000 {
+		t.Errorf("waived gas at block 2a modified: have %d, want %d", have, 3000)
+	}
+	// A head not sharing an ancestor in the database replays the epoch
+	delete(chain.blocks, block2a.Hash())
+	if have := waivedAt(q2a.advance(chain, params.TestChainConfig, signer, block3b.Header())); have != 8000 {
+		t.Errorf("waived gas mismatch after replay: have %d, want %d", have, 8000)
+	}
+}
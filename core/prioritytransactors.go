@@ -2,17 +2,20 @@ package core
 
 import (
 	"fmt"
+	"math/big"
 	"strings"
 
 	"github.com/electroneum/electroneum-sc/accounts/abi"
 	"github.com/electroneum/electroneum-sc/common"
 	"github.com/electroneum/electroneum-sc/contracts/prioritytransactors"
+	"github.com/electroneum/electroneum-sc/core/state"
+	"github.com/electroneum/electroneum-sc/core/types"
 	"github.com/electroneum/electroneum-sc/core/vm"
 	"github.com/electroneum/electroneum-sc/params"
 )
 
 // GetPriorityTransactors Gets the priority transactor list for the current state using the priority contract address for the block number passed
-func MustGetPriorityTransactors(evm *vm.EVM) common.PriorityTransactorMap {
+func GetPriorityTransactors(evm *vm.EVM) (common.PriorityTransactorMap, error) {
 	var (
 		blockNumber = evm.Context.BlockNumber
 		config      = evm.ChainConfig()
@@ -26,25 +29,19 @@ func MustGetPriorityTransactors(evm *vm.EVM) common.PriorityTransactorMap {
 		// Check if contract code exists at the address. If it doesn't. We haven't deployed the contract yet, so no error needed.
 		byteCode := evm.StateDB.GetCode(address)
 		if len(byteCode) == 0 {
-			return result
+			return result, nil
 		}
 
 		contractABI, _ := abi.JSON(strings.NewReader(prioritytransactors.ETNPriorityTransactorsInterfaceMetaData.ABI))
 		input, _ := contractABI.Pack(method)
 		output, _, err := evm.StaticCall(contract, address, input, params.MaxGasLimit)
-		// if there is an issue pulling the contract panic as something must be very
-		// wrong, and we don't want an accidental fork or potentially try again and have
-		// an incorrect flow
 		if err != nil {
-			panic(fmt.Errorf("error getting the priority transactors from the EVM/contract: %s", err))
+			return nil, fmt.Errorf("error getting the priority transactors from the EVM/contract: %s", err)
 		}
 
 		unpackResult, err := contractABI.Unpack(method, output)
-		// if there is an issue pulling the contract panic as something must be very
-		// wrong, and we don't want an accidental fork or potentially try again and have
-		// an incorrect flow
 		if err != nil {
-			panic(fmt.Errorf("error getting the priority transactors from the EVM/contract: %s", err))
+			return nil, fmt.Errorf("error getting the priority transactors from the EVM/contract: %s", err)
 		}
 
 		transactorsMeta := abi.ConvertType(unpackResult[0], new([]prioritytransactors.ETNPriorityTransactorsInterfaceTransactorMeta)).(*[]prioritytransactors.ETNPriorityTransactorsInterfaceTransactorMeta)
@@ -54,6 +51,123 @@ func MustGetPriorityTransactors(evm *vm.EVM) common.PriorityTransactorMap {
 				EntityName:       t.Name,
 			}
 		}
+		applyPriorityTransactorLimits(evm, address, contractABI, result)
+		applyPriorityTransactorThresholds(evm, address, contractABI, result)
+	}
+	return result, nil
+}
+
+// MustGetPriorityTransactors is like GetPriorityTransactors, but panics if the
+// transactors can't be read from the contract.
+func MustGetPriorityTransactors(evm *vm.EVM) common.PriorityTransactorMap {
+	result, err := GetPriorityTransactors(evm)
+	// if there is an issue pulling the contract panic as something must be very
+	// wrong, and we don't want an accidental fork or potentially try again and have
+	// an incorrect flow
+	if err != nil {
+		panic(err)
 	}
 	return result
 }
+
+// applyPriorityTransactorLimits sets the quotas of the transactors from the contract. Contracts
+// deployed before the limits were introduced don't implement getTransactorLimits, in which case
+// the transactors are left unlimited.
+func applyPriorityTransactorLimits(evm *vm.EVM, address common.Address, contractABI abi.ABI, transactors common.PriorityTransactorMap) {
+	method := "getTransactorLimits"
+	input, _ := contractABI.Pack(method)
+	output, _, err := evm.StaticCall(vm.AccountRef(address), address, input, params.MaxGasLimit)
+	if err != nil {
+		return
+	}
+	unpackResult, err := contractABI.Unpack(method, output)
+	if err != nil {
+		return
+	}
+	limits := abi.ConvertType(unpackResult[0], new([]prioritytransactors.ETNPriorityTransactorsInterfaceTransactorLimits)).(*[]prioritytransactors.ETNPriorityTransactorsInterfaceTransactorLimits)
+	for _, l := range *limits {
+		key := common.HexToPublicKey(l.PublicKey)
+		if transactor, ok := transactors[key]; ok {
+			transactor.MaxGasPerBlock = l.MaxGasPerBlock
+			transactor.MaxPendingTxs = l.MaxPendingTxs
+			transactor.MaxWaivedGasPerEpoch = l.MaxWaivedGasPerEpoch
+			transactors[key] = transactor
+		}
+	}
+}
+
+// applyPriorityTransactorThresholds sets the signature thresholds of the transactors from the
+// contract, each threshold applying to all the keys of an entity. Contracts deployed before
+// multi-priority transactions were introduced don't implement getEntityThresholds, in which
+// case a single priority signature is enough.
+func applyPriorityTransactorThresholds(evm *vm.EVM, address common.Address, contractABI abi.ABI, transactors common.PriorityTransactorMap) {
+	method := "getEntityThresholds"
+	input, _ := contractABI.Pack(method)
+	output, _, err := evm.StaticCall(vm.AccountRef(address), address, input, params.MaxGasLimit)
+	if err != nil {
+		return
+	}
+	unpackResult, err := contractABI.Unpack(method, output)
+	if err != nil {
+		return
+	}
+	thresholds := abi.ConvertType(unpackResult[0], new([]prioritytransactors.ETNPriorityTransactorsInterfaceEntityThreshold)).(*[]prioritytransactors.ETNPriorityTransactorsInterfaceEntityThreshold)
+	for _, t := range *thresholds {
+		if t.Name == "" {
+			continue
+		}
+		for key, transactor := range transactors {
+			if transactor.EntityName == t.Name {
+				transactor.SignatureThreshold = t.Threshold
+				transactors[key] = transactor
+			}
+		}
+	}
+}
+
+// ValidatePrioritySenders checks that the priority keys that co-signed a transaction
+// are allowed to authorise it: all the keys must be registered transactors of the
+// same entity, and at least as many as the signature threshold of the entity must
+// have signed. Keys without an entity name can only authorise transactions alone.
+// It returns the transactor of the first key, whose quotas apply to the transaction.
+func ValidatePrioritySenders(pubkeys []common.PublicKey, lookup func(common.PublicKey) (common.PriorityTransactor, bool)) (common.PriorityTransactor, error) {
+	if len(pubkeys) == 0 {
+		return common.PriorityTransactor{}, errBadPrioritySignature
+	}
+	first, found := lookup(pubkeys[0])
+	if !found {
+		return common.PriorityTransactor{}, errBadPriorityKey
+	}
+	for _, pubkey := range pubkeys[1:] {
+		transactor, found := lookup(pubkey)
+		if !found {
+			return common.PriorityTransactor{}, errBadPriorityKey
+		}
+		if first.EntityName == "" || transactor.EntityName != first.EntityName {
+			return common.PriorityTransactor{}, errPriorityEntityMismatch
+		}
+	}
+	if uint64(len(pubkeys)) < first.SignatureThreshold {
+		return common.PriorityTransactor{}, errPriorityThreshold
+	}
+	return first, nil
+}
+
+// validatePriorityTransaction checks that a priority transaction may be applied
+// on top of the given state, which holds the priority transactors of the block.
+func validatePriorityTransaction(config *params.ChainConfig, number *big.Int, statedb *state.StateDB, tx *types.Transaction, msg types.Message) error {
+	if tx.Type() == types.MultiPriorityTxType && !config.IsMultiPriority(number) {
+		return errMultiPriorityNotActive
+	}
+	if len(msg.PrioritySenders()) > params.MaxPrioritySignatures {
+		return types.ErrTooManyPrioritySigs
+	}
+	transactor, err := ValidatePrioritySenders(msg.PrioritySenders(), statedb.GetPriorityTransactorByKey)
+	if err != nil {
+		return err
+	}
+	if !transactor.IsGasPriceWaiver && tx.HasZeroFee() {
+		return errNoGasPriceWaiver
+	}
+	return nil
+}
@@ -0,0 +1,120 @@
+// Copyright 2023 Electroneum Ltd
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package core
+
+import (
+	"errors"
+	"sort"
+
+	"github.com/electroneum/electroneum-sc/common"
+	"github.com/electroneum/electroneum-sc/contracts/prioritytransactors"
+	"github.com/electroneum/electroneum-sc/core/vm"
+)
+
+// PriorityTransactorsCode returns the runtime code of a contract serving the given
+// transactors through the priority transactors contract ABI. Each method returns
+// its output, encoded ahead of time, and any other call reverts. It's meant to be
+// deployed in the genesis of development and simulated chains.
+func PriorityTransactorsCode(transactors common.PriorityTransactorMap) ([]byte, error) {
+	contractABI, err := prioritytransactors.ETNPriorityTransactorsInterfaceMetaData.GetAbi()
+	if err != nil {
+		return nil, err
+	}
+	var (
+		metas      []prioritytransactors.ETNPriorityTransactorsInterfaceTransactorMeta
+		limits     []prioritytransactors.ETNPriorityTransactorsInterfaceTransactorLimits
+		thresholds []prioritytransactors.ETNPriorityTransactorsInterfaceEntityThreshold
+		entities   = make(map[string]bool)
+	)
+	for pubkey, transactor := range transactors {
+		metas = append(metas, prioritytransactors.ETNPriorityTransactorsInterfaceTransactorMeta{
+			IsGasPriceWaiver: transactor.IsGasPriceWaiver,
+			PublicKey:        pubkey.ToHexString(),
+			Name:             transactor.EntityName,
+		})
+		limits = append(limits, prioritytransactors.ETNPriorityTransactorsInterfaceTransactorLimits{
+			PublicKey:            pubkey.ToHexString(),
+			MaxGasPerBlock:       transactor.MaxGasPerBlock,
+			MaxPendingTxs:        transactor.MaxPendingTxs,
+			MaxWaivedGasPerEpoch: transactor.MaxWaivedGasPerEpoch,
+		})
+		if transactor.EntityName != "" && transactor.SignatureThreshold != 0 && !entities[transactor.EntityName] {
+			entities[transactor.EntityName] = true
+			thresholds = append(thresholds, prioritytransactors.ETNPriorityTransactorsInterfaceEntityThreshold{
+				Name:      transactor.EntityName,
+				Threshold: transactor.SignatureThreshold,
+			})
+		}
+	}
+	sort.Slice(metas, func(i, j int) bool { return metas[i].PublicKey < metas[j].PublicKey })
+	sort.Slice(limits, func(i, j int) bool { return limits[i].PublicKey < limits[j].PublicKey })
+	sort.Slice(thresholds, func(i, j int) bool { return thresholds[i].Name < thresholds[j].Name })
+
+	var (
+		methods = []string{"getTransactors", "getTransactorLimits", "getEntityThresholds"}
+		outputs = make([][]byte, len(methods))
+	)
+	if outputs[0], err = contractABI.Methods[methods[0]].Outputs.Pack(metas); err != nil {
+		return nil, err
+	}
+	if outputs[1], err = contractABI.Methods[methods[1]].Outputs.Pack(limits); err != nil {
+		return nil, err
+	}
+	if outputs[2], err = contractABI.Methods[methods[2]].Outputs.Pack(thresholds); err != nil {
+		return nil, err
+	}
+	// Lay out the selector dispatch, then a return block per method, then the outputs
+	const (
+		headerSize   = 6  // Loading the selector
+		dispatchSize = 11 // Jumping to the return block of a method
+		revertSize   = 4  // Reverting on unknown selectors
+		returnSize   = 16 // Copying an output into memory and returning it
+	)
+	var (
+		returns = headerSize + dispatchSize*len(methods) + revertSize
+		data    = returns + returnSize*len(methods)
+		code    = []byte{byte(vm.PUSH1), 0x00, byte(vm.CALLDATALOAD), byte(vm.PUSH1), 0xe0, byte(vm.SHR)}
+	)
+	for i, method := range methods {
+		dest := returns + returnSize*i
+		code = append(code, byte(vm.DUP1), byte(vm.PUSH4))
+		code = append(code, contractABI.Methods[method].ID...)
+		code = append(code, byte(vm.EQ), byte(vm.PUSH2), byte(dest>>8), byte(dest), byte(vm.JUMPI))
+	}
+	code = append(code, byte(vm.PUSH1), 0x00, byte(vm.DUP1), byte(vm.REVERT))
+	for _, output := range outputs {
+		if data+len(output) > 0xffff {
+			return nil, errors.New("too many priority transactors")
+		}
+		size := len(output)
+		code = append(code,
+			byte(vm.JUMPDEST),
+			byte(vm.PUSH2), byte(size>>8), byte(size),
+			byte(vm.PUSH2), byte(data>>8), byte(data),
+			byte(vm.PUSH1), 0x00,
+			byte(vm.CODECOPY),
+			byte(vm.PUSH2), byte(size>>8), byte(size),
+			byte(vm.PUSH1), 0x00,
+			byte(vm.RETURN),
+		)
+		data += size
+	}
+	for _, output := range outputs {
+		code = append(code, output...)
+	}
+	return code, nil
+}
@@ -0,0 +1,164 @@
+// Copyright 2022 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>
+
+package rawdb
+
+import (
+	"fmt"
+
+	"github.com/electroneum/electroneum-sc/common"
+	"github.com/electroneum/electroneum-sc/crypto"
+	"github.com/electroneum/electroneum-sc/ethdb"
+	"github.com/electroneum/electroneum-sc/log"
+)
+
+// The list of supported state storage schemes.
+const (
+	// HashScheme stores the trie nodes keyed by their hash. Nodes are shared
+	// between states, which keeps every state ever committed to disk until it
+	// is pruned offline.
+	HashScheme = "hash"
+
+	// PathScheme stores the trie nodes keyed by their owner and path in the
+	// trie. Only the latest state is kept on disk, an update overwrites the
+	// nodes it replaces, and recent states are kept in memory as diff layers.
+	PathScheme = "path"
+)
+
+// ReadAccountTrieNode retrieves the account trie node and the associated node
+// hash with the specified node path.
+func ReadAccountTrieNode(db ethdb.KeyValueReader, path []byte) ([]byte, common.Hash) {
+	data, err := db.Get(accountTrieNodeKey(path))
+	if err != nil || len(data) == 0 {
+		return nil, common.Hash{}
+	}
+	return data, crypto.Keccak256Hash(data)
+}
+
+// WriteAccountTrieNode writes the provided account trie node into database.
+func WriteAccountTrieNode(db ethdb.KeyValueWriter, path []byte, node []byte) {
+	if err := db.Put(accountTrieNodeKey(path), node); err != nil {
+		log.Crit("Failed to store account trie node", "err", err)
+	}
+}
+
+// DeleteAccountTrieNode deletes the specified account trie node from the database.
+func DeleteAccountTrieNode(db ethdb.KeyValueWriter, path []byte) {
+	if err := db.Delete(accountTrieNodeKey(path)); err != nil {
+		log.Crit("Failed to delete account trie node", "err", err)
+	}
+}
+
+// ReadStorageTrieNode retrieves the storage trie node and the associated node
+// hash with the specified node path.
+func ReadStorageTrieNode(db ethdb.KeyValueReader, accountHash common.Hash, path []byte) ([]byte, common.Hash) {
+	data, err := db.Get(storageTrieNodeKey(accountHash, path))
+	if err != nil || len(data) == 0 {
+		return nil, common.Hash{}
+	}
+	return data, crypto.Keccak256Hash(data)
+}
+
+// WriteStorageTrieNode writes the provided storage trie node into database.
+func WriteStorageTrieNode(db ethdb.KeyValueWriter, accountHash common.Hash, path []byte, node []byte) {
+	if err := db.Put(storageTrieNodeKey(accountHash, path), node); err != nil {
+		log.Crit("Failed to store storage trie node", "err", err)
+	}
+}
+
+// DeleteStorageTrieNode deletes the specified storage trie node from the database.
+func DeleteStorageTrieNode(db ethdb.KeyValueWriter, accountHash common.Hash, path []byte) {
+	if err := db.Delete(storageTrieNodeKey(accountHash, path)); err != nil {
+		log.Crit("Failed to delete storage trie node", "err", err)
+	}
+}
+
+// ReadTrieJournal retrieves the serialized in-memory trie node layers saved at
+// the last shutdown.
+func ReadTrieJournal(db ethdb.KeyValueReader) []byte {
+	data, _ := db.Get(trieJournalKey)
+	return data
+}
+
+// WriteTrieJournal stores the serialized in-memory trie node layers to save at
+// shutdown.
+func WriteTrieJournal(db ethdb.KeyValueWriter, journal []byte) {
+	if err := db.Put(trieJournalKey, journal); err != nil {
+		log.Crit("Failed to store trie journal", "err", err)
+	}
+}
+
+// DeleteTrieJournal deletes the serialized in-memory trie node layers saved at
+// the last shutdown.
+func DeleteTrieJournal(db ethdb.KeyValueWriter) {
+	if err := db.Delete(trieJournalKey); err != nil {
+		log.Crit("Failed to remove trie journal", "err", err)
+	}
+}
+
+// readStoredStateScheme retrieves the scheme of the state stored in the given
+// database, or the empty string if the database holds no chain yet. Databases
+// created before the scheme was recorded are hash based.
+func readStoredStateScheme(db ethdb.KeyValueReader) string {
+	if data, _ := db.Get(stateSchemeKey); len(data) != 0 {
+		return string(data)
+	}
+	if ReadHeadHeaderHash(db) != (common.Hash{}) {
+		return HashScheme
+	}
+	return ""
+}
+
+// ReadStateScheme retrieves the scheme of the state stored in the given
+// database, defaulting to the hash scheme.
+func ReadStateScheme(db ethdb.KeyValueReader) string {
+	if scheme := readStoredStateScheme(db); scheme != "" {
+		return scheme
+	}
+	return HashScheme
+}
+
+// WriteStateScheme stores the scheme of the state stored in the database.
+func WriteStateScheme(db ethdb.KeyValueWriter, scheme string) {
+	if err := db.Put(stateSchemeKey, []byte(scheme)); err != nil {
+		log.Crit("Failed to store state scheme", "err", err)
+	}
+}
+
+// ParseStateScheme checks the state scheme requested by the user against the
+// scheme of the state already stored in the database, and returns the scheme
+// to use.
+//
+//	                   provided == ""   provided != ""
+//	                 +---------------------------------------------
+//	db is empty      |  hash default  |  provided
+//	db is non-empty  |  from db       |  provided (if compatible)
+func ParseStateScheme(provided string, db ethdb.KeyValueReader) (string, error) {
+	if provided != "" && provided != HashScheme && provided != PathScheme {
+		return "", fmt.Errorf("unknown state scheme %q", provided)
+	}
+	stored := readStoredStateScheme(db)
+	switch {
+	case provided == "" && stored == "":
+		return HashScheme, nil
+	case provided == "":
+		return stored, nil
+	case stored == "" || stored == provided:
+		return provided, nil
+	default:
+		return "", fmt.Errorf("state.scheme choice was %v but found pre-existing %v state in the database", provided, stored)
+	}
+}
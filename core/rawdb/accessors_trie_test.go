@@ -0,0 +1,69 @@
+// Copyright 2022 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package rawdb
+
+import (
+	"testing"
+
+	"github.com/electroneum/electroneum-sc/common"
+)
+
+// Tests that the state scheme requested by the user is checked against the one
+// of the state already stored in the database.
+func TestParseStateScheme(t *testing.T) {
+	tests := []struct {
+		provided string
+		stored   string // Scheme marker in the database, "legacy" for a chain without it
+		want     string
+		fail     bool
+	}{
+		{provided: "", stored: "", want: HashScheme},
+		{provided: HashScheme, stored: "", want: HashScheme},
+		{provided: PathScheme, stored: "", want: PathScheme},
+		{provided: "", stored: PathScheme, want: PathScheme},
+		{provided: PathScheme, stored: PathScheme, want: PathScheme},
+		{provided: HashScheme, stored: PathScheme, fail: true},
+		{provided: "", stored: "legacy", want: HashScheme},
+		{provided: HashScheme, stored: "legacy", want: HashScheme},
+		{provided: PathScheme, stored: "legacy", fail: true},
+		{provided: "unknown", stored: "", fail: true},
+	}
+	for i, tt := range tests {
+		db := NewMemoryDatabase()
+		switch tt.stored {
+		case "":
+		case "legacy":
+			WriteHeadHeaderHash(db, common.HexToHash("0x01"))
+		default:
+			WriteStateScheme(db, tt.stored)
+		}
+		scheme, err := ParseStateScheme(tt.provided, db)
+		if tt.fail {
+			if err == nil {
+				t.Errorf("test %d: expected failure, got scheme %q", i, scheme)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("test %d: unexpected error: %v", i, err)
+			continue
+		}
+		if scheme != tt.want {
+			t.Errorf("test %d: scheme mismatch: have %q, want %q", i, scheme, tt.want)
+		}
+	}
+}
@@ -0,0 +1,476 @@
+// Copyright 2023 Electroneum Ltd
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package rawdb
+
+import (
+	"bufio"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"hash"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/electroneum/electroneum-sc/common"
+	"github.com/electroneum/electroneum-sc/ethdb"
+	"github.com/electroneum/electroneum-sc/log"
+	"github.com/electroneum/electroneum-sc/rlp"
+)
+
+const (
+	// backupVersion is the version of the backup layout written by Backup.
+	backupVersion = 1
+
+	// backupManifest is the name of the manifest file of a backup.
+	backupManifest = "manifest.json"
+
+	// backupKeyValue is the name of the backup file of the key-value store.
+	backupKeyValue = "chaindata.rlp"
+
+	// backupAncients is the name of the backup directory of the freezer.
+	backupAncients = "ancients"
+
+	// backupAncientBatch is the number of ancient items copied at once.
+	backupAncientBatch = 1024
+
+	// backupAncientBytes is the maximum size of the ancient items copied at once.
+	backupAncientBytes = 16 * 1024 * 1024
+)
+
+var (
+	errBackupNotEmpty  = errors.New("backup directory not empty")
+	errBackupTruncated = errors.New("freezer truncated during backup")
+	errRestoreNotEmpty = errors.New("restore target database not empty")
+)
+
+// BackupManifest describes the content of a database backup, with the checksums
+// to verify it before it is restored.
+type BackupManifest struct {
+	Version  int          `json:"version"`
+	Time     time.Time    `json:"time"`
+	Head     common.Hash  `json:"head"`           // Head block of the backup
+	Number   uint64       `json:"number"`         // Number of the head block
+	Ancients uint64       `json:"ancients"`       // Number of items of each freezer table
+	Tail     uint64       `json:"tail,omitempty"` // First item of the prunable freezer tables, if history expired
+	Files    []BackupFile `json:"files"`
+}
+
+// BackupFile is a file of a database backup.
+type BackupFile struct {
+	Name   string `json:"name"` // Slash-separated path, relative to the backup directory
+	Size   int64  `json:"size"`
+	SHA256 string `json:"sha256"`
+}
+
+// backupEntry is a key-value pair, as stored in the key-value store backup.
+type backupEntry struct {
+	Key   []byte
+	Value []byte
+}
+
+// backupWriter writes a file of the backup, computing its checksum on the fly.
+type backupWriter struct {
+	file *os.File
+	buf  *bufio.Writer
+	hash hash.Hash
+	size int64
+	name string
+}
+
+func newBackupWriter(dir string, name string) (*backupWriter, error) {
+	file, err := os.OpenFile(filepath.Join(dir, filepath.FromSlash(name)), os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0644)
+	if err != nil {
+		return nil, err
+	}
+	w := &backupWriter{file: file, hash: sha256.New(), name: name}
+	w.buf = bufio.NewWriter(io.MultiWriter(file, w.hash))
+	return w, nil
+}
+
+func (w *backupWriter) Write(b []byte) (int, error) {
+	n, err := w.buf.Write(b)
+	w.size += int64(n)
+	return n, err
+}
+
+// close flushes and syncs the file, returning its description for the manifest.
+func (w *backupWriter) close() (BackupFile, error) {
+	defer w.file.Close()
+
+	if err := w.buf.Flush(); err != nil {
+		return BackupFile{}, err
+	}
+	if err := w.file.Sync(); err != nil {
+		return BackupFile{}, err
+	}
+	return BackupFile{Name: w.name, Size: w.size, SHA256: hex.EncodeToString(w.hash.Sum(nil))}, nil
+}
+
+// CheckBackupDir returns an error if a backup can't be written to the given
+// directory, as it's not empty.
+func CheckBackupDir(dir string) error {
+	if entries, err := os.ReadDir(dir); err == nil && len(entries) > 0 {
+		return errBackupNotEmpty
+	}
+	return nil
+}
+
+// BackupManifestPath returns the path of the manifest of the backup in the given
+// directory. The manifest is written last, once the backup is complete.
+func BackupManifestPath(dir string) string {
+	return filepath.Join(dir, backupManifest)
+}
+
+// Backup writes a consistent copy of the database, both the key-value store and
+// the freezer, to the given directory while the database is in use. The
+// directory must not exist yet or be empty.
+//
+// The key-value store is copied from a snapshot, and the freezer up to the
+// number of items it had right after the snapshot was taken. Blocks migrated to
+// the freezer in between are found in both, but none is missing in the backup.
+// The freezer tables are append-only, so they're copied in batches without
+// blocking the freezer in between. The backup fails if the freezer is truncated
+// below the copied range in the meantime.
+func Backup(db ethdb.Database, dir string) (*BackupManifest, error) {
+	if err := CheckBackupDir(dir); err != nil {
+		return nil, err
+	}
+	if err := os.MkdirAll(filepath.Join(dir, backupAncients), 0755); err != nil {
+		return nil, err
+	}
+	snap, err := db.NewSnapshot()
+	if err != nil {
+		return nil, err
+	}
+	defer snap.Release()
+
+	manifest := &BackupManifest{
+		Version: backupVersion,
+		Time:    time.Now().UTC(),
+		Head:    ReadHeadBlockHash(snap),
+	}
+	if number := ReadHeaderNumber(snap, manifest.Head); number != nil {
+		manifest.Number = *number
+	}
+	log.Info("Backing up database", "dir", dir, "number", manifest.Number, "hash", manifest.Head)
+
+	// Capture the range of the freezer tables at snapshot time
+	var freezer bool
+	err = db.ReadAncients(func(op ethdb.AncientReaderOp) error {
+		frozen, err := op.Ancients()
+		if err != nil {
+			return nil // Database without freezer
+		}
+		tail, err := op.Tail()
+		if err != nil {
+			return err
+		}
+		manifest.Ancients, manifest.Tail, freezer = frozen, tail, true
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	// Copy the freezer tables, up to the items frozen at snapshot time
+	if freezer && manifest.Ancients > 0 {
+		for _, kind := range backupTables() {
+			var first uint64
+			if freezerPrunable[kind] {
+				first = manifest.Tail
+			}
+			file, err := backupAncientTable(db, dir, kind, first, manifest.Ancients)
+			if err != nil {
+				return nil, err
+			}
+			manifest.Files = append(manifest.Files, file)
+		}
+	}
+	// Copy the key-value store from the snapshot
+	file, err := backupKeyValues(snap, dir)
+	if err != nil {
+		return nil, err
+	}
+	manifest.Files = append(manifest.Files, file)
+
+	blob, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	if err := os.WriteFile(BackupManifestPath(dir), blob, 0644); err != nil {
+		return nil, err
+	}
+	log.Info("Backed up database", "dir", dir, "number", manifest.Number, "hash", manifest.Head, "files", len(manifest.Files))
+	return manifest, nil
+}
+
+// backupTables returns the freezer tables to back up, in a stable order.
+func backupTables() []string {
+	tables := make([]string, 0, len(FreezerNoSnappy))
+	for kind := range FreezerNoSnappy {
+		tables = append(tables, kind)
+	}
+	sort.Strings(tables)
+	return tables
+}
+
+// backupKeyValues copies the content of a key-value store snapshot to the backup.
+func backupKeyValues(snap ethdb.Snapshot, dir string) (BackupFile, error) {
+	w, err := newBackupWriter(dir, backupKeyValue)
+	if err != nil {
+		return BackupFile{}, err
+	}
+	var (
+		count  uint64
+		start  = time.Now()
+		logged = time.Now()
+	)
+	it := snap.NewIterator(nil, nil)
+	defer it.Release()
+
+	for it.Next() {
+		if err := rlp.Encode(w, &backupEntry{Key: it.Key(), Value: it.Value()}); err != nil {
+			w.close()
+			return BackupFile{}, err
+		}
+		count++
+		if time.Since(logged) > 8*time.Second {
+			log.Info("Backing up key-value store", "entries", count, "size", common.StorageSize(w.size), "elapsed", common.PrettyDuration(time.Since(start)))
+			logged = time.Now()
+		}
+	}
+	if err := it.Error(); err != nil {
+		w.close()
+		return BackupFile{}, err
+	}
+	log.Info("Backed up key-value store", "entries", count, "size", common.StorageSize(w.size), "elapsed", common.PrettyDuration(time.Since(start)))
+	return w.close()
+}
+
+// backupAncientTable copies the items of a freezer table in the given range to
+// the backup. The freezer is only locked while a batch is read.
+func backupAncientTable(db ethdb.AncientReader, dir string, kind string, first uint64, items uint64) (BackupFile, error) {
+	w, err := newBackupWriter(dir, backupAncients+"/"+kind+".rlp")
+	if err != nil {
+		return BackupFile{}, err
+	}
+	for next := first; next < items; {
+		var blobs [][]byte
+		err := db.ReadAncients(func(op ethdb.AncientReaderOp) error {
+			// The history expiry or a rewind may have dropped the items since
+			if frozen, err := op.Ancients(); err != nil || frozen < items {
+				return errBackupTruncated
+			}
+			if tail, err := op.Tail(); err != nil || (freezerPrunable[kind] && tail > next) {
+				return errBackupTruncated
+			}
+			var err error
+			blobs, err = op.AncientRange(kind, next, min(backupAncientBatch, items-next), backupAncientBytes)
+			return err
+		})
+		if err != nil {
+			w.close()
+			return BackupFile{}, err
+		}
+		for _, blob := range blobs {
+			if err := rlp.Encode(w, blob); err != nil {
+				w.close()
+				return BackupFile{}, err
+			}
+		}
+		next += uint64(len(blobs))
+	}
+	log.Info("Backed up freezer table", "table", kind, "items", items-first, "size", common.StorageSize(w.size))
+	return w.close()
+}
+
+// ReadBackupManifest reads the manifest of the backup in the given directory.
+func ReadBackupManifest(dir string) (*BackupManifest, error) {
+	var manifest BackupManifest
+	if err := common.LoadJSON(filepath.Join(dir, backupManifest), &manifest); err != nil {
+		return nil, err
+	}
+	if manifest.Version != backupVersion {
+		return nil, fmt.Errorf("unsupported backup version %d", manifest.Version)
+	}
+	return &manifest, nil
+}
+
+// VerifyBackup checks the files of the backup in the given directory against
+// the checksums of its manifest.
+func VerifyBackup(dir string) (*BackupManifest, error) {
+	manifest, err := ReadBackupManifest(dir)
+	if err != nil {
+		return nil, err
+	}
+	for _, file := range manifest.Files {
+		f, err := os.Open(filepath.Join(dir, filepath.FromSlash(file.Name)))
+		if err != nil {
+			return nil, err
+		}
+		hasher := sha256.New()
+		size, err := io.Copy(hasher, f)
+		f.Close()
+		if err != nil {
+			return nil, err
+		}
+		if size != file.Size {
+			return nil, fmt.Errorf("backup file %s size mismatch: have %d, want %d", file.Name, size, file.Size)
+		}
+		if sum := hex.EncodeToString(hasher.Sum(nil)); sum != file.SHA256 {
+			return nil, fmt.Errorf("backup file %s checksum mismatch: have %s, want %s", file.Name, sum, file.SHA256)
+		}
+	}
+	return manifest, nil
+}
+
+// Restore verifies the backup in the given directory and writes it to the
+// database described by the options, which must not exist yet.
+func Restore(dir string, o OpenOptions) error {
+	manifest, err := VerifyBackup(dir)
+	if err != nil {
+		return err
+	}
+	if PreexistingDatabase(o.Directory) != "" {
+		return errRestoreNotEmpty
+	}
+	log.Info("Restoring database", "dir", dir, "number", manifest.Number, "hash", manifest.Head, "ancients", manifest.Ancients, "tail", manifest.Tail)
+
+	// Write the freezer first, the key-value store referencing its blocks
+	if manifest.Ancients > 0 {
+		if len(o.AncientsDirectory) == 0 {
+			return errors.New("ancients directory required to restore the freezer")
+		}
+		if err := restoreAncients(dir, o.AncientsDirectory, manifest.Tail, manifest.Ancients); err != nil {
+			return err
+		}
+	}
+	kvdb, err := openKeyValueDatabase(o)
+	if err != nil {
+		return err
+	}
+	defer kvdb.Close()
+
+	if err := restoreKeyValues(dir, kvdb); err != nil {
+		return err
+	}
+	log.Info("Restored database", "number", manifest.Number, "hash", manifest.Head)
+	return nil
+}
+
+// restoreAncients writes the freezer tables of the backup to a new freezer. The
+// items of the prunable tables below the tail are written empty, and dropped by
+// tail truncation afterwards.
+func restoreAncients(dir string, datadir string, tail uint64, items uint64) error {
+	if tail > items {
+		return fmt.Errorf("backup freezer tail %d above head %d", tail, items)
+	}
+	freezer, err := newFreezer(datadir, "", false, freezerTableSize, FreezerNoSnappy, freezerPrunable)
+	if err != nil {
+		return err
+	}
+	defer freezer.Close()
+
+	if frozen, err := freezer.Ancients(); err != nil {
+		return err
+	} else if frozen > 0 {
+		return errRestoreNotEmpty
+	}
+	streams := make(map[string]*rlp.Stream)
+	for _, kind := range backupTables() {
+		f, err := os.Open(filepath.Join(dir, backupAncients, kind+".rlp"))
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		streams[kind] = rlp.NewStream(bufio.NewReader(f), 0)
+	}
+	for next := uint64(0); next < items; {
+		last := next + min(backupAncientBatch, items-next)
+		_, err := freezer.ModifyAncients(func(op ethdb.AncientWriteOp) error {
+			for number := next; number < last; number++ {
+				for kind, stream := range streams {
+					if number < tail && freezerPrunable[kind] {
+						if err := op.AppendRaw(kind, number, nil); err != nil {
+							return err
+						}
+						continue
+					}
+					blob, err := stream.Bytes()
+					if err != nil {
+						return fmt.Errorf("failed to read %s item %d: %v", kind, number, err)
+					}
+					if err := op.AppendRaw(kind, number, blob); err != nil {
+						return err
+					}
+				}
+			}
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+		next = last
+	}
+	if err := freezer.TruncateTail(tail); err != nil {
+		return err
+	}
+	log.Info("Restored freezer", "items", items, "tail", tail)
+	return freezer.Sync()
+}
+
+// restoreKeyValues writes the key-value store backup to the given database.
+func restoreKeyValues(dir string, db ethdb.KeyValueStore) error {
+	f, err := os.Open(filepath.Join(dir, backupKeyValue))
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	var (
+		stream = rlp.NewStream(bufio.NewReader(f), 0)
+		batch  = db.NewBatch()
+		count  uint64
+	)
+	for {
+		var entry backupEntry
+		if err := stream.Decode(&entry); err == io.EOF {
+			break
+		} else if err != nil {
+			return fmt.Errorf("failed to read entry %d: %v", count, err)
+		}
+		if err := batch.Put(entry.Key, entry.Value); err != nil {
+			return err
+		}
+		if batch.ValueSize() >= ethdb.IdealBatchSize {
+			if err := batch.Write(); err != nil {
+				return err
+			}
+			batch.Reset()
+		}
+		count++
+	}
+	if err := batch.Write(); err != nil {
+		return err
+	}
+	log.Info("Restored key-value store", "entries", count)
+	return nil
+}
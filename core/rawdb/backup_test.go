@@ -0,0 +1,176 @@
+// Copyright 2023 Electroneum Ltd
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package rawdb
+
+import (
+	"bytes"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/electroneum/electroneum-sc/core/types"
+	"github.com/electroneum/electroneum-sc/ethdb/memorydb"
+)
+
+// Tests that a backup of both the key-value store and the freezer is restored
+// identically, and that a corrupted backup is refused.
+func TestBackupRestore(t *testing.T) {
+	db, err := NewDatabaseWithFreezer(memorydb.New(), t.TempDir(), "", false)
+	if err != nil {
+		t.Fatalf("failed to create database: %v", err)
+	}
+	defer db.Close()
+
+	// Freeze a few blocks, and keep the most recent ones in the key-value store
+	var blocks []*types.Block
+	for i := 0; i < 8; i++ {
+		header := &types.Header{Number: big.NewInt(int64(i)), Extra: []byte("backup test")}
+		if i > 0 {
+			header.ParentHash = blocks[i-1].Hash()
+		}
+		blocks = append(blocks, types.NewBlockWithHeader(header))
+	}
+	receipts := make([]types.Receipts, 5)
+	if _, err := WriteAncientBlocks(db, blocks[:5], receipts, big.NewInt(100)); err != nil {
+		t.Fatalf("failed to freeze blocks: %v", err)
+	}
+	for _, block := range blocks[5:] {
+		WriteBlock(db, block)
+		WriteCanonicalHash(db, block.Hash(), block.NumberU64())
+	}
+	WriteHeadBlockHash(db, blocks[7].Hash())
+
+	// Back up the database, and ensure the directory can't be reused
+	dir := filepath.Join(t.TempDir(), "backup")
+	manifest, err := Backup(db, dir)
+	if err != nil {
+		t.Fatalf("failed to back up database: %v", err)
+	}
+	if manifest.Head != blocks[7].Hash() || manifest.Number != 7 || manifest.Ancients != 5 {
+		t.Fatalf("manifest mismatch: have head %x number %d ancients %d", manifest.Head, manifest.Number, manifest.Ancients)
+	}
+	if _, err := Backup(db, dir); err != errBackupNotEmpty {
+		t.Fatalf("backup to used directory error mismatch: have %v, want %v", err, errBackupNotEmpty)
+	}
+	// Restore the backup and ensure the content is the same
+	var (
+		target  = t.TempDir()
+		options = OpenOptions{Directory: filepath.Join(target, "chaindata"), AncientsDirectory: filepath.Join(target, "ancient")}
+	)
+	if err := Restore(dir, options); err != nil {
+		t.Fatalf("failed to restore database: %v", err)
+	}
+	if err := Restore(dir, options); err != errRestoreNotEmpty {
+		t.Fatalf("restore to existing database error mismatch: have %v, want %v", err, errRestoreNotEmpty)
+	}
+	restored, err := Open(options)
+	if err != nil {
+		t.Fatalf("failed to open restored database: %v", err)
+	}
+	defer restored.Close()
+
+	if frozen, _ := restored.Ancients(); frozen != 5 {
+		t.Fatalf("restored ancients mismatch: have %d, want %d", frozen, 5)
+	}
+	if head := ReadHeadBlockHash(restored); head != blocks[7].Hash() {
+		t.Fatalf("restored head mismatch: have %x, want %x", head, blocks[7].Hash())
+	}
+	for _, block := range blocks {
+		if !bytes.Equal(ReadHeaderRLP(restored, block.Hash(), block.NumberU64()), ReadHeaderRLP(db, block.Hash(), block.NumberU64())) {
+			t.Errorf("restored header %d mismatch", block.NumberU64())
+		}
+		if hash := ReadCanonicalHash(restored, block.NumberU64()); hash != block.Hash() {
+			t.Errorf("restored canonical hash %d mismatch: have %x, want %x", block.NumberU64(), hash, block.Hash())
+		}
+	}
+	// Corrupt the backup and ensure it's refused
+	path := filepath.Join(dir, backupKeyValue)
+	blob, _ := os.ReadFile(path)
+	blob[len(blob)-1] ^= 0xff
+	os.WriteFile(path, blob, 0644)
+
+	if _, err := VerifyBackup(dir); err == nil {
+		t.Fatalf("corrupted backup verified")
+	}
+	if err := Restore(dir, OpenOptions{Directory: filepath.Join(t.TempDir(), "chaindata")}); err == nil {
+		t.Fatalf("corrupted backup restored")
+	}
+}
+
+// Tests that a backup of a database whose history expired is restored with the
+// same freezer tail.
+func TestBackupRestoreExpiredHistory(t *testing.T) {
+	db, err := NewDatabaseWithFreezer(memorydb.New(), t.TempDir(), "", false)
+	if err != nil {
+		t.Fatalf("failed to create database: %v", err)
+	}
+	defer db.Close()
+
+	var blocks []*types.Block
+	for i := 0; i < 8; i++ {
+		header := &types.Header{Number: big.NewInt(int64(i)), Extra: []byte("backup test")}
+		if i > 0 {
+			header.ParentHash = blocks[i-1].Hash()
+		}
+		blocks = append(blocks, types.NewBlockWithHeader(header))
+	}
+	if _, err := WriteAncientBlocks(db, blocks, make([]types.Receipts, len(blocks)), big.NewInt(100)); err != nil {
+		t.Fatalf("failed to freeze blocks: %v", err)
+	}
+	if err := db.TruncateTail(5); err != nil {
+		t.Fatalf("failed to expire history: %v", err)
+	}
+	dir := filepath.Join(t.TempDir(), "backup")
+	manifest, err := Backup(db, dir)
+	if err != nil {
+		t.Fatalf("failed to back up database: %v", err)
+	}
+	if manifest.Ancients != 8 || manifest.Tail != 5 {
+		t.Fatalf("manifest mismatch: have ancients %d tail %d", manifest.Ancients, manifest.Tail)
+	}
+	// A table copy whose range expires in the meantime fails, rather than
+	// writing a backup with missing items
+	tmp := t.TempDir()
+	os.Mkdir(filepath.Join(tmp, backupAncients), 0755)
+	if _, err := backupAncientTable(db, tmp, freezerBodiesTable, 4, 8); err != errBackupTruncated {
+		t.Fatalf("expired range copy error mismatch: have %v, want %v", err, errBackupTruncated)
+	}
+	target := t.TempDir()
+	options := OpenOptions{Directory: filepath.Join(target, "chaindata"), AncientsDirectory: filepath.Join(target, "ancient")}
+	if err := Restore(dir, options); err != nil {
+		t.Fatalf("failed to restore database: %v", err)
+	}
+	restored, err := Open(options)
+	if err != nil {
+		t.Fatalf("failed to open restored database: %v", err)
+	}
+	defer restored.Close()
+
+	if tail, _ := restored.Tail(); tail != 5 {
+		t.Fatalf("restored tail mismatch: have %d, want %d", tail, 5)
+	}
+	for _, block := range blocks {
+		hash, number := block.Hash(), block.NumberU64()
+		if ReadHeader(restored, hash, number) == nil {
+			t.Errorf("restored header %d missing", number)
+		}
+		if have := ReadBodyRLP(restored, hash, number) != nil; have != (number >= 5) {
+			t.Errorf("restored body %d presence mismatch: have %t, want %t", number, have, number >= 5)
+		}
+	}
+}
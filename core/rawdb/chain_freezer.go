@@ -56,7 +56,7 @@ type chainFreezer struct {
 
 // newChainFreezer initializes the freezer for ancient chain data.
 func newChainFreezer(datadir string, namespace string, readonly bool, maxTableSize uint32, tables map[string]bool) (*chainFreezer, error) {
-	freezer, err := NewFreezer(datadir, namespace, readonly, maxTableSize, tables)
+	freezer, err := newFreezer(datadir, namespace, readonly, maxTableSize, tables, freezerPrunable)
 	if err != nil {
 		return nil, err
 	}
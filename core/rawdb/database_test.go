@@ -15,3 +15,36 @@
 // along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
 
 package rawdb
+
+import (
+	"testing"
+)
+
+// Tests that the engine of an existing database is detected, and that opening
+// it with another engine is refused.
+func TestOpenDetectsDatabaseEngine(t *testing.T) {
+	dir := t.TempDir()
+	if engine := PreexistingDatabase(dir); engine != "" {
+		t.Fatalf("engine of missing database mismatch: have %q, want none", engine)
+	}
+	db, err := Open(OpenOptions{Directory: dir})
+	if err != nil {
+		t.Fatalf("failed to open database: %v", err)
+	}
+	db.Close()
+
+	if engine := PreexistingDatabase(dir); engine != dbLeveldb {
+		t.Fatalf("engine of existing database mismatch: have %q, want %q", engine, dbLeveldb)
+	}
+	if _, err := Open(OpenOptions{Type: dbPebble, Directory: dir}); err == nil {
+		t.Fatalf("opened leveldb database as pebble")
+	}
+	if _, err := Open(OpenOptions{Type: "rocksdb", Directory: t.TempDir()}); err == nil {
+		t.Fatalf("opened database with unknown engine")
+	}
+	db, err = Open(OpenOptions{Directory: dir, AncientsDirectory: t.TempDir()})
+	if err != nil {
+		t.Fatalf("failed to reopen database: %v", err)
+	}
+	db.Close()
+}
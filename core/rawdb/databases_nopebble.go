@@ -0,0 +1,37 @@
+// Copyright 2023 Electroneum Ltd
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+//go:build !((arm64 || amd64) && !openbsd)
+
+package rawdb
+
+import (
+	"errors"
+
+	"github.com/electroneum/electroneum-sc/ethdb"
+)
+
+// PebbleEnabled reports whether the pebble database backend is built in.
+const PebbleEnabled = false
+
+// errPebbleNotSupported is returned when opening a pebble database with a build
+// not including the pebble backend.
+var errPebbleNotSupported = errors.New("pebble database backend not supported on this platform")
+
+// NewPebbleDBDatabase is a stub for builds without the pebble backend.
+func NewPebbleDBDatabase(file string, cache int, handles int, namespace string, readonly bool) (ethdb.Database, error) {
+	return nil, errPebbleNotSupported
+}
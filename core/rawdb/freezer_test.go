@@ -283,6 +283,49 @@ func TestFreezerReadonlyValidate(t *testing.T) {
 	}
 }
 
+// Tests that tail truncation only drops the prunable tables, and that their tail
+// is kept when the freezer is reopened.
+func TestFreezerPrunableTables(t *testing.T) {
+	var (
+		dir      = t.TempDir()
+		tables   = map[string]bool{"a": true, "b": true}
+		prunable = map[string]bool{"b": true}
+	)
+	f, err := newFreezer(dir, "", false, 2049, tables, prunable)
+	if err != nil {
+		t.Fatal("can't open freezer", err)
+	}
+	_, err = f.ModifyAncients(func(op ethdb.AncientWriteOp) error {
+		for i := uint64(0); i < 10; i++ {
+			require.NoError(t, op.AppendRaw("a", i, []byte{byte(i)}))
+			require.NoError(t, op.AppendRaw("b", i, []byte{byte(i)}))
+		}
+		return nil
+	})
+	require.NoError(t, err)
+	require.NoError(t, f.TruncateTail(5))
+	require.NoError(t, f.Close())
+
+	// Reopen the freezer and ensure only the prunable table was truncated
+	f, err = newFreezer(dir, "", false, 2049, tables, prunable)
+	if err != nil {
+		t.Fatal("can't reopen freezer", err)
+	}
+	defer f.Close()
+
+	if tail, _ := f.Tail(); tail != 5 {
+		t.Fatalf("tail mismatch: have %d, want %d", tail, 5)
+	}
+	for i := uint64(0); i < 10; i++ {
+		if ok, _ := f.HasAncient("a", i); !ok {
+			t.Errorf("item %d of non-prunable table missing", i)
+		}
+		if ok, _ := f.HasAncient("b", i); ok != (i >= 5) {
+			t.Errorf("item %d of prunable table presence mismatch: have %t, want %t", i, ok, i >= 5)
+		}
+	}
+}
+
 func newFreezerForTesting(t *testing.T, tables map[string]bool) (*Freezer, string) {
 	t.Helper()
 
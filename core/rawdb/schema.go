@@ -57,6 +57,12 @@ var (
 	// snapshotJournalKey tracks the in-memory diff layers across restarts.
 	snapshotJournalKey = []byte("SnapshotJournal")
 
+	// stateSchemeKey tracks the scheme used to store the state trie nodes.
+	stateSchemeKey = []byte("StateScheme")
+
+	// trieJournalKey tracks the in-memory trie node layers across restarts.
+	trieJournalKey = []byte("TrieJournal")
+
 	// snapshotGeneratorKey tracks the snapshot generation marker across restarts.
 	snapshotGeneratorKey = []byte("SnapshotGenerator")
 
@@ -99,6 +105,8 @@ var (
 	SnapshotStoragePrefix = []byte("o") // SnapshotStoragePrefix + account hash + storage hash -> storage trie value
 	CodePrefix            = []byte("c") // CodePrefix + code hash -> account code
 	skeletonHeaderPrefix  = []byte("S") // skeletonHeaderPrefix + num (uint64 big endian) -> header
+	TrieNodeAccountPrefix = []byte("A") // TrieNodeAccountPrefix + hexPath -> trie node
+	TrieNodeStoragePrefix = []byte("O") // TrieNodeStoragePrefix + account hash + hexPath -> trie node
 
 	PreimagePrefix = []byte("secure-key-")       // PreimagePrefix + hash -> preimage
 	configPrefix   = []byte("ethereum-config-")  // config prefix for the db
@@ -138,6 +146,14 @@ var FreezerNoSnappy = map[string]bool{
 	freezerDifficultyTable: true,
 }
 
+// freezerPrunable configures which ancient-tables are dropped by tail truncation
+// once the block history expires. Headers, hashes and difficulties are kept to
+// retain the whole header chain.
+var freezerPrunable = map[string]bool{
+	freezerBodiesTable:  true,
+	freezerReceiptTable: true,
+}
+
 // LegacyTxLookupEntry is the legacy TxLookupEntry definition with some unnecessary
 // fields.
 type LegacyTxLookupEntry struct {
@@ -223,6 +239,31 @@ func skeletonHeaderKey(number uint64) []byte {
 	return append(skeletonHeaderPrefix, encodeBlockNumber(number)...)
 }
 
+// accountTrieNodeKey = TrieNodeAccountPrefix + nodePath
+func accountTrieNodeKey(path []byte) []byte {
+	return append(TrieNodeAccountPrefix, path...)
+}
+
+// storageTrieNodeKey = TrieNodeStoragePrefix + account hash + nodePath
+func storageTrieNodeKey(accountHash common.Hash, path []byte) []byte {
+	return append(append(TrieNodeStoragePrefix, accountHash.Bytes()...), path...)
+}
+
+// IsAccountTrieNode reports whether a provided database entry is an account
+// trie node in the path-based scheme.
+func IsAccountTrieNode(key []byte) bool {
+	// The path of a trie node is 64 nibbles at most
+	return bytes.HasPrefix(key, TrieNodeAccountPrefix) && len(key) <= len(TrieNodeAccountPrefix)+2*common.HashLength
+}
+
+// IsStorageTrieNode reports whether a provided database entry is a storage
+// trie node in the path-based scheme.
+func IsStorageTrieNode(key []byte) bool {
+	return bytes.HasPrefix(key, TrieNodeStoragePrefix) &&
+		len(key) >= len(TrieNodeStoragePrefix)+common.HashLength &&
+		len(key) <= len(TrieNodeStoragePrefix)+3*common.HashLength
+}
+
 // preimageKey = PreimagePrefix + hash
 func preimageKey(hash common.Hash) []byte {
 	return append(PreimagePrefix, hash.Bytes()...)
@@ -590,6 +590,7 @@ func (s *StateDB) createObject(addr common.Address) (newobj, prev *stateObject)
 		}
 	}
 	newobj = newObject(s, addr, types.StateAccount{})
+	newobj.created = true
 	if prev == nil {
 		s.journal.append(createObjectChange{account: &addr})
 	} else {
@@ -655,6 +656,7 @@ func (s *StateDB) Copy() *StateDB {
 	state := &StateDB{
 		db:                  s.db,
 		trie:                s.db.CopyTrie(s.trie),
+		originalRoot:        s.originalRoot,
 		stateObjects:        make(map[common.Address]*stateObject, len(s.journal.dirties)),
 		stateObjectsPending: make(map[common.Address]struct{}, len(s.stateObjectsPending)),
 		stateObjectsDirty:   make(map[common.Address]struct{}, len(s.journal.dirties)),
@@ -821,7 +823,7 @@ func (s *StateDB) Finalise(deleteEmptyObjects bool) {
 		addressesToPrefetch = append(addressesToPrefetch, common.CopyBytes(addr[:])) // Copy needed for closure
 	}
 	if s.prefetcher != nil && len(addressesToPrefetch) > 0 {
-		s.prefetcher.prefetch(s.originalRoot, addressesToPrefetch)
+		s.prefetcher.prefetch(common.Hash{}, s.originalRoot, addressesToPrefetch)
 	}
 	// Invalidate journal because reverting across transactions is not allowed.
 	s.clearJournalAndRefund()
@@ -862,7 +864,7 @@ func (s *StateDB) IntermediateRoot(deleteEmptyObjects bool) common.Hash {
 	// _untouched_. We can check with the prefetcher, if it can give us a trie
 	// which has the same root, but also has some content loaded into it.
 	if prefetcher != nil {
-		if trie := prefetcher.trie(s.originalRoot); trie != nil {
+		if trie := prefetcher.trie(common.Hash{}, s.originalRoot); trie != nil {
 			s.trie = trie
 		}
 	}
@@ -878,7 +880,7 @@ func (s *StateDB) IntermediateRoot(deleteEmptyObjects bool) common.Hash {
 		usedAddrs = append(usedAddrs, common.CopyBytes(addr[:])) // Copy needed for closure
 	}
 	if prefetcher != nil {
-		prefetcher.used(s.originalRoot, usedAddrs)
+		prefetcher.used(common.Hash{}, s.originalRoot, usedAddrs)
 	}
 	if len(s.stateObjectsPending) > 0 {
 		s.stateObjectsPending = make(map[common.Address]struct{})
@@ -914,20 +916,47 @@ func (s *StateDB) Commit(deleteEmptyObjects bool) (common.Hash, error) {
 	s.IntermediateRoot(deleteEmptyObjects)
 
 	// Commit objects to the trie, measuring the elapsed time
-	var storageCommitted int
+	var (
+		storageCommitted int
+		nodes            *trie.MergedNodeSet
+		pathScheme       = s.db.TrieDB().Scheme() == rawdb.PathScheme
+	)
+	if pathScheme {
+		nodes = trie.NewMergedNodeSet()
+	}
 	codeWriter := s.db.TrieDB().DiskDB().NewBatch()
 	for addr := range s.stateObjectsDirty {
-		if obj := s.stateObjects[addr]; !obj.deleted {
+		obj := s.stateObjects[addr]
+
+		// Stale storage nodes are not reachable anymore in the hash scheme, but
+		// they must be deleted explicitly in the path scheme. The storage of a
+		// destructed or re-created account is wiped before the new storage of
+		// the account is committed on top.
+		if pathScheme && (obj.deleted || obj.created) {
+			set, err := s.wipeStorage(addr, obj.addrHash)
+			if err != nil {
+				return common.Hash{}, err
+			}
+			if set != nil {
+				nodes.Merge(set)
+			}
+		}
+		obj.created = false
+
+		if !obj.deleted {
 			// Write any contract code associated with the state object
 			if obj.code != nil && obj.dirtyCode {
 				rawdb.WriteCode(codeWriter, common.BytesToHash(obj.CodeHash()), obj.code)
 				obj.dirtyCode = false
 			}
 			// Write any storage changes in the state object to its storage trie
-			committed, err := obj.CommitTrie(s.db)
+			set, committed, err := obj.CommitTrie(s.db)
 			if err != nil {
 				return common.Hash{}, err
 			}
+			if set != nil {
+				nodes.Merge(set)
+			}
 			storageCommitted += committed
 		}
 	}
@@ -944,20 +973,56 @@ func (s *StateDB) Commit(deleteEmptyObjects bool) (common.Hash, error) {
 	if metrics.EnabledExpensive {
 		start = time.Now()
 	}
-	// The onleaf func is called _serially_, so we can reuse the same account
-	// for unmarshalling every time.
-	var account types.StateAccount
-	root, accountCommitted, err := s.trie.Commit(func(_ [][]byte, _ []byte, leaf []byte, parent common.Hash) error {
-		if err := rlp.DecodeBytes(leaf, &account); err != nil {
-			return nil
+	var (
+		root             common.Hash
+		accountCommitted int
+		err              error
+	)
+	if pathScheme {
+		var set *trie.NodeSet
+		if root, set, err = s.trie.CommitNodes(); err != nil {
+			return common.Hash{}, err
 		}
-		if account.Root != emptyRoot {
-			s.db.TrieDB().Reference(account.Root, parent)
+		nodes.Merge(set)
+		accountCommitted = set.Size()
+
+		// Insert the nodes of the whole state transition into the database,
+		// unless the state didn't change (e.g. empty Clique blocks)
+		parent := s.originalRoot
+		if parent == (common.Hash{}) {
+			parent = emptyRoot
+		}
+		if root != parent {
+			if err := s.db.TrieDB().Update(root, parent, nodes); err != nil {
+				return common.Hash{}, err
+			}
+			s.originalRoot = root
+
+			// The committed tries were collapsed into hash nodes, which can only
+			// be resolved from the layers of the new state
+			if s.trie, err = s.db.OpenTrie(root); err != nil {
+				return common.Hash{}, err
+			}
+			for _, obj := range s.stateObjects {
+				obj.trie = nil
+			}
+		}
+	} else {
+		// The onleaf func is called _serially_, so we can reuse the same account
+		// for unmarshalling every time.
+		var account types.StateAccount
+		root, accountCommitted, err = s.trie.Commit(func(_ [][]byte, _ []byte, leaf []byte, parent common.Hash) error {
+			if err := rlp.DecodeBytes(leaf, &account); err != nil {
+				return nil
+			}
+			if account.Root != emptyRoot {
+				s.db.TrieDB().Reference(account.Root, parent)
+			}
+			return nil
+		})
+		if err != nil {
+			return common.Hash{}, err
 		}
-		return nil
-	})
-	if err != nil {
-		return common.Hash{}, err
 	}
 	if metrics.EnabledExpensive {
 		s.AccountCommits += time.Since(start)
@@ -994,6 +1059,42 @@ func (s *StateDB) Commit(deleteEmptyObjects bool) (common.Hash, error) {
 	return root, err
 }
 
+// wipeStorage returns the deletion markers of all the storage trie nodes of the
+// given account as of the last commit, or nil if the account had no storage.
+func (s *StateDB) wipeStorage(addr common.Address, addrHash common.Hash) (*trie.NodeSet, error) {
+	tr, err := s.db.OpenTrie(s.originalRoot)
+	if err != nil {
+		return nil, err
+	}
+	enc, err := tr.TryGet(addr.Bytes())
+	if err != nil || len(enc) == 0 {
+		return nil, err
+	}
+	var account types.StateAccount
+	if err := rlp.DecodeBytes(enc, &account); err != nil {
+		return nil, err
+	}
+	if account.Root == emptyRoot {
+		return nil, nil
+	}
+	storage, err := s.db.OpenStorageTrie(s.originalRoot, addrHash, account.Root)
+	if err != nil {
+		return nil, err
+	}
+	set := trie.NewNodeSet(addrHash)
+	it := storage.NodeIterator(nil)
+	for it.Next(true) {
+		// Embedded nodes and values are not stored on their own
+		if it.Hash() != (common.Hash{}) {
+			set.MarkDeleted(it.Path())
+		}
+	}
+	if it.Error() != nil {
+		return nil, it.Error()
+	}
+	return set, nil
+}
+
 // PrepareAccessList handles the preparatory steps for executing a state transition with
 // regards to both EIP-2929 and EIP-2930:
 //
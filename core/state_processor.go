@@ -80,15 +80,15 @@ func (p *StateProcessor) Process(block *types.Block, statedb *state.StateDB, cfg
 			return nil, nil, 0, fmt.Errorf("could not apply tx %d [%v]: %w", i, tx.Hash().Hex(), err)
 		}
 		// Validate priority transaction
-		if tx.Type() == types.PriorityTxType {
-			transactor, found := statedb.GetPriorityTransactorByKey(msg.PrioritySender())
-			if !found {
-				return nil, nil, 0, fmt.Errorf("could not apply tx %d [%v]: %w", i, tx.Hash().Hex(), errBadPriorityKey)
-			}
-			if !transactor.IsGasPriceWaiver && tx.HasZeroFee() {
-				return nil, nil, 0, fmt.Errorf("could not apply tx %d [%v]: %w", i, tx.Hash().Hex(), errNoGasPriceWaiver)
+		if tx.IsPriority() {
+			if err := validatePriorityTransaction(p.config, blockNumber, statedb, tx, msg); err != nil {
+				return nil, nil, 0, fmt.Errorf("could not apply tx %d [%v]: %w", i, tx.Hash().Hex(), err)
 			}
 		}
+		// Make sure the sender is permitted to send the transaction
+		if err := CheckAccountPermissions(vmenv, msg.From(), msg.To() == nil); err != nil {
+			return nil, nil, 0, fmt.Errorf("could not apply tx %d [%v]: %w", i, tx.Hash().Hex(), err)
+		}
 		statedb.Prepare(tx.Hash(), i)
 		receipt, err := applyTransaction(msg, p.config, p.bc, nil, gp, statedb, blockNumber, blockHash, tx, usedGas, vmenv)
 		if err != nil {
@@ -104,7 +104,7 @@ func (p *StateProcessor) Process(block *types.Block, statedb *state.StateDB, cfg
 }
 
 // Keep this function for applying the transaction only, as opposed to verification and pre checks.
-// Priority transactor checks are done within process() for verifying pre-created blocks and the prior wrapper function of applytransaction() for creating blocks
+// Priority transactor and account permission checks are done within process() for verifying pre-created blocks and the prior wrapper function of applytransaction() for creating blocks
 func applyTransaction(msg types.Message, config *params.ChainConfig, bc ChainContext, author *common.Address, gp *GasPool, statedb *state.StateDB, blockNumber *big.Int, blockHash common.Hash, tx *types.Transaction, usedGas *uint64, evm *vm.EVM) (*types.Receipt, error) {
 	// Create a new context to be used in the EVM environment.
 	txContext := NewEVMTxContext(msg)
@@ -170,14 +170,14 @@ func ApplyTransaction(config *params.ChainConfig, bc ChainContext, author *commo
 	vmenv := vm.NewEVM(blockContext, vm.TxContext{}, statedb, config, cfg)
 
 	// Validate priority transaction
-	if tx.Type() == types.PriorityTxType {
-		transactor, found := statedb.GetPriorityTransactorByKey(msg.PrioritySender())
-		if !found {
-			return nil, fmt.Errorf("could not apply tx [%v]: %w", tx.Hash().Hex(), errBadPriorityKey)
-		}
-		if !transactor.IsGasPriceWaiver && tx.HasZeroFee() {
-			return nil, fmt.Errorf("could not apply tx [%v]: %w", tx.Hash().Hex(), errNoGasPriceWaiver)
+	if tx.IsPriority() {
+		if err := validatePriorityTransaction(config, header.Number, statedb, tx, msg); err != nil {
+			return nil, fmt.Errorf("could not apply tx [%v]: %w", tx.Hash().Hex(), err)
 		}
 	}
+	// Make sure the sender is permitted to send the transaction
+	if err := CheckAccountPermissions(vmenv, msg.From(), msg.To() == nil); err != nil {
+		return nil, fmt.Errorf("could not apply tx [%v]: %w", tx.Hash().Hex(), err)
+	}
 	return applyTransaction(msg, config, bc, author, gp, statedb, header.Number, header.Hash(), tx, usedGas, vmenv)
 }
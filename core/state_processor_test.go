@@ -18,6 +18,7 @@ package core
 
 import (
 	"crypto/ecdsa"
+	"errors"
 	"math/big"
 	"testing"
 
@@ -299,6 +300,74 @@ func TestStateProcessorErrors(t *testing.T) {
 	}
 }
 
+// Tests that blocks containing transactions of accounts not permitted to send
+// them by the account permissions contract are rejected.
+func TestStateProcessorAccountPermissions(t *testing.T) {
+	var (
+		key1, _     = crypto.HexToECDSA("b71c71a67e1177ad4e901695e1b4b9ee17ae16c6668d313eac2f96dbcda3f291")
+		key2, _     = crypto.HexToECDSA("0202020202020202020202020202020202020202020202020202002020202020")
+		permitted   = crypto.PubkeyToAddress(key1.PublicKey)
+		banned      = crypto.PubkeyToAddress(key2.PublicKey)
+		permissions = common.HexToAddress("0x0000000000000000000000000000000000001002")
+	)
+	config := *params.AllEthashProtocolChanges
+	config.Transitions = []params.Transition{{Block: big.NewInt(1), AccountPermissionsContractAddress: permissions}}
+	signer := types.LatestSigner(&config)
+
+	// The contract allows every account but the banned one to transact, and no
+	// account to deploy contracts:
+	//   canTransact = calldata[4:36] != banned, canDeploy = false
+	code := append(append([]byte{byte(vm.PUSH1), 0x04, byte(vm.CALLDATALOAD), byte(vm.PUSH20)}, banned.Bytes()...),
+		byte(vm.EQ), byte(vm.ISZERO), byte(vm.PUSH1), 0x00, byte(vm.MSTORE),
+		byte(vm.PUSH1), 0x00, byte(vm.PUSH1), 0x20, byte(vm.MSTORE),
+		byte(vm.PUSH1), 0x40, byte(vm.PUSH1), 0x00, byte(vm.RETURN),
+	)
+	var (
+		db    = rawdb.NewMemoryDatabase()
+		gspec = &Genesis{
+			Config: &config,
+			Alloc: GenesisAlloc{
+				permitted:   {Balance: big.NewInt(1000000000000000000)},
+				banned:      {Balance: big.NewInt(1000000000000000000)},
+				permissions: {Code: code, Balance: big.NewInt(0)},
+			},
+		}
+		genesis       = gspec.MustCommit(db)
+		blockchain, _ = NewBlockChain(db, nil, gspec.Config, ethash.NewFaker(), vm.Config{}, nil, nil)
+	)
+	defer blockchain.Stop()
+
+	makeTx := func(key *ecdsa.PrivateKey, to *common.Address) *types.Transaction {
+		var tx *types.Transaction
+		if to == nil {
+			tx = types.NewContractCreation(0, big.NewInt(0), 100000, big.NewInt(875000000), nil)
+		} else {
+			tx = types.NewTransaction(0, *to, big.NewInt(0), params.TxGas, big.NewInt(875000000), nil)
+		}
+		tx, _ = types.SignTx(tx, signer, key)
+		return tx
+	}
+	for i, tt := range []struct {
+		tx   *types.Transaction
+		want error
+	}{
+		{makeTx(key2, &permitted), ErrAccountNotPermitted},
+		{makeTx(key1, nil), ErrDeployNotPermitted},
+	} {
+		block := GenerateBadBlock(genesis, ethash.NewFaker(), types.Transactions{tt.tx}, gspec.Config)
+		if _, err := blockchain.InsertChain(types.Blocks{block}); !errors.Is(err, tt.want) {
+			t.Errorf("test %d: error mismatch: have %v, want %v", i, err, tt.want)
+		}
+	}
+	// Transactions of permitted accounts are accepted
+	blocks, _ := GenerateChain(gspec.Config, genesis, ethash.NewFaker(), db, 1, func(i int, b *BlockGen) {
+		b.AddTx(makeTx(key1, &banned))
+	})
+	if _, err := blockchain.InsertChain(blocks); err != nil {
+		t.Fatalf("failed to import permitted transaction: %v", err)
+	}
+}
+
 // GenerateBadBlock constructs a "block" which contains the transactions. The transactions are not expected to be
 // valid, and no proper post-state can be made. But from the perspective of the blockchain, the block is sufficiently
 // valid to be considered for import:
@@ -0,0 +1,134 @@
+// Copyright 2023 Electroneum Ltd
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package core
+
+import (
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/electroneum/electroneum-sc/common"
+	"github.com/electroneum/electroneum-sc/core/types"
+	lru "github.com/hashicorp/golang-lru"
+)
+
+// txDropHistory is the number of rejected or evicted transactions the pool
+// remembers the reason of.
+const txDropHistory = 4096
+
+// Reasons for a transaction to be rejected or evicted by the pool. Transactions
+// leaving the pool because their nonce was used, most likely by their inclusion
+// in a block, are not considered dropped.
+const (
+	TxDropInvalid     = "invalid"     // Rejected by the validation rules
+	TxDropUnderpriced = "underpriced" // Paying less than the pool minimum, the rest of the full pool or the transaction it replaces
+	TxDropOverflow    = "overflow"    // Exceeding the pool or account limits
+	TxDropQuota       = "quota"       // Exceeding the quotas of the priority transactor
+	TxDropReplaced    = "replaced"    // Replaced by a transaction with the same nonce and a higher price
+	TxDropRevoked     = "revoked"     // Co-signed by a priority key no longer allowed by the contract
+	TxDropUnpermitted = "unpermitted" // Sent by an account not permitted to send it by the account permissions contract
+	TxDropUnpayable   = "unpayable"   // Sender balance or block gas limit no longer covers the transaction
+	TxDropExpired     = "expired"     // Queued for longer than the pool lifetime
+)
+
+// TxDrop records why a transaction was rejected or evicted by the pool.
+type TxDrop struct {
+	Hash     common.Hash
+	Priority bool      // Whether the transaction is a priority transaction
+	Reason   string    // One of the TxDrop reasons
+	Error    string    // Error the transaction was rejected with, if any
+	Time     time.Time // Time the transaction was dropped
+}
+
+// txDropReason returns the drop reason for a transaction rejected by the pool
+// with the given error.
+func txDropReason(err error) string {
+	switch {
+	case errors.Is(err, ErrUnderpriced), errors.Is(err, ErrReplaceUnderpriced):
+		return TxDropUnderpriced
+	case errors.Is(err, ErrTxPoolOverflow), errors.Is(err, ErrPriorityTxPoolOverflow):
+		return TxDropOverflow
+	case errors.Is(err, ErrPriorityGasQuota), errors.Is(err, ErrPriorityPendingQuota), errors.Is(err, ErrPriorityWaiverQuota):
+		return TxDropQuota
+	case errors.Is(err, ErrAccountNotPermitted), errors.Is(err, ErrDeployNotPermitted):
+		return TxDropUnpermitted
+	default:
+		return TxDropInvalid
+	}
+}
+
+// txDrops is a bounded record of the transactions dropped by the pool, along
+// with the drops not yet announced to the subscribers.
+type txDrops struct {
+	recent *lru.Cache // Most recent drops, by transaction hash
+
+	lock   sync.Mutex
+	unsent []*TxDrop // Drops to announce once the pool lock is released
+}
+
+func newTxDrops() *txDrops {
+	recent, _ := lru.New(txDropHistory)
+	return &txDrops{recent: recent}
+}
+
+// add records the drop of a transaction, for the given reason.
+func (d *txDrops) add(tx *types.Transaction, reason string, err error) {
+	drop := &TxDrop{
+		Hash:     tx.Hash(),
+		Priority: IsPriorityTransaction(tx),
+		Reason:   reason,
+		Time:     time.Now(),
+	}
+	if err != nil {
+		drop.Error = err.Error()
+	}
+	d.recent.Add(drop.Hash, drop)
+
+	d.lock.Lock()
+	d.unsent = append(d.unsent, drop)
+	d.lock.Unlock()
+}
+
+// addAll records the drop of a batch of transactions, for the same reason.
+func (d *txDrops) addAll(txs types.Transactions, reason string) {
+	for _, tx := range txs {
+		d.add(tx, reason, nil)
+	}
+}
+
+// get returns the drop of the given transaction, if still remembered.
+func (d *txDrops) get(hash common.Hash) *TxDrop {
+	if drop, ok := d.recent.Get(hash); ok {
+		return drop.(*TxDrop)
+	}
+	return nil
+}
+
+// forget removes the drop of a transaction, which made it back into the pool.
+func (d *txDrops) forget(hash common.Hash) {
+	d.recent.Remove(hash)
+}
+
+// take returns the drops not announced yet, and resets them.
+func (d *txDrops) take() []*TxDrop {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+
+	unsent := d.unsent
+	d.unsent = nil
+	return unsent
+}
@@ -283,7 +283,7 @@ func (l *txList) Overlaps(tx *types.Transaction) bool {
 // thresholds are also potentially updated.
 func (l *txList) Add(tx *types.Transaction, priceBump uint64) (bool, *types.Transaction) {
 	// Only accept transactions that are compatible with the list (only regular transactions or only priority transactions)
-	if l.isPriority && tx.Type() != types.PriorityTxType || !l.isPriority && tx.Type() == types.PriorityTxType {
+	if l.isPriority != tx.IsPriority() {
 		return false, nil
 	}
 
@@ -515,7 +515,7 @@ func newTxPricedList(all *txLookup) *txPricedList {
 // Put inserts a new transaction into the heap.
 func (l *txPricedList) Put(tx *types.Transaction, local bool) {
 	// Ignore local and priority transactions
-	if local || tx.Type() == types.PriorityTxType {
+	if local || tx.IsPriority() {
 		return
 	}
 	// Insert every new transaction to the urgent heap first; Discard will balance the heaps
@@ -76,6 +76,18 @@ var (
 	// another remote priority transaction.
 	ErrPriorityTxPoolOverflow = errors.New("priority tx pool is full")
 
+	// ErrPriorityGasQuota is returned if a priority transaction uses more gas than
+	// its transactor is allowed in a block.
+	ErrPriorityGasQuota = errors.New("exceeds priority transactor block gas quota")
+
+	// ErrPriorityPendingQuota is returned if the transactor of a priority transaction
+	// already has as many transactions in the pool as it is allowed.
+	ErrPriorityPendingQuota = errors.New("exceeds priority transactor pending quota")
+
+	// ErrPriorityWaiverQuota is returned if a zero fee priority transaction would
+	// exceed the gas its transactor is allowed to have waived in the epoch.
+	ErrPriorityWaiverQuota = errors.New("exceeds priority transactor waived gas quota")
+
 	// ErrReplaceUnderpriced is returned if a transaction is attempted to be replaced
 	// with a different one without the required price bump.
 	ErrReplaceUnderpriced = errors.New("replacement transaction underpriced")
@@ -124,6 +136,12 @@ var (
 	underpricedTxMeter        = metrics.NewRegisteredMeter("txpool/underpriced", nil)
 	overflowedTxMeter         = metrics.NewRegisteredMeter("txpool/overflowed", nil)
 	overflowedPriorityTxMeter = metrics.NewRegisteredMeter("txpool/priorityoverflowed", nil)
+
+	// Metrics for the priority transactions rejected by the quotas of their transactor
+	priorityGasQuotaMeter     = metrics.NewRegisteredMeter("txpool/priority/quota/gas", nil)
+	priorityPendingQuotaMeter = metrics.NewRegisteredMeter("txpool/priority/quota/pending", nil)
+	priorityWaiverQuotaMeter  = metrics.NewRegisteredMeter("txpool/priority/quota/waived", nil)
+
 	// throttleTxMeter counts how many transactions are rejected due to too-many-changes between
 	// txpool reorgs.
 	throttleTxMeter = metrics.NewRegisteredMeter("txpool/throttle", nil)
@@ -161,6 +179,8 @@ type blockChain interface {
 	StateAt(root common.Hash) (*state.StateDB, error)
 	SubscribeChainHeadEvent(ch chan<- ChainHeadEvent) event.Subscription
 	MustGetPriorityTransactorsForState(header *types.Header, state *state.StateDB) common.PriorityTransactorMap
+	GetAccountPermissionsForState(header *types.Header, state *state.StateDB, account common.Address) (AccountPermissions, error)
+	GetReceiptsByHash(hash common.Hash) types.Receipts
 }
 
 // TxPoolConfig are the configuration parameters of the transaction pool.
@@ -262,6 +282,7 @@ type TxPool struct {
 	chain       blockChain
 	gasPrice    *big.Int
 	txFeed      event.Feed
+	dropFeed    event.Feed
 	scope       event.SubscriptionScope
 	signer      types.Signer
 	mu          sync.RWMutex
@@ -270,6 +291,8 @@ type TxPool struct {
 	eip2718  bool // Fork indicator whether we are using EIP-2718 type transactions.
 	eip1559  bool // Fork indicator whether we are using EIP-1559 type transactions.
 
+	multiPriority bool // Fork indicator whether we are using multi-priority transactions.
+
 	currentState  *state.StateDB // Current state in the blockchain head
 	pendingNonces *txNoncer      // Pending state tracking virtual nonces
 	currentMaxGas uint64         // Current gas limit for transaction caps
@@ -282,6 +305,7 @@ type TxPool struct {
 	beats   map[common.Address]time.Time // Last heartbeat from each known account
 	all     *txLookup                    // All transactions to allow lookups
 	priced  *txPricedList                // All transactions sorted by price
+	drops   *txDrops                     // Recently rejected or evicted transactions
 
 	chainHeadCh     chan ChainHeadEvent
 	chainHeadSub    event.Subscription
@@ -296,6 +320,10 @@ type TxPool struct {
 	changesSinceReorg int // A counter for how many drops we've performed in-between reorg.
 
 	currentPriorityTransactors common.PriorityTransactorMap
+	priorityQuotas             priorityQuotas // Gas waived for the priority transactors in the current epoch
+
+	currentHead        *types.Header                         // Current head of the blockchain
+	accountPermissions map[common.Address]AccountPermissions // Permissions of the senders at the current head, filled lazily
 }
 
 type txpoolResetRequest struct {
@@ -316,7 +344,7 @@ func NewTxPool(config TxPoolConfig, chainconfig *params.ChainConfig, chain block
 		pending:         make(map[common.Address]*txList),
 		queue:           make(map[common.Address]*txList),
 		beats:           make(map[common.Address]time.Time),
-		all:             newTxLookup(),
+		drops:           newTxDrops(),
 		chainHeadCh:     make(chan ChainHeadEvent, chainHeadChanSize),
 		reqResetCh:      make(chan *txpoolResetRequest),
 		reqPromoteCh:    make(chan *accountSet),
@@ -326,6 +354,7 @@ func NewTxPool(config TxPoolConfig, chainconfig *params.ChainConfig, chain block
 		initDoneCh:      make(chan struct{}),
 		gasPrice:        new(big.Int).SetUint64(config.PriceLimit),
 	}
+	pool.all = newTxLookup(pool.signer)
 	pool.locals = newAccountSet(pool.signer)
 	for _, addr := range config.Locals {
 		log.Info("Setting new local account", "address", addr)
@@ -333,6 +362,7 @@ func NewTxPool(config TxPoolConfig, chainconfig *params.ChainConfig, chain block
 	}
 	pool.priced = newTxPricedList(pool.all)
 	pool.reset(nil, chain.CurrentBlock().Header())
+	pool.priorityQuotas = pool.priorityQuotas.advance(chain, chainconfig, pool.signer, chain.CurrentBlock().Header())
 
 	// Start the reorg loop early so it can handle requests generated during journal loading.
 	pool.wg.Add(1)
@@ -419,10 +449,12 @@ func (pool *TxPool) loop() {
 					for _, tx := range list {
 						pool.removeTx(tx.Hash(), true)
 					}
+					pool.drops.addAll(list, TxDropExpired)
 					queuedEvictionMeter.Mark(int64(len(list)))
 				}
 			}
 			pool.mu.Unlock()
+			pool.sendDrops()
 
 		// Handle local transaction journal rotation
 		case <-journal.C:
@@ -458,6 +490,21 @@ func (pool *TxPool) SubscribeNewTxsEvent(ch chan<- NewTxsEvent) event.Subscripti
 	return pool.scope.Track(pool.txFeed.Subscribe(ch))
 }
 
+// SubscribeDropTxsEvent registers a subscription of DropTxsEvent and
+// starts sending event to the given channel.
+func (pool *TxPool) SubscribeDropTxsEvent(ch chan<- DropTxsEvent) event.Subscription {
+	return pool.scope.Track(pool.dropFeed.Subscribe(ch))
+}
+
+// sendDrops announces the transactions dropped since the last announcement.
+//
+// Note, this method must be called without holding the pool lock!
+func (pool *TxPool) sendDrops() {
+	if drops := pool.drops.take(); len(drops) > 0 {
+		pool.dropFeed.Send(DropTxsEvent{drops})
+	}
+}
+
 // GasPrice returns the current gas price enforced by the transaction pool.
 func (pool *TxPool) GasPrice() *big.Int {
 	pool.mu.RLock()
@@ -470,6 +517,7 @@ func (pool *TxPool) GasPrice() *big.Int {
 // new transaction, and drops all transactions below this threshold.
 func (pool *TxPool) SetGasPrice(price *big.Int) {
 	pool.mu.Lock()
+	defer pool.sendDrops() // Deferred first, so it runs once the lock is released
 	defer pool.mu.Unlock()
 
 	old := pool.gasPrice
@@ -485,6 +533,7 @@ func (pool *TxPool) SetGasPrice(price *big.Int) {
 				prTxCount++
 			}
 		}
+		pool.drops.addAll(drop, TxDropUnderpriced)
 		pool.priced.Removed(len(drop) - prTxCount)
 	}
 
@@ -625,6 +674,127 @@ func (pool *TxPool) PendingPriority(enforceTips bool) map[common.Address]types.T
 	return pending
 }
 
+// PriorityWaivedGas retrieves the gas waived for each priority transactor since
+// the start of the epoch of the current head.
+func (pool *TxPool) PriorityWaivedGas() map[common.PublicKey]uint64 {
+	pool.mu.RLock()
+	defer pool.mu.RUnlock()
+
+	waived := make(map[common.PublicKey]uint64, len(pool.priorityQuotas.waived))
+	for pubkey, gas := range pool.priorityQuotas.waived {
+		waived[pubkey] = gas
+	}
+	return waived
+}
+
+// priorityTxCount returns the number of transactions in the pool signed with the
+// priority key, not counting the one that tx would replace.
+func (pool *TxPool) priorityTxCount(pubkey common.PublicKey, from common.Address, tx *types.Transaction) uint64 {
+	count := pool.all.PriorityCount(pubkey)
+	for _, list := range []*txList{pool.pending[from], pool.queue[from]} {
+		if list == nil {
+			continue
+		}
+		if old := list.txs.Get(tx.Nonce()); old != nil && count > 0 {
+			if key, err := types.PrioritySender(pool.signer, old); err == nil && key == pubkey {
+				count--
+			}
+		}
+	}
+	return count
+}
+
+// priorityTransactor returns the current priority transactor of the given key.
+func (pool *TxPool) priorityTransactor(pubkey common.PublicKey) (common.PriorityTransactor, bool) {
+	transactor, ok := pool.currentPriorityTransactors[pubkey]
+	return transactor, ok
+}
+
+// priorityAuthorised reports whether the priority keys that co-signed a pooled
+// transaction are still allowed to authorise it by the current transactors.
+func (pool *TxPool) priorityAuthorised(tx *types.Transaction) bool {
+	pubkeys, _ := types.PrioritySenders(pool.signer, tx) // no need to deal with error because this has already been validated once before
+	_, err := ValidatePrioritySenders(pubkeys, pool.priorityTransactor)
+	return err == nil
+}
+
+// checkAccountPermissions returns the error, if any, of the sender of a
+// transaction not being permitted to send it by the account permissions contract
+// at the next block. The permissions are cached until the next reset.
+func (pool *TxPool) checkAccountPermissions(from common.Address, tx *types.Transaction) error {
+	permissions, ok := pool.accountPermissions[from]
+	if !ok {
+		var err error
+		if permissions, err = pool.chain.GetAccountPermissionsForState(pool.currentHead, pool.currentState, from); err != nil {
+			return err
+		}
+		pool.accountPermissions[from] = permissions
+	}
+	return permissions.Check(tx.To() == nil)
+}
+
+// senderPermissions looks up the permissions of the senders of the pooled
+// transactions at the given head, on a state of its own so the pool doesn't need
+// to be locked. Nil is returned if there's no account permissions contract at
+// the block after the head.
+func (pool *TxPool) senderPermissions(head *types.Header) map[common.Address]AccountPermissions {
+	next := new(big.Int).Add(head.Number, big.NewInt(1))
+	if pool.chainconfig.GetAccountPermissionsContractAddress(next) == (common.Address{}) {
+		return nil
+	}
+	statedb, err := pool.chain.StateAt(head.Root)
+	if err != nil {
+		return nil
+	}
+	senders := make(map[common.Address]struct{})
+	collect := func(hash common.Hash, tx *types.Transaction, local bool) bool {
+		from, _ := types.Sender(pool.signer, tx) // already validated during insertion
+		senders[from] = struct{}{}
+		return true
+	}
+	pool.all.Range(collect, true, true, false)
+	pool.all.Range(collect, true, true, true)
+
+	permissions := make(map[common.Address]AccountPermissions, len(senders))
+	for from := range senders {
+		if p, err := pool.chain.GetAccountPermissionsForState(head, statedb, from); err == nil {
+			permissions[from] = p
+		}
+	}
+	return permissions
+}
+
+// dropUnpermitted evicts the transactions whose senders are no longer permitted
+// to send them by the account permissions contract. The permissions not cached
+// yet are looked up on the current state.
+func (pool *TxPool) dropUnpermitted() {
+	next := new(big.Int).Add(pool.currentHead.Number, big.NewInt(1))
+	if pool.chainconfig.GetAccountPermissionsContractAddress(next) == (common.Address{}) {
+		return
+	}
+	var unpermitted []*types.Transaction
+	collect := func(hash common.Hash, tx *types.Transaction, local bool) bool {
+		from, _ := types.Sender(pool.signer, tx) // already validated during insertion
+		if pool.checkAccountPermissions(from, tx) != nil {
+			unpermitted = append(unpermitted, tx)
+		}
+		return true
+	}
+	pool.all.Range(collect, true, true, false)
+	pool.all.Range(collect, true, true, true)
+
+	for _, tx := range unpermitted {
+		if pool.all.Get(tx.Hash()) == nil {
+			continue
+		}
+		pool.removeTx(tx.Hash(), true)
+		pool.drops.add(tx, TxDropUnpermitted, nil)
+	}
+	if len(unpermitted) > 0 {
+		log.Debug("Removed unpermitted transactions", "count", len(unpermitted))
+	}
+}
+
 // Locals retrieves the accounts currently considered local by the pool.
 func (pool *TxPool) Locals() []common.Address {
 	pool.mu.Lock()
@@ -660,6 +830,10 @@ func (pool *TxPool) validateTx(tx *types.Transaction, local bool) error {
 	if !pool.eip1559 && (tx.Type() == types.DynamicFeeTxType || IsPriorityTransaction(tx)) {
 		return ErrTxTypeNotSupported
 	}
+	// Reject multi-priority transactions until their fork activates.
+	if !pool.multiPriority && tx.Type() == types.MultiPriorityTxType {
+		return ErrTxTypeNotSupported
+	}
 	// Reject transactions over defined size to prevent DOS attacks
 	if uint64(tx.Size()) > txMaxSize {
 		return ErrOversizedData
@@ -684,28 +858,51 @@ func (pool *TxPool) validateTx(tx *types.Transaction, local bool) error {
 	if tx.GasFeeCapIntCmp(tx.GasTipCap()) < 0 {
 		return ErrTipAboveFeeCap
 	}
+	// Bound the number of priority signatures before recovering any of them
+	if len(tx.RawPrioritySignatures()) > params.MaxPrioritySignatures {
+		return types.ErrTooManyPrioritySigs
+	}
 	// Make sure the transaction is signed properly.
 	from, err := types.Sender(pool.signer, tx)
 	if err != nil {
 		return ErrInvalidSender
 	}
 	isGasWaiver := false
-	if tx.Type() == types.PriorityTxType {
-		// Make sure the priority signature checks out
-		priorityPubkey, err := types.PrioritySender(pool.signer, tx)
+	if tx.IsPriority() {
+		// Make sure the priority signatures check out
+		priorityPubkeys, err := types.PrioritySenders(pool.signer, tx)
 		if err != nil {
 			return errBadPrioritySignature
 		}
-		// Make sure the priority public key is an allowed one
-		transactor, exists := pool.currentPriorityTransactors[priorityPubkey]
-		if !exists {
-			return errBadPriorityKey
+		priorityPubkey := priorityPubkeys[0]
+
+		// Make sure the priority public keys are allowed to authorise the transaction
+		transactor, err := ValidatePrioritySenders(priorityPubkeys, pool.priorityTransactor)
+		if err != nil {
+			return err
 		}
 		isGasWaiver = transactor.IsGasPriceWaiver
 		// Assure transaction gasprice, fee and tip are > 0 for non gas waiver transactors
 		if !isGasWaiver && tx.HasZeroFee() {
 			return errNoGasPriceWaiver
 		}
+		// Ensure the transactor stays within its quotas
+		if transactor.MaxGasPerBlock != 0 && tx.Gas() > transactor.MaxGasPerBlock {
+			priorityGasQuotaMeter.Mark(1)
+			return ErrPriorityGasQuota
+		}
+		if transactor.MaxPendingTxs != 0 && pool.priorityTxCount(priorityPubkey, from, tx) >= transactor.MaxPendingTxs {
+			priorityPendingQuotaMeter.Mark(1)
+			return ErrPriorityPendingQuota
+		}
+		if transactor.MaxWaivedGasPerEpoch != 0 && tx.HasZeroFee() && pool.priorityQuotas.waived[priorityPubkey]+tx.Gas() > transactor.MaxWaivedGasPerEpoch {
+			priorityWaiverQuotaMeter.Mark(1)
+			return ErrPriorityWaiverQuota
+		}
+	}
+	// Make sure the sender is permitted to send the transaction
+	if err := pool.checkAccountPermissions(from, tx); err != nil {
+		return err
 	}
 	// Drop non-local transactions under our own minimal accepted gas price or tip
 	if !local && !isGasWaiver && tx.GasTipCapIntCmp(pool.gasPrice) < 0 {
@@ -800,6 +997,7 @@ func (pool *TxPool) add(tx *types.Transaction, local bool) (replaced bool, err e
 			log.Trace("Discarding freshly underpriced transaction", "hash", tx.Hash(), "gasTipCap", tx.GasTipCap(), "gasFeeCap", tx.GasFeeCap())
 			underpricedTxMeter.Mark(1)
 			pool.removeTx(tx.Hash(), false)
+			pool.drops.add(tx, TxDropUnderpriced, nil)
 		}
 	}
 	// Try to replace an existing transaction in the pending pool
@@ -817,6 +1015,7 @@ func (pool *TxPool) add(tx *types.Transaction, local bool) (replaced bool, err e
 		// New transaction is better, replace old one
 		if old != nil {
 			pool.all.Remove(old.Hash())
+			pool.drops.add(old, TxDropReplaced, nil)
 			if !list.isPriority {
 				pool.priced.Removed(1)
 			}
@@ -877,6 +1076,7 @@ func (pool *TxPool) enqueueTx(hash common.Hash, tx *types.Transaction, local boo
 	// Discard any previous transaction and mark this
 	if old != nil {
 		pool.all.Remove(old.Hash())
+		pool.drops.add(old, TxDropReplaced, nil)
 		if !pool.queue[from].isPriority {
 			pool.priced.Removed(1)
 		}
@@ -928,6 +1128,7 @@ func (pool *TxPool) promoteTx(addr common.Address, hash common.Hash, tx *types.T
 	if !inserted {
 		// An older transaction was better, discard this
 		pool.all.Remove(hash)
+		pool.drops.add(tx, TxDropUnderpriced, ErrReplaceUnderpriced)
 		if !IsPriorityTransaction(tx) {
 			pool.priced.Removed(1)
 		}
@@ -937,6 +1138,7 @@ func (pool *TxPool) promoteTx(addr common.Address, hash common.Hash, tx *types.T
 	// Otherwise discard any previous transaction and mark this
 	if old != nil {
 		pool.all.Remove(old.Hash())
+		pool.drops.add(old, TxDropReplaced, nil)
 		if !IsPriorityTransaction(tx) {
 			pool.priced.Removed(1)
 		}
@@ -1012,6 +1214,14 @@ func (pool *TxPool) addTxs(txs []*types.Transaction, local, sync bool) []error {
 			knownTxMeter.Mark(1)
 			continue
 		}
+		// Exclude transactions co-signed by too many priority keys
+		// before recovering any of the signatures
+		if len(tx.RawPrioritySignatures()) > params.MaxPrioritySignatures {
+			errs[i] = types.ErrTooManyPrioritySigs
+			invalidTxMeter.Mark(1)
+			pool.drops.add(tx, TxDropInvalid, types.ErrTooManyPrioritySigs)
+			continue
+		}
 		// Exclude transactions with invalid signatures as soon as
 		// possible and cache senders in transactions before
 		// obtaining lock
@@ -1019,6 +1229,7 @@ func (pool *TxPool) addTxs(txs []*types.Transaction, local, sync bool) []error {
 		if err != nil {
 			errs[i] = ErrInvalidSender
 			invalidTxMeter.Mark(1)
+			pool.drops.add(tx, TxDropInvalid, ErrInvalidSender)
 			continue
 		}
 		if IsPriorityTransaction(tx) {
@@ -1028,6 +1239,7 @@ func (pool *TxPool) addTxs(txs []*types.Transaction, local, sync bool) []error {
 			if err != nil {
 				errs[i] = ErrInvalidPrioritySender
 				invalidTxMeter.Mark(1)
+				pool.drops.add(tx, TxDropInvalid, ErrInvalidPrioritySender)
 				continue
 			}
 		}
@@ -1035,6 +1247,7 @@ func (pool *TxPool) addTxs(txs []*types.Transaction, local, sync bool) []error {
 		news = append(news, tx)
 	}
 	if len(news) == 0 {
+		pool.sendDrops()
 		return errs
 	}
 
@@ -1042,6 +1255,7 @@ func (pool *TxPool) addTxs(txs []*types.Transaction, local, sync bool) []error {
 	pool.mu.Lock()
 	newErrs, dirtyAddrs := pool.addTxsLocked(news, local)
 	pool.mu.Unlock()
+	pool.sendDrops()
 
 	var nilSlot = 0
 	for _, err := range newErrs {
@@ -1067,6 +1281,12 @@ func (pool *TxPool) addTxsLocked(txs []*types.Transaction, local bool) ([]error,
 	for i, tx := range txs {
 		replaced, err := pool.add(tx, local)
 		errs[i] = err
+		switch {
+		case err == nil:
+			pool.drops.forget(tx.Hash()) // Resubmitted after a drop
+		case err != ErrAlreadyKnown:
+			pool.drops.add(tx, txDropReason(err), err)
+		}
 		if err == nil && !replaced {
 			dirty.addTx(tx)
 		}
@@ -1098,6 +1318,12 @@ func (pool *TxPool) Status(hashes []common.Hash) []TxStatus {
 	return status
 }
 
+// Drop returns why the transaction with the given hash was rejected or evicted
+// by the pool, or nil if it wasn't, or was too long ago to be remembered.
+func (pool *TxPool) Drop(hash common.Hash) *TxDrop {
+	return pool.drops.get(hash)
+}
+
 // Get returns a transaction if it is contained in the pool and nil otherwise.
 func (pool *TxPool) Get(hash common.Hash) *types.Transaction {
 	return pool.all.Get(hash)
@@ -1275,11 +1501,41 @@ func (pool *TxPool) runReorg(done chan struct{}, reset *txpoolResetRequest, dirt
 		// the flatten operation can be avoided.
 		promoteAddrs = dirtyAccounts.flatten()
 	}
+	// Account for the gas waived in the new head before taking the lock, as the
+	// quotas epoch may need to be replayed from the database. Only this loop moves
+	// the tracker, so reading it here doesn't race with the pool.
+	// The permissions of the pooled senders at the new head are looked up before
+	// too, as it takes an EVM call per sender.
+	var (
+		head        *types.Header
+		quotas      priorityQuotas
+		permissions map[common.Address]AccountPermissions
+	)
+	if reset != nil {
+		head = reset.newHead
+		if head == nil {
+			head = pool.chain.CurrentBlock().Header() // Special case during testing
+		}
+		quotas = pool.priorityQuotas.advance(pool.chain, pool.chainconfig, pool.signer, head)
+		permissions = pool.senderPermissions(head)
+	}
 	pool.mu.Lock()
 	if reset != nil {
 		// Reset from the old head to the new, rescheduling any reorged transactions
+		pool.priorityQuotas = quotas
 		pool.reset(reset.oldHead, reset.newHead)
 
+		// Evict the transactions of the accounts no longer permitted to send them.
+		// Only the senders that arrived since the lookup are checked under the lock.
+		if pool.currentHead.Hash() == head.Hash() {
+			for addr, p := range permissions {
+				if _, ok := pool.accountPermissions[addr]; !ok {
+					pool.accountPermissions[addr] = p
+				}
+			}
+		}
+		pool.dropUnpermitted()
+
 		// Nonces were reset, discard any events that became stale
 		for addr := range events {
 			events[addr].Forward(pool.pendingNonces.get(addr))
@@ -1324,6 +1580,7 @@ func (pool *TxPool) runReorg(done chan struct{}, reset *txpoolResetRequest, dirt
 	dropBetweenReorgHistogram.Update(int64(pool.changesSinceReorg))
 	pool.changesSinceReorg = 0 // Reset change counter
 	pool.mu.Unlock()
+	pool.sendDrops()
 
 	// Notify subsystems for newly added transactions
 	for _, tx := range promoted {
@@ -1423,6 +1680,10 @@ func (pool *TxPool) reset(oldHead, newHead *types.Header) {
 
 	pool.currentPriorityTransactors = pool.chain.MustGetPriorityTransactorsForState(newHead, pool.currentState)
 
+	// The permissions of the senders are looked up again for the new head
+	pool.currentHead = newHead
+	pool.accountPermissions = make(map[common.Address]AccountPermissions)
+
 	// Inject any transactions discarded due to reorgs
 	log.Debug("Reinjecting stale transactions", "count", len(reinject))
 	senderCacher.recover(pool.signer, reinject)
@@ -1433,6 +1694,7 @@ func (pool *TxPool) reset(oldHead, newHead *types.Header) {
 	pool.istanbul = pool.chainconfig.IsIstanbul(next)
 	pool.eip2718 = pool.chainconfig.IsBerlin(next)
 	pool.eip1559 = pool.chainconfig.IsLondon(next)
+	pool.multiPriority = pool.chainconfig.IsMultiPriority(next)
 }
 
 // promoteExecutables moves transactions that have become processable from the
@@ -1451,11 +1713,9 @@ func (pool *TxPool) promoteExecutables(accounts []common.Address) []*types.Trans
 
 		// kick priority tx that have a key that's expired
 		for _, tx := range list.Flatten() {
-			if tx.Type() == types.PriorityTxType && !pool.locals.containsTx(tx) {
-				priorityPubkey, _ := types.PrioritySender(pool.signer, tx) // no need to deal with error because this has already been validated once before
-				if _, ok := pool.currentPriorityTransactors[priorityPubkey]; !ok {
-					pool.all.Remove(tx.Hash())
-				}
+			if tx.IsPriority() && !pool.locals.containsTx(tx) && !pool.priorityAuthorised(tx) {
+				pool.all.Remove(tx.Hash())
+				pool.drops.add(tx, TxDropRevoked, nil)
 			}
 		}
 
@@ -1472,6 +1732,7 @@ func (pool *TxPool) promoteExecutables(accounts []common.Address) []*types.Trans
 			hash := tx.Hash()
 			pool.all.Remove(hash)
 		}
+		pool.drops.addAll(drops, TxDropUnpayable)
 		log.Trace("Removed unpayable queued transactions", "count", len(drops))
 		queuedNofundsMeter.Mark(int64(len(drops)))
 
@@ -1495,6 +1756,7 @@ func (pool *TxPool) promoteExecutables(accounts []common.Address) []*types.Trans
 				pool.all.Remove(hash)
 				log.Trace("Removed cap-exceeding queued transaction", "hash", hash)
 			}
+			pool.drops.addAll(caps, TxDropOverflow)
 			queuedRateLimitMeter.Mark(int64(len(caps)))
 		}
 		// Mark all the items dropped as removed
@@ -1564,6 +1826,7 @@ func (pool *TxPool) truncatePending() {
 						pool.pendingNonces.setIfLower(offenders[i], tx.Nonce())
 						log.Trace("Removed fairness-exceeding pending transaction", "hash", hash)
 					}
+					pool.drops.addAll(caps, TxDropOverflow)
 					pool.priced.Removed(len(caps))
 					pendingGauge.Dec(int64(len(caps)))
 					if pool.locals.contains(offenders[i]) {
@@ -1591,6 +1854,7 @@ func (pool *TxPool) truncatePending() {
 					pool.pendingNonces.setIfLower(addr, tx.Nonce())
 					log.Trace("Removed fairness-exceeding pending transaction", "hash", hash)
 				}
+				pool.drops.addAll(caps, TxDropOverflow)
 				pool.priced.Removed(len(caps))
 				pendingGauge.Dec(int64(len(caps)))
 				if pool.locals.contains(addr) {
@@ -1654,6 +1918,7 @@ func (pool *TxPool) truncatePriorityPending() {
 						pool.pendingNonces.setIfLower(offenders[i], tx.Nonce())
 						log.Trace("Removed fairness-exceeding pending transaction", "hash", hash)
 					}
+					pool.drops.addAll(caps, TxDropOverflow)
 					pendingGauge.Dec(int64(len(caps)))
 					if pool.locals.contains(offenders[i]) {
 						localGauge.Dec(int64(len(caps)))
@@ -1680,6 +1945,7 @@ func (pool *TxPool) truncatePriorityPending() {
 					pool.pendingNonces.setIfLower(addr, tx.Nonce())
 					log.Trace("Removed fairness-exceeding pending transaction", "hash", hash)
 				}
+				pool.drops.addAll(caps, TxDropOverflow)
 				pendingGauge.Dec(int64(len(caps)))
 				if pool.locals.contains(addr) {
 					localGauge.Dec(int64(len(caps)))
@@ -1724,6 +1990,7 @@ func (pool *TxPool) truncateQueue() {
 		if size := uint64(list.Len()); size <= drop {
 			for _, tx := range list.Flatten() {
 				pool.removeTx(tx.Hash(), true)
+				pool.drops.add(tx, TxDropOverflow, nil)
 			}
 			drop -= size
 			queuedRateLimitMeter.Mark(int64(size))
@@ -1733,6 +2000,7 @@ func (pool *TxPool) truncateQueue() {
 		txs := list.Flatten()
 		for i := len(txs) - 1; i >= 0 && drop > 0; i-- {
 			pool.removeTx(txs[i].Hash(), true)
+			pool.drops.add(txs[i], TxDropOverflow, nil)
 			drop--
 			queuedRateLimitMeter.Mark(1)
 		}
@@ -1771,6 +2039,7 @@ func (pool *TxPool) truncatePriorityQueue() {
 		if size := uint64(list.Len()); size <= drop {
 			for _, tx := range list.Flatten() {
 				pool.removeTx(tx.Hash(), true)
+				pool.drops.add(tx, TxDropOverflow, nil)
 			}
 			drop -= size
 			queuedRateLimitMeter.Mark(int64(size))
@@ -1780,6 +2049,7 @@ func (pool *TxPool) truncatePriorityQueue() {
 		txs := list.Flatten()
 		for i := len(txs) - 1; i >= 0 && drop > 0; i-- {
 			pool.removeTx(txs[i].Hash(), true)
+			pool.drops.add(txs[i], TxDropOverflow, nil)
 			drop--
 			queuedRateLimitMeter.Mark(1)
 		}
@@ -1802,11 +2072,9 @@ func (pool *TxPool) demoteUnexecutables() {
 		// again (priority reestablished in the next few hours) is pretty much zero and we dont want to waste resources
 		// populating the queue unnecessarily and waste an account slot that could be used for another priority sender
 		for _, tx := range list.Flatten() {
-			if tx.Type() == types.PriorityTxType && !pool.locals.containsTx(tx) {
-				priorityPubkey, _ := types.PrioritySender(pool.signer, tx) // no need to deal with error because this has already been validated once before
-				if _, ok := pool.currentPriorityTransactors[priorityPubkey]; !ok {
-					pool.all.Remove(tx.Hash())
-				}
+			if tx.IsPriority() && !pool.locals.containsTx(tx) && !pool.priorityAuthorised(tx) {
+				pool.all.Remove(tx.Hash())
+				pool.drops.add(tx, TxDropRevoked, nil)
 			}
 		}
 
@@ -1824,6 +2092,7 @@ func (pool *TxPool) demoteUnexecutables() {
 			log.Trace("Removed unpayable pending transaction", "hash", hash)
 			pool.all.Remove(hash)
 		}
+		pool.drops.addAll(drops, TxDropUnpayable)
 		pendingNofundsMeter.Mark(int64(len(drops)))
 
 		for _, tx := range invalids {
@@ -1859,7 +2128,7 @@ func (pool *TxPool) demoteUnexecutables() {
 }
 
 func IsPriorityTransaction(tx *types.Transaction) bool {
-	return tx.Type() == types.PriorityTxType
+	return tx.IsPriority()
 }
 
 // addressByHeartbeat is an account address tagged with its last activity timestamp.
@@ -1964,15 +2233,20 @@ type txLookup struct {
 	remotes         map[common.Hash]*types.Transaction
 	localsPriority  map[common.Hash]*types.Transaction
 	remotesPriority map[common.Hash]*types.Transaction
+
+	signer         types.Signer                // Signer to recover the priority keys with
+	priorityCounts map[common.PublicKey]uint64 // Number of transactions signed with each priority key
 }
 
 // newTxLookup returns a new txLookup structure.
-func newTxLookup() *txLookup {
+func newTxLookup(signer types.Signer) *txLookup {
 	return &txLookup{
 		locals:          make(map[common.Hash]*types.Transaction),
 		remotes:         make(map[common.Hash]*types.Transaction),
 		localsPriority:  make(map[common.Hash]*types.Transaction),
 		remotesPriority: make(map[common.Hash]*types.Transaction),
+		signer:          signer,
+		priorityCounts:  make(map[common.PublicKey]uint64),
 	}
 }
 
@@ -2105,6 +2379,15 @@ func (t *txLookup) PrioritySlots() int {
 	return t.prioritySlots
 }
 
+// PriorityCount returns the number of transactions in the lookup signed with the
+// given priority key.
+func (t *txLookup) PriorityCount(pubkey common.PublicKey) uint64 {
+	t.lock.RLock()
+	defer t.lock.RUnlock()
+
+	return t.priorityCounts[pubkey]
+}
+
 // Add adds a transaction to the lookup.
 func (t *txLookup) Add(tx *types.Transaction, local bool) {
 	t.lock.Lock()
@@ -2114,6 +2397,9 @@ func (t *txLookup) Add(tx *types.Transaction, local bool) {
 		t.prioritySlots += numSlots(tx)
 		prioritySlotsGauge.Update(int64(t.prioritySlots))
 
+		if pubkey, err := types.PrioritySender(t.signer, tx); err == nil {
+			t.priorityCounts[pubkey]++
+		}
 		if local {
 			t.localsPriority[tx.Hash()] = tx
 		} else {
@@ -2154,6 +2440,14 @@ func (t *txLookup) Remove(hash common.Hash) {
 	if IsPriorityTransaction(tx) {
 		t.prioritySlots -= numSlots(tx)
 		prioritySlotsGauge.Update(int64(t.prioritySlots))
+
+		if pubkey, err := types.PrioritySender(t.signer, tx); err == nil {
+			if t.priorityCounts[pubkey] <= 1 {
+				delete(t.priorityCounts, pubkey)
+			} else {
+				t.priorityCounts[pubkey]--
+			}
+		}
 	} else {
 		t.slots -= numSlots(tx)
 		slotsGauge.Update(int64(t.slots))
@@ -134,6 +134,14 @@ func (bc *testBlockChain) SubscribeChainHeadEvent(ch chan<- ChainHeadEvent) even
 	return bc.chainHeadFeed.Subscribe(ch)
 }
 
+func (bc *testBlockChain) GetReceiptsByHash(hash common.Hash) types.Receipts {
+	return nil
+}
+
+func (bc *testBlockChain) GetAccountPermissionsForState(header *types.Header, state *state.StateDB, account common.Address) (AccountPermissions, error) {
+	return allAccountPermissions, nil
+}
+
 func (bc *testBlockChain) MustGetPriorityTransactorsForState(header *types.Header, state *state.StateDB) common.PriorityTransactorMap {
 	priorityPubkeys := []string{
 		"04efb99d9860f4dec4cb548a5722c27e9ef58e37fbab9719c5b33d55c216db49311221a01f638ce5f255875b194e0acaa58b19a89d2e56a864427298f826a7f887",
@@ -238,6 +246,21 @@ func priorityTx(nonce uint64, gaslimit uint64, gasFee *big.Int, tip *big.Int, ke
 	return tx
 }
 
+func multiPriorityTx(nonce uint64, gaslimit uint64, gasFee *big.Int, tip *big.Int, key *ecdsa.PrivateKey, priorityKeys ...*ecdsa.PrivateKey) *types.Transaction {
+	tx, _ := types.SignNewMultiPriorityTx(key, priorityKeys, types.LatestSignerForChainID(params.TestChainConfig.ChainID), &types.MultiPriorityTx{
+		ChainID:    params.TestChainConfig.ChainID,
+		Nonce:      nonce,
+		GasTipCap:  tip,
+		GasFeeCap:  gasFee,
+		Gas:        gaslimit,
+		To:         &common.Address{},
+		Value:      big.NewInt(100),
+		Data:       nil,
+		AccessList: nil,
+	})
+	return tx
+}
+
 func setupTxPool() (*TxPool, *ecdsa.PrivateKey) {
 	return setupTxPoolWithConfig(params.TestChainConfig)
 }
@@ -488,6 +511,102 @@ func TestInvalidTransactions(t *testing.T) {
 	}
 }
 
+func TestPriorityTransactorQuotas(t *testing.T) {
+	t.Parallel()
+
+	statedb, _ := state.New(common.Hash{}, state.NewDatabase(rawdb.NewMemoryDatabase()), nil)
+	blockchain := &testBlockChain{1000000, statedb, new(event.Feed), WaiverPriorityTx, common.PriorityTransactorMap{}}
+
+	pool := NewTxPool(testTxPoolConfig, params.TestChainConfig, blockchain)
+	defer pool.Stop()
+
+	key, _ := crypto.GenerateKey()
+	testAddBalance(pool, crypto.PubkeyToAddress(key.PublicKey), new(big.Int).SetUint64(params.Ether))
+
+	// Limit the transactor, which already had 20000 gas waived in the epoch
+	pubkey, _ := types.PrioritySender(pool.signer, priorityTx(0, 21000, big.NewInt(0), big.NewInt(0), key, priorityPrivateKeys[0]))
+	pool.mu.Lock()
+	transactor := pool.currentPriorityTransactors[pubkey]
+	transactor.MaxGasPerBlock, transactor.MaxPendingTxs, transactor.MaxWaivedGasPerEpoch = 50000, 2, 60000
+	pool.currentPriorityTransactors[pubkey] = transactor
+	pool.priorityQuotas.waived = map[common.PublicKey]uint64{pubkey: 20000}
+	pool.mu.Unlock()
+
+	if err := pool.AddRemote(priorityTx(0, 50001, big.NewInt(1), big.NewInt(1), key, priorityPrivateKeys[0])); !errors.Is(err, ErrPriorityGasQuota) {
+		t.Errorf("expected %v, got %v", ErrPriorityGasQuota, err)
+	}
+	if err := pool.AddRemote(priorityTx(0, 50000, big.NewInt(0), big.NewInt(0), key, priorityPrivateKeys[0])); !errors.Is(err, ErrPriorityWaiverQuota) {
+		t.Errorf("expected %v, got %v", ErrPriorityWaiverQuota, err)
+	}
+	// Transactions paying fees aren't limited by the waived gas quota
+	for i, err := range pool.AddRemotesSync([]*types.Transaction{
+		priorityTx(0, 50000, big.NewInt(1), big.NewInt(1), key, priorityPrivateKeys[0]),
+		priorityTx(1, 50000, big.NewInt(1), big.NewInt(1), key, priorityPrivateKeys[0]),
+	}) {
+		if err != nil {
+			t.Fatalf("failed to add transaction %d: %v", i, err)
+		}
+	}
+	if err := pool.AddRemote(priorityTx(2, 50000, big.NewInt(1), big.NewInt(1), key, priorityPrivateKeys[0])); !errors.Is(err, ErrPriorityPendingQuota) {
+		t.Errorf("expected %v, got %v", ErrPriorityPendingQuota, err)
+	}
+	// Replacements don't count towards the pending quota
+	if err := pool.addRemoteSync(priorityTx(1, 50000, big.NewInt(2), big.NewInt(2), key, priorityPrivateKeys[0])); err != nil {
+		t.Errorf("failed to replace transaction: %v", err)
+	}
+	if count := pool.all.PriorityCount(pubkey); count != 2 {
+		t.Errorf("priority transaction count mismatch: have %d, want %d", count, 2)
+	}
+}
+
+// Tests that multi-priority transactions are only accepted when co-signed by
+// enough keys of the same priority transactor entity.
+func TestMultiPriorityTransactorThreshold(t *testing.T) {
+	t.Parallel()
+
+	statedb, _ := state.New(common.Hash{}, state.NewDatabase(rawdb.NewMemoryDatabase()), nil)
+	blockchain := &testBlockChain{1000000, statedb, new(event.Feed), NonWaiverPriorityTx, common.PriorityTransactorMap{}}
+
+	pool := NewTxPool(testTxPoolConfig, params.TestChainConfig, blockchain)
+	defer pool.Stop()
+
+	key, _ := crypto.GenerateKey()
+	testAddBalance(pool, crypto.PubkeyToAddress(key.PublicKey), new(big.Int).SetUint64(params.Ether))
+
+	// Require two signatures from the first three keys, the fourth belonging to another entity
+	pool.mu.Lock()
+	for i, entity := range []string{"acme", "acme", "acme", "other"} {
+		pubkey := common.PublicKey(crypto.FromECDSAPub(&priorityPrivateKeys[i].PublicKey))
+		transactor := pool.currentPriorityTransactors[pubkey]
+		transactor.EntityName, transactor.SignatureThreshold = entity, 2
+		pool.currentPriorityTransactors[pubkey] = transactor
+	}
+	pool.mu.Unlock()
+
+	if err := pool.AddRemote(priorityTx(0, 21000, big.NewInt(1), big.NewInt(1), key, priorityPrivateKeys[0])); !errors.Is(err, errPriorityThreshold) {
+		t.Errorf("single signature: expected %v, got %v", errPriorityThreshold, err)
+	}
+	if err := pool.AddRemote(multiPriorityTx(0, 21000, big.NewInt(1), big.NewInt(1), key, priorityPrivateKeys[0], priorityPrivateKeys[3])); !errors.Is(err, errPriorityEntityMismatch) {
+		t.Errorf("mixed entities: expected %v, got %v", errPriorityEntityMismatch, err)
+	}
+	if err := pool.AddRemote(multiPriorityTx(0, 21000, big.NewInt(1), big.NewInt(1), key, priorityPrivateKeys[0], priorityPrivateKeys[0])); !errors.Is(err, ErrInvalidSender) {
+		t.Errorf("duplicate signature: expected %v, got %v", ErrInvalidSender, err)
+	}
+	cosigners := make([]*ecdsa.PrivateKey, params.MaxPrioritySignatures+1)
+	for i := range cosigners {
+		cosigners[i], _ = crypto.GenerateKey()
+	}
+	if err := pool.AddRemote(multiPriorityTx(0, 21000, big.NewInt(1), big.NewInt(1), key, cosigners...)); !errors.Is(err, types.ErrTooManyPrioritySigs) {
+		t.Errorf("too many signatures: expected %v, got %v", types.ErrTooManyPrioritySigs, err)
+	}
+	if err := pool.addRemoteSync(multiPriorityTx(0, 21000, big.NewInt(1), big.NewInt(1), key, priorityPrivateKeys[1], priorityPrivateKeys[2])); err != nil {
+		t.Errorf("failed to add co-signed transaction: %v", err)
+	}
+	if pending, _ := pool.Stats(); pending != 1 {
+		t.Errorf("pending transactions mismatched: have %d, want %d", pending, 1)
+	}
+}
+
 func TestTransactionQueue(t *testing.T) {
 	t.Parallel()
 
@@ -3722,6 +3841,158 @@ func TestTransactionReplacementDynamicFee(t *testing.T) {
 	}
 }
 
+// Tests that transactions rejected or evicted by the pool have their reason
+// recorded, and announced on the drop feed.
+func TestTransactionDropTracking(t *testing.T) {
+	t.Parallel()
+
+	pool, key := setupTxPool()
+	defer pool.Stop()
+	testAddBalance(pool, crypto.PubkeyToAddress(key.PublicKey), big.NewInt(1000000000))
+
+	drops := make(chan DropTxsEvent, 32)
+	sub := pool.SubscribeDropTxsEvent(drops)
+	defer sub.Unsubscribe()
+
+	// Reject an underpriced replacement, then replace the original transaction
+	original := pricedTransaction(0, 100000, big.NewInt(100), key)
+	if err := pool.addRemoteSync(original); err != nil {
+		t.Fatalf("failed to add original transaction: %v", err)
+	}
+	cheap := pricedTransaction(0, 100001, big.NewInt(100), key)
+	if err := pool.AddRemote(cheap); err != ErrReplaceUnderpriced {
+		t.Fatalf("cheap replacement error mismatch: have %v, want %v", err, ErrReplaceUnderpriced)
+	}
+	replacement := pricedTransaction(0, 100000, big.NewInt(200), key)
+	if err := pool.AddRemote(replacement); err != nil {
+		t.Fatalf("failed to replace original transaction: %v", err)
+	}
+	for _, want := range []struct {
+		tx     *types.Transaction
+		reason string
+		err    string
+	}{
+		{cheap, TxDropUnderpriced, ErrReplaceUnderpriced.Error()},
+		{original, TxDropReplaced, ""},
+	} {
+		drop := pool.Drop(want.tx.Hash())
+		if drop == nil {
+			t.Fatalf("drop of %x not recorded", want.tx.Hash())
+		}
+		if drop.Reason != want.reason || drop.Error != want.err || drop.Priority {
+			t.Errorf("drop of %x mismatch: have %s/%q, want %s/%q", want.tx.Hash(), drop.Reason, drop.Error, want.reason, want.err)
+		}
+		select {
+		case ev := <-drops:
+			if len(ev.Drops) != 1 || ev.Drops[0] != drop {
+				t.Errorf("drop event mismatch: have %v, want %v", ev.Drops, drop)
+			}
+		case <-time.After(time.Second):
+			t.Fatalf("drop of %x not announced", want.tx.Hash())
+		}
+	}
+	if drop := pool.Drop(replacement.Hash()); drop != nil {
+		t.Errorf("pooled transaction recorded as dropped: %v", drop)
+	}
+	// Resubmitting a dropped transaction should record its latest drop
+	if err := pool.AddRemote(pricedTransaction(0, 100000, big.NewInt(400), key)); err != nil {
+		t.Fatalf("failed to replace transaction: %v", err)
+	}
+	if err := pool.AddRemote(original); err != ErrReplaceUnderpriced {
+		t.Fatalf("resubmission error mismatch: have %v, want %v", err, ErrReplaceUnderpriced)
+	}
+	if drop := pool.Drop(original.Hash()); drop == nil || drop.Reason != TxDropUnderpriced {
+		t.Errorf("resubmitted drop mismatch: have %v, want %s", drop, TxDropUnderpriced)
+	}
+}
+
+// permissionedTestChain is a test blockchain whose account permissions are set
+// by the test instead of being read from a contract.
+type permissionedTestChain struct {
+	*testBlockChain
+	permissions map[common.Address]AccountPermissions
+}
+
+func (c *permissionedTestChain) GetAccountPermissionsForState(header *types.Header, state *state.StateDB, account common.Address) (AccountPermissions, error) {
+	if permissions, ok := c.permissions[account]; ok {
+		return permissions, nil
+	}
+	return allAccountPermissions, nil
+}
+
+// Tests that the pool rejects the transactions of accounts not permitted to
+// send them, and evicts them once an account loses its permissions.
+func TestTransactionAccountPermissions(t *testing.T) {
+	t.Parallel()
+
+	var (
+		statedb, _  = state.New(common.Hash{}, state.NewDatabase(rawdb.NewMemoryDatabase()), nil)
+		banned, _   = crypto.GenerateKey()
+		deployer, _ = crypto.GenerateKey()
+		sender, _   = crypto.GenerateKey()
+	)
+	blockchain := &permissionedTestChain{
+		testBlockChain: &testBlockChain{1000000000, statedb, new(event.Feed), NoPriorityTx, common.PriorityTransactorMap{}},
+		permissions: map[common.Address]AccountPermissions{
+			crypto.PubkeyToAddress(banned.PublicKey): {},
+			crypto.PubkeyToAddress(sender.PublicKey): {CanTransact: true},
+		},
+	}
+	config := *params.TestChainConfig
+	config.Transitions = []params.Transition{{Block: common.Big0, AccountPermissionsContractAddress: common.HexToAddress("0x0000000000000000000000000000000000001002")}}
+
+	pool := NewTxPool(testTxPoolConfig, &config, blockchain)
+	defer pool.Stop()
+
+	for _, key := range []*ecdsa.PrivateKey{banned, deployer, sender} {
+		testAddBalance(pool, crypto.PubkeyToAddress(key.PublicKey), big.NewInt(1000000000))
+	}
+	creation := func(nonce uint64, key *ecdsa.PrivateKey) *types.Transaction {
+		tx, _ := types.SignTx(types.NewContractCreation(nonce, big.NewInt(0), 100000, big.NewInt(1), nil), types.HomesteadSigner{}, key)
+		return tx
+	}
+	// Only the permitted transactions are accepted
+	var (
+		unpermitted = transaction(0, 100000, banned)
+		undeployed  = creation(0, sender)
+		transfer    = transaction(0, 100000, sender)
+		deployment  = creation(0, deployer)
+	)
+	if err := pool.addRemoteSync(unpermitted); !errors.Is(err, ErrAccountNotPermitted) {
+		t.Errorf("unpermitted transaction error mismatch: have %v, want %v", err, ErrAccountNotPermitted)
+	}
+	if err := pool.addRemoteSync(undeployed); !errors.Is(err, ErrDeployNotPermitted) {
+		t.Errorf("unpermitted deployment error mismatch: have %v, want %v", err, ErrDeployNotPermitted)
+	}
+	for _, tx := range []*types.Transaction{unpermitted, undeployed} {
+		if drop := pool.Drop(tx.Hash()); drop == nil || drop.Reason != TxDropUnpermitted {
+			t.Errorf("drop of %x mismatch: have %v, want %s", tx.Hash(), drop, TxDropUnpermitted)
+		}
+	}
+	if err := pool.addRemoteSync(transfer); err != nil {
+		t.Fatalf("failed to add permitted transfer: %v", err)
+	}
+	if err := pool.addRemoteSync(deployment); err != nil {
+		t.Fatalf("failed to add permitted deployment: %v", err)
+	}
+	// Revoking the permissions of an account evicts its transactions at the next reset
+	blockchain.permissions[crypto.PubkeyToAddress(deployer.PublicKey)] = AccountPermissions{CanTransact: true}
+	<-pool.requestReset(nil, nil)
+
+	if pending, queued := pool.Stats(); pending != 1 || queued != 0 {
+		t.Fatalf("pool stats mismatch: have %d/%d, want 1/0", pending, queued)
+	}
+	if pool.Get(transfer.Hash()) == nil {
+		t.Errorf("permitted transfer evicted")
+	}
+	if drop := pool.Drop(deployment.Hash()); drop == nil || drop.Reason != TxDropUnpermitted {
+		t.Errorf("revoked deployment drop mismatch: have %v, want %s", drop, TxDropUnpermitted)
+	}
+	if err := validateTxPoolInternals(pool); err != nil {
+		t.Fatalf("pool internal state corrupted: %v", err)
+	}
+}
+
 // Tests that the pool rejects replacement dynamic fee transactions that don't
 // meet the minimum price bump required.
 func TestPriorityTransactionReplacement(t *testing.T) {
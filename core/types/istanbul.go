@@ -130,6 +130,10 @@ type QBFTExtra struct {
 	Vote          *ValidatorVote
 	Round         uint32
 	CommittedSeal [][]byte
+
+	// ValidatorWeights holds the proposer weights of the validators, in the same
+	// order. It is only set on blocks carrying a contract managed validator set.
+	ValidatorWeights []uint64
 }
 
 type ValidatorVote struct {
@@ -139,13 +143,18 @@ type ValidatorVote struct {
 
 // EncodeRLP serializes qist into the Ethereum RLP format.
 func (qst *QBFTExtra) EncodeRLP(w io.Writer) error {
-	return rlp.Encode(w, []interface{}{
+	fields := []interface{}{
 		qst.VanityData,
 		qst.Validators,
 		qst.Vote,
 		qst.Round,
 		qst.CommittedSeal,
-	})
+	}
+	// Leave the weights out when unset so the encoding of plain headers is unchanged
+	if len(qst.ValidatorWeights) > 0 {
+		fields = append(fields, qst.ValidatorWeights)
+	}
+	return rlp.Encode(w, fields)
 }
 
 // DecodeRLP implements rlp.Decoder, and load the QBFTExtra fields from a RLP stream.
@@ -156,11 +165,14 @@ func (qst *QBFTExtra) DecodeRLP(s *rlp.Stream) error {
 		Vote          *ValidatorVote `rlp:"nil"`
 		Round         uint32
 		CommittedSeal [][]byte
+
+		ValidatorWeights []uint64 `rlp:"optional"`
 	}
 	if err := s.Decode(&qbftExtra); err != nil {
 		return err
 	}
 	qst.VanityData, qst.Validators, qst.Vote, qst.Round, qst.CommittedSeal = qbftExtra.VanityData, qbftExtra.Validators, qbftExtra.Vote, qbftExtra.Round, qbftExtra.CommittedSeal
+	qst.ValidatorWeights = qbftExtra.ValidatorWeights
 
 	return nil
 }
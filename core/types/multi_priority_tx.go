@@ -0,0 +1,135 @@
+// Copyright 2023 Electroneum Ltd
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package types
+
+import (
+	"math/big"
+
+	"github.com/electroneum/electroneum-sc/common"
+)
+
+// PrioritySignature is the signature of a priority key co-signing a transaction.
+type PrioritySignature struct {
+	V *big.Int `json:"v" gencodec:"required"`
+	R *big.Int `json:"r" gencodec:"required"`
+	S *big.Int `json:"s" gencodec:"required"`
+}
+
+// MultiPriorityTx is a priority transaction co-signed by several priority keys,
+// which is only valid if enough keys of the same priority transactor entity
+// signed it to meet the signature threshold of the entity.
+type MultiPriorityTx struct {
+	ChainID    *big.Int
+	Nonce      uint64
+	GasTipCap  *big.Int // a.k.a. maxPriorityFeePerGas
+	GasFeeCap  *big.Int // a.k.a. maxFeePerGas
+	Gas        uint64
+	To         *common.Address `rlp:"nil"` // nil means contract creation
+	Value      *big.Int
+	Data       []byte
+	AccessList AccessList
+
+	// Signature values
+	V *big.Int `json:"v" gencodec:"required"`
+	R *big.Int `json:"r" gencodec:"required"`
+	S *big.Int `json:"s" gencodec:"required"`
+
+	// Electroneum Signature values, one for each co-signing priority key
+	PrioritySignatures []PrioritySignature `json:"prioritySignatures" gencodec:"required"`
+}
+
+// copy creates a deep copy of the transaction data and initializes all fields.
+func (tx *MultiPriorityTx) copy() TxData {
+	cpy := &MultiPriorityTx{
+		Nonce: tx.Nonce,
+		To:    copyAddressPtr(tx.To),
+		Data:  common.CopyBytes(tx.Data),
+		Gas:   tx.Gas,
+		// These are copied below.
+		AccessList:         make(AccessList, len(tx.AccessList)),
+		Value:              new(big.Int),
+		ChainID:            new(big.Int),
+		GasTipCap:          new(big.Int),
+		GasFeeCap:          new(big.Int),
+		V:                  new(big.Int),
+		R:                  new(big.Int),
+		S:                  new(big.Int),
+		PrioritySignatures: make([]PrioritySignature, len(tx.PrioritySignatures)),
+	}
+	copy(cpy.AccessList, tx.AccessList)
+	if tx.Value != nil {
+		cpy.Value.Set(tx.Value)
+	}
+	if tx.ChainID != nil {
+		cpy.ChainID.Set(tx.ChainID)
+	}
+	if tx.GasTipCap != nil {
+		cpy.GasTipCap.Set(tx.GasTipCap)
+	}
+	if tx.GasFeeCap != nil {
+		cpy.GasFeeCap.Set(tx.GasFeeCap)
+	}
+	if tx.V != nil {
+		cpy.V.Set(tx.V)
+	}
+	if tx.R != nil {
+		cpy.R.Set(tx.R)
+	}
+	if tx.S != nil {
+		cpy.S.Set(tx.S)
+	}
+	for i, sig := range tx.PrioritySignatures {
+		cpy.PrioritySignatures[i] = PrioritySignature{V: new(big.Int), R: new(big.Int), S: new(big.Int)}
+		if sig.V != nil {
+			cpy.PrioritySignatures[i].V.Set(sig.V)
+		}
+		if sig.R != nil {
+			cpy.PrioritySignatures[i].R.Set(sig.R)
+		}
+		if sig.S != nil {
+			cpy.PrioritySignatures[i].S.Set(sig.S)
+		}
+	}
+	return cpy
+}
+
+// accessors for innerTx.
+func (tx *MultiPriorityTx) txType() byte           { return MultiPriorityTxType }
+func (tx *MultiPriorityTx) chainID() *big.Int      { return tx.ChainID }
+func (tx *MultiPriorityTx) accessList() AccessList { return tx.AccessList }
+func (tx *MultiPriorityTx) data() []byte           { return tx.Data }
+func (tx *MultiPriorityTx) gas() uint64            { return tx.Gas }
+func (tx *MultiPriorityTx) gasFeeCap() *big.Int    { return tx.GasFeeCap }
+func (tx *MultiPriorityTx) gasTipCap() *big.Int    { return tx.GasTipCap }
+func (tx *MultiPriorityTx) gasPrice() *big.Int     { return tx.GasFeeCap }
+func (tx *MultiPriorityTx) value() *big.Int        { return tx.Value }
+func (tx *MultiPriorityTx) nonce() uint64          { return tx.Nonce }
+func (tx *MultiPriorityTx) to() *common.Address    { return tx.To }
+
+func (tx *MultiPriorityTx) rawSignatureValues() (v, r, s *big.Int) {
+	return tx.V, tx.R, tx.S
+}
+
+func (tx *MultiPriorityTx) setSignatureValues(chainID, v, r, s *big.Int) {
+	tx.ChainID, tx.V, tx.R, tx.S = chainID, v, r, s
+}
+
+// addPrioritySignatureValues adds the signature of another priority key.
+func (tx *MultiPriorityTx) addPrioritySignatureValues(chainID, v, r, s *big.Int) {
+	tx.ChainID = chainID
+	tx.PrioritySignatures = append(tx.PrioritySignatures, PrioritySignature{V: v, R: r, S: s})
+}
@@ -0,0 +1,155 @@
+// Copyright 2023 Electroneum Ltd
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package types
+
+import (
+	"container/heap"
+	"math/big"
+	"sort"
+	"time"
+
+	"github.com/electroneum/electroneum-sc/common"
+)
+
+// TransactionsByEntityAndNonce represents a set of priority transactions that
+// returns transactions in turns across the entities of their priority
+// transactors, so that no entity can starve the others. Within the turn of an
+// entity, transactions are ordered by price and then by arrival time, so its
+// zero-fee transactions are returned first in, first out.
+type TransactionsByEntityAndNonce struct {
+	txs         map[common.Address]Transactions // Per account nonce-sorted list of transactions
+	heads       map[string]*TxByPriceAndTime    // Next transaction for each unique account, per entity (price heap)
+	turns       []string                        // Entities with transactions left, in turn order
+	turn        int                             // Index of the entity whose turn it is
+	transactors common.PriorityTransactorMap    // Priority transactors the entities are taken from
+	signer      Signer                          // Signer for the set of transactions
+	baseFee     *big.Int                        // Current base fee
+}
+
+// NewTransactionsByEntityAndNonce creates a transaction set that can retrieve
+// transactions in turns across priority transactor entities, in a nonce-honouring
+// way. Entities take turns in the arrival order of their oldest transaction.
+//
+// Note, the input map is reowned so the caller should not interact any more with
+// if after providing it to the constructor.
+func NewTransactionsByEntityAndNonce(signer Signer, txs map[common.Address]Transactions, transactors common.PriorityTransactorMap, baseFee *big.Int) *TransactionsByEntityAndNonce {
+	t := &TransactionsByEntityAndNonce{
+		txs:         txs,
+		heads:       make(map[string]*TxByPriceAndTime),
+		transactors: transactors,
+		signer:      signer,
+		baseFee:     baseFee,
+	}
+	oldest := make(map[string]time.Time)
+	for from, accTxs := range txs {
+		acc, _ := Sender(signer, accTxs[0])
+		wrapped, err := NewTxWithMinerFee(accTxs[0], baseFee)
+		// Remove transaction if sender doesn't match from, or if wrapping fails.
+		if acc != from || err != nil {
+			delete(txs, from)
+			continue
+		}
+		entity := t.entity(accTxs[0])
+		if _, ok := t.heads[entity]; !ok {
+			t.heads[entity] = new(TxByPriceAndTime)
+			t.turns = append(t.turns, entity)
+		}
+		*t.heads[entity] = append(*t.heads[entity], wrapped)
+		if first, ok := oldest[entity]; !ok || accTxs[0].time.Before(first) {
+			oldest[entity] = accTxs[0].time
+		}
+		txs[from] = accTxs[1:]
+	}
+	for _, heads := range t.heads {
+		heap.Init(heads)
+	}
+	sort.Slice(t.turns, func(i, j int) bool {
+		if oldest[t.turns[i]].Equal(oldest[t.turns[j]]) {
+			return t.turns[i] < t.turns[j]
+		}
+		return oldest[t.turns[i]].Before(oldest[t.turns[j]])
+	})
+	return t
+}
+
+// entity returns the name of the entity owning the priority key that co-signed
+// the transaction. Keys without a name are considered entities on their own.
+func (t *TransactionsByEntityAndNonce) entity(tx *Transaction) string {
+	pubkey, err := PrioritySender(t.signer, tx)
+	if err != nil {
+		return ""
+	}
+	if name := t.transactors[pubkey].EntityName; name != "" {
+		return name
+	}
+	return pubkey.ToHexString()
+}
+
+// Peek returns the next transaction of the entity whose turn it is.
+func (t *TransactionsByEntityAndNonce) Peek() *Transaction {
+	if len(t.turns) == 0 {
+		return nil
+	}
+	return (*t.heads[t.turns[t.turn]])[0].tx
+}
+
+// Shift replaces the current best head with the next one from the same account,
+// and passes the turn to the next entity.
+func (t *TransactionsByEntityAndNonce) Shift() {
+	entity := t.turns[t.turn]
+	head := heap.Pop(t.heads[entity]).(*TxWithMinerFee)
+
+	acc, _ := Sender(t.signer, head.tx)
+	if txs, ok := t.txs[acc]; ok && len(txs) > 0 {
+		if wrapped, err := NewTxWithMinerFee(txs[0], t.baseFee); err == nil {
+			t.txs[acc] = txs[1:]
+
+			// The next transaction may be co-signed by a key of another entity
+			next := t.entity(txs[0])
+			if _, ok := t.heads[next]; !ok {
+				t.heads[next] = new(TxByPriceAndTime)
+				t.turns = append(t.turns, next)
+			}
+			heap.Push(t.heads[next], wrapped)
+		}
+	}
+	t.next(entity)
+}
+
+// Pop removes the best transaction of the entity whose turn it is, *not*
+// replacing it with the next one from the same account, and passes the turn to
+// the next entity. This should be used when a transaction cannot be executed
+// and hence all subsequent ones should be discarded from the same account.
+func (t *TransactionsByEntityAndNonce) Pop() {
+	entity := t.turns[t.turn]
+	heap.Pop(t.heads[entity])
+	t.next(entity)
+}
+
+// next passes the turn from the given entity to the next one, dropping the
+// entity from the turns if it has no transactions left.
+func (t *TransactionsByEntityAndNonce) next(entity string) {
+	if t.heads[entity].Len() == 0 {
+		delete(t.heads, entity)
+		t.turns = append(t.turns[:t.turn], t.turns[t.turn+1:]...)
+	} else {
+		t.turn++
+	}
+	if t.turn >= len(t.turns) {
+		t.turn = 0
+	}
+}
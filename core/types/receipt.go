@@ -214,7 +214,7 @@ func (r *Receipt) decodeTyped(b []byte) error {
 		return errShortTypedReceipt
 	}
 	switch b[0] {
-	case DynamicFeeTxType, PriorityTxType, AccessListTxType:
+	case DynamicFeeTxType, PriorityTxType, MultiPriorityTxType, AccessListTxType:
 		var data receiptRLP
 		err := rlp.DecodeBytes(b[1:], &data)
 		if err != nil {
@@ -390,6 +390,9 @@ func (rs Receipts) EncodeIndex(i int, w *bytes.Buffer) {
 	case PriorityTxType:
 		w.WriteByte(PriorityTxType)
 		rlp.Encode(w, data)
+	case MultiPriorityTxType:
+		w.WriteByte(MultiPriorityTxType)
+		rlp.Encode(w, data)
 	default:
 		// For unsupported types, write nothing. Since this is for
 		// DeriveSha, the error will be caught matching the derived hash
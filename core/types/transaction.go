@@ -37,6 +37,9 @@ var (
 	ErrInvalidTxType        = errors.New("transaction type not valid in this context")
 	ErrTxTypeNotSupported   = errors.New("transaction type not supported")
 	ErrGasFeeCapTooLow      = errors.New("fee cap less than base fee")
+	ErrNoPrioritySignature  = errors.New("priority transaction has no priority signature")
+	ErrDuplicatePrioritySig = errors.New("priority transaction co-signed twice by the same key")
+	ErrTooManyPrioritySigs  = errors.New("priority transaction co-signed by too many keys")
 	errShortTypedTx         = errors.New("typed transaction too short")
 )
 
@@ -45,7 +48,8 @@ const (
 	LegacyTxType = iota
 	AccessListTxType
 	DynamicFeeTxType
-	PriorityTxType = 64 // the implementation stops at 128
+	PriorityTxType      = 64 // the implementation stops at 128
+	MultiPriorityTxType = 65
 )
 
 // Transaction is an Ethereum transaction.
@@ -69,7 +73,7 @@ func NewTx(inner TxData) *Transaction {
 
 // TxData is the underlying data of a transaction.
 //
-// This is implemented by DynamicFeeTx, LegacyTx and AccessListTx and PriorityTx
+// This is implemented by DynamicFeeTx, LegacyTx and AccessListTx, PriorityTx and MultiPriorityTx
 type TxData interface {
 	txType() byte // returns the type ID
 	copy() TxData // creates a deep copy and initializes all fields
@@ -190,6 +194,10 @@ func (tx *Transaction) decodeTyped(b []byte) (TxData, error) {
 		var inner PriorityTx
 		err := rlp.DecodeBytes(b[1:], &inner)
 		return &inner, err
+	case MultiPriorityTxType:
+		var inner MultiPriorityTx
+		err := rlp.DecodeBytes(b[1:], &inner)
+		return &inner, err
 	default:
 		return nil, ErrTxTypeNotSupported
 	}
@@ -254,8 +262,14 @@ func (tx *Transaction) Type() uint8 {
 	return tx.inner.txType()
 }
 
+// IsPriority reports whether the transaction is co-signed by priority keys,
+// either by a single one or by several keys of the same entity.
+func (tx *Transaction) IsPriority() bool {
+	return tx.Type() == PriorityTxType || tx.Type() == MultiPriorityTxType
+}
+
 func (tx *Transaction) HasZeroFee() bool {
-	if tx.Type() != PriorityTxType {
+	if !tx.IsPriority() {
 		return false
 	}
 	return tx.GasPrice().Cmp(common.Big0) == 0 && tx.GasFeeCapIntCmp(common.Big0) == 0 && tx.GasTipCapIntCmp(common.Big0) == 0
@@ -315,11 +329,30 @@ func (tx *Transaction) RawPrioritySignatureValues() (v, r, s *big.Int) {
 	switch inner := tx.inner.(type) {
 	case *PriorityTx:
 		return inner.rawPrioritySignatureValues()
+	case *MultiPriorityTx:
+		if len(inner.PrioritySignatures) == 0 {
+			return nil, nil, nil
+		}
+		return inner.PrioritySignatures[0].V, inner.PrioritySignatures[0].R, inner.PrioritySignatures[0].S
 	default:
 		return nil, nil, nil
 	}
 }
 
+// RawPrioritySignatures returns the signatures of all the priority keys that
+// co-signed the transaction. The return values should not be modified by the
+// caller.
+func (tx *Transaction) RawPrioritySignatures() []PrioritySignature {
+	switch inner := tx.inner.(type) {
+	case *PriorityTx:
+		return []PrioritySignature{{V: inner.PriorityV, R: inner.PriorityR, S: inner.PriorityS}}
+	case *MultiPriorityTx:
+		return inner.PrioritySignatures
+	default:
+		return nil
+	}
+}
+
 // GasFeeCapCmp compares the fee cap of two transactions.
 func (tx *Transaction) GasFeeCapCmp(other *Transaction) int {
 	return tx.inner.gasFeeCap().Cmp(other.inner.gasFeeCap())
@@ -421,19 +454,28 @@ func (tx *Transaction) WithSignature(signer Signer, sig []byte) (*Transaction, e
 	return &Transaction{inner: cpy, time: tx.time}, nil
 }
 
-// WithSignature returns a new transaction with the given signature.
-// This signature needs to be in the [R || S || V] format where V is 0 or 1.
+// WithPrioritySignature returns a new transaction with the given priority signature.
+// This signature needs to be in the [R || S || V] format where V is 0 or 1. The
+// signature replaces the one of a PriorityTx, and is added to the ones of a
+// MultiPriorityTx.
 func (tx *Transaction) WithPrioritySignature(signer Signer, sig []byte) (*Transaction, error) {
-	if tx.Type() != PriorityTxType {
+	if !tx.IsPriority() {
 		return nil, ErrTxTypeNotSupported
 	}
 	r, s, v, err := signer.SignatureValues(tx, sig)
 	if err != nil {
 		return nil, err
 	}
-	cpy := tx.inner.copy().(*PriorityTx)
-	cpy.setPrioritySignatureValues(signer.ChainID(), v, r, s)
-	return &Transaction{inner: cpy, time: tx.time}, nil
+	switch cpy := tx.inner.copy().(type) {
+	case *PriorityTx:
+		cpy.setPrioritySignatureValues(signer.ChainID(), v, r, s)
+		return &Transaction{inner: cpy, time: tx.time}, nil
+	case *MultiPriorityTx:
+		cpy.addPrioritySignatureValues(signer.ChainID(), v, r, s)
+		return &Transaction{inner: cpy, time: tx.time}, nil
+	default:
+		return nil, ErrTxTypeNotSupported
+	}
 }
 
 // Transactions implements DerivableList for transactions.
@@ -601,18 +643,19 @@ func (t *TransactionsByPriceAndNonce) Pop() {
 //
 // NOTE: In a future PR this will be removed.
 type Message struct {
-	to             *common.Address
-	from           common.Address
-	nonce          uint64
-	amount         *big.Int
-	gasLimit       uint64
-	gasPrice       *big.Int
-	gasFeeCap      *big.Int
-	gasTipCap      *big.Int
-	prioritySender common.PublicKey
-	data           []byte
-	accessList     AccessList
-	isFake         bool
+	to              *common.Address
+	from            common.Address
+	nonce           uint64
+	amount          *big.Int
+	gasLimit        uint64
+	gasPrice        *big.Int
+	gasFeeCap       *big.Int
+	gasTipCap       *big.Int
+	prioritySender  common.PublicKey
+	prioritySenders []common.PublicKey
+	data            []byte
+	accessList      AccessList
+	isFake          bool
 }
 
 // Priority txes will only be hitting this function in the event of mock-calls, in which case the VRS & Priority pubkey will be included in the API params. Priority tx will always sign with web3. then call sendsignedtransaction. NewMessage is used for sendtransaction only.
@@ -654,11 +697,12 @@ func (tx *Transaction) AsMessage(s Signer, baseFee *big.Int) (Message, error) {
 
 	var err error
 
-	if tx.Type() == PriorityTxType {
-		msg.prioritySender, err = PrioritySender(s, tx)
+	if tx.IsPriority() {
+		msg.prioritySenders, err = PrioritySenders(s, tx)
 		if err != nil {
 			return msg, err //is this ok?
 		}
+		msg.prioritySender = msg.prioritySenders[0]
 	}
 
 	msg.from, err = Sender(s, tx) //very important point: this IS the transaction signature verification. txes derive 'from' from the tx signature. sender() gets the sender and verifies the signature in one go
@@ -678,6 +722,10 @@ func (m Message) Data() []byte                     { return m.data }
 func (m Message) AccessList() AccessList           { return m.accessList }
 func (m Message) IsFake() bool                     { return m.isFake }
 
+// PrioritySenders returns all the priority keys that co-signed the message, the
+// first one being the PrioritySender.
+func (m Message) PrioritySenders() []common.PublicKey { return m.prioritySenders }
+
 // copyAddressPtr copies an address.
 func copyAddressPtr(a *common.Address) *common.Address {
 	if a == nil {
@@ -45,6 +45,9 @@ type txJSON struct {
 	PriorityS            *hexutil.Big    `json:"priorityS"`
 	To                   *common.Address `json:"to"`
 
+	// Multi-priority transaction fields:
+	PrioritySignatures []prioritySignatureJSON `json:"prioritySignatures,omitempty"`
+
 	// Access list transaction fields:
 	ChainID    *hexutil.Big `json:"chainId,omitempty"`
 	AccessList *AccessList  `json:"accessList,omitempty"`
@@ -53,6 +56,13 @@ type txJSON struct {
 	Hash common.Hash `json:"hash"`
 }
 
+// prioritySignatureJSON is the JSON representation of a priority signature.
+type prioritySignatureJSON struct {
+	V *hexutil.Big `json:"v"`
+	R *hexutil.Big `json:"r"`
+	S *hexutil.Big `json:"s"`
+}
+
 // MarshalJSON marshals as JSON with a hash.
 func (t *Transaction) MarshalJSON() ([]byte, error) {
 	var enc txJSON
@@ -113,6 +123,27 @@ func (t *Transaction) MarshalJSON() ([]byte, error) {
 		enc.PriorityV = (*hexutil.Big)(tx.PriorityV)
 		enc.PriorityR = (*hexutil.Big)(tx.PriorityR)
 		enc.PriorityS = (*hexutil.Big)(tx.PriorityS)
+	case *MultiPriorityTx:
+		enc.ChainID = (*hexutil.Big)(tx.ChainID)
+		enc.AccessList = &tx.AccessList
+		enc.Nonce = (*hexutil.Uint64)(&tx.Nonce)
+		enc.Gas = (*hexutil.Uint64)(&tx.Gas)
+		enc.MaxFeePerGas = (*hexutil.Big)(tx.GasFeeCap)
+		enc.MaxPriorityFeePerGas = (*hexutil.Big)(tx.GasTipCap)
+		enc.Value = (*hexutil.Big)(tx.Value)
+		enc.Data = (*hexutil.Bytes)(&tx.Data)
+		enc.To = t.To()
+		enc.V = (*hexutil.Big)(tx.V)
+		enc.R = (*hexutil.Big)(tx.R)
+		enc.S = (*hexutil.Big)(tx.S)
+		enc.PrioritySignatures = make([]prioritySignatureJSON, len(tx.PrioritySignatures))
+		for i, sig := range tx.PrioritySignatures {
+			enc.PrioritySignatures[i] = prioritySignatureJSON{
+				V: (*hexutil.Big)(sig.V),
+				R: (*hexutil.Big)(sig.R),
+				S: (*hexutil.Big)(sig.S),
+			}
+		}
 	}
 	return json.Marshal(&enc)
 }
@@ -357,6 +388,73 @@ func (t *Transaction) UnmarshalJSON(input []byte) error {
 			}
 		}
 
+	case MultiPriorityTxType:
+		var itx MultiPriorityTx
+		inner = &itx
+		// Access list is optional for now.
+		if dec.AccessList != nil {
+			itx.AccessList = *dec.AccessList
+		}
+		if dec.ChainID == nil {
+			return errors.New("missing required field 'chainId' in transaction")
+		}
+		itx.ChainID = (*big.Int)(dec.ChainID)
+		if dec.To != nil {
+			itx.To = dec.To
+		}
+		if dec.Nonce == nil {
+			return errors.New("missing required field 'nonce' in transaction")
+		}
+		itx.Nonce = uint64(*dec.Nonce)
+		if dec.MaxPriorityFeePerGas == nil {
+			return errors.New("missing required field 'maxPriorityFeePerGas' for txdata")
+		}
+		itx.GasTipCap = (*big.Int)(dec.MaxPriorityFeePerGas)
+		if dec.MaxFeePerGas == nil {
+			return errors.New("missing required field 'maxFeePerGas' for txdata")
+		}
+		itx.GasFeeCap = (*big.Int)(dec.MaxFeePerGas)
+		if dec.Gas == nil {
+			return errors.New("missing required field 'gas' for txdata")
+		}
+		itx.Gas = uint64(*dec.Gas)
+		if dec.Value == nil {
+			return errors.New("missing required field 'value' in transaction")
+		}
+		itx.Value = (*big.Int)(dec.Value)
+		if dec.Data == nil {
+			return errors.New("missing required field 'input' in transaction")
+		}
+		itx.Data = *dec.Data
+		if dec.V == nil {
+			return errors.New("missing required field 'v' in transaction")
+		}
+		itx.V = (*big.Int)(dec.V)
+		if dec.R == nil {
+			return errors.New("missing required field 'r' in transaction")
+		}
+		itx.R = (*big.Int)(dec.R)
+		if dec.S == nil {
+			return errors.New("missing required field 's' in transaction")
+		}
+		itx.S = (*big.Int)(dec.S)
+		withSignature := itx.V.Sign() != 0 || itx.R.Sign() != 0 || itx.S.Sign() != 0
+		if withSignature {
+			if err := sanityCheckSignature(itx.V, itx.R, itx.S, false); err != nil {
+				return err
+			}
+		}
+		itx.PrioritySignatures = make([]PrioritySignature, len(dec.PrioritySignatures))
+		for i, sig := range dec.PrioritySignatures {
+			if sig.V == nil || sig.R == nil || sig.S == nil {
+				return errors.New("missing required field 'v', 'r' or 's' in priority signature")
+			}
+			itx.PrioritySignatures[i] = PrioritySignature{V: (*big.Int)(sig.V), R: (*big.Int)(sig.R), S: (*big.Int)(sig.S)}
+			if err := sanityCheckSignature(itx.PrioritySignatures[i].V, itx.PrioritySignatures[i].R, itx.PrioritySignatures[i].S, false); err != nil {
+				return err
+			}
+		}
+
 	default:
 		return ErrTxTypeNotSupported
 	}
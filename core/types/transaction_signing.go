@@ -37,11 +37,13 @@ type sigCache struct {
 	from   common.Address
 }
 
-// prioritySigCache is used to cache the derived sender and contains
-// the signer used to derive it.
+// prioritySigCache is used to cache the derived priority senders and contains
+// the signer used to derive them. The co-signing keys of a multi-priority
+// transaction are only cached once all of them were derived.
 type prioritySigCache struct {
-	signer         Signer
-	priorityPubkey common.PublicKey
+	signer          Signer
+	priorityPubkey  common.PublicKey
+	priorityPubkeys []common.PublicKey
 }
 
 // MakeSigner returns a Signer based on the given chain config and block number.
@@ -110,7 +112,7 @@ func SignTx(tx *Transaction, s Signer, prv *ecdsa.PrivateKey) (*Transaction, err
 
 // SignTx signs the transaction using the given signer and private key.
 func SignPriorityTx(tx *Transaction, s Signer, prv *ecdsa.PrivateKey, priorityPrv *ecdsa.PrivateKey) (*Transaction, error) {
-	if tx.Type() != PriorityTxType {
+	if !tx.IsPriority() {
 		return nil, ErrTxIsNotPriorityType
 	}
 	h := s.Hash(tx)
@@ -162,6 +164,33 @@ func SignNewPriorityTx(prv *ecdsa.PrivateKey, priorityPrv *ecdsa.PrivateKey, s S
 	return txCpy.WithPrioritySignature(s, prioritySig)
 }
 
+// SignNewMultiPriorityTx creates a multi-priority transaction, signs it and
+// co-signs it with each of the priority keys.
+func SignNewMultiPriorityTx(prv *ecdsa.PrivateKey, priorityPrvs []*ecdsa.PrivateKey, s Signer, txdata TxData) (*Transaction, error) {
+	tx := NewTx(txdata)
+	if tx.Type() != MultiPriorityTxType {
+		return nil, ErrTxIsNotPriorityType
+	}
+	h := s.Hash(tx)
+	sig, err := crypto.Sign(h[:], prv)
+	if err != nil {
+		return nil, err
+	}
+	if tx, err = tx.WithSignature(s, sig); err != nil {
+		return nil, err
+	}
+	for _, priorityPrv := range priorityPrvs {
+		prioritySig, err := crypto.Sign(h[:], priorityPrv)
+		if err != nil {
+			return nil, err
+		}
+		if tx, err = tx.WithPrioritySignature(s, prioritySig); err != nil {
+			return nil, err
+		}
+	}
+	return tx, nil
+}
+
 // MustSignNewTx creates a transaction and signs it.
 // This panics if the transaction cannot be signed.
 func MustSignNewTx(prv *ecdsa.PrivateKey, s Signer, txdata TxData) *Transaction {
@@ -217,6 +246,35 @@ func PrioritySender(signer Signer, tx *Transaction) (common.PublicKey, error) {
 	return pub, nil
 }
 
+// PrioritySenders returns the secp256k1 pubkeys of all the priority keys that
+// co-signed the transaction, the first one being the PrioritySender. It fails
+// if any of the priority signatures is invalid, or if a key signed twice.
+func PrioritySenders(signer Signer, tx *Transaction) ([]common.PublicKey, error) {
+	if tx.Type() != MultiPriorityTxType {
+		pubkey, err := PrioritySender(signer, tx)
+		if err != nil {
+			return nil, err
+		}
+		return []common.PublicKey{pubkey}, nil
+	}
+	if sc := tx.priorityPubkey.Load(); sc != nil {
+		prioritySigCache := sc.(prioritySigCache)
+		if prioritySigCache.priorityPubkeys != nil && prioritySigCache.signer.Equal(signer) {
+			return prioritySigCache.priorityPubkeys, nil
+		}
+	}
+	s, ok := signer.(londonSigner)
+	if !ok {
+		return nil, ErrTxTypeNotSupported
+	}
+	pubkeys, err := s.prioritySenders(tx)
+	if err != nil {
+		return nil, err
+	}
+	tx.priorityPubkey.Store(prioritySigCache{signer: signer, priorityPubkey: pubkeys[0], priorityPubkeys: pubkeys})
+	return pubkeys, nil
+}
+
 // Signer encapsulates transaction signature handling. The name of this type is slightly
 // misleading because Signers don't actually sign, they're just for validating and
 // processing of signatures.
@@ -255,7 +313,7 @@ func NewLondonSigner(chainId *big.Int) Signer {
 }
 
 func (s londonSigner) Sender(tx *Transaction) (common.Address, error) {
-	if tx.Type() != DynamicFeeTxType && tx.Type() != PriorityTxType {
+	if tx.Type() != DynamicFeeTxType && !tx.IsPriority() {
 		return s.eip2930Signer.Sender(tx)
 	}
 	V, R, S := tx.RawSignatureValues()
@@ -265,9 +323,9 @@ func (s londonSigner) Sender(tx *Transaction) (common.Address, error) {
 	if tx.ChainId().Cmp(s.chainId) != 0 {
 		return common.Address{}, ErrInvalidChainId
 	}
-	// Also sanity check the priority signature
-	if tx.Type() == PriorityTxType {
-		_, err := s.PrioritySender(tx)
+	// Also sanity check the priority signatures
+	if tx.IsPriority() {
+		_, err := PrioritySenders(s, tx)
 		if err != nil {
 			return common.Address{}, err
 		}
@@ -286,11 +344,59 @@ func (s londonSigner) PrioritySender(tx *Transaction) (common.PublicKey, error)
 			return common.PublicKey{}, ErrInvalidChainId
 		}
 		return recoverPublicKey(s.Hash(tx), V, R, S, true)
+	case *MultiPriorityTx:
+		if len(inner.PrioritySignatures) == 0 {
+			return common.PublicKey{}, ErrNoPrioritySignature
+		}
+		if tx.ChainId().Cmp(s.chainId) != 0 {
+			return common.PublicKey{}, ErrInvalidChainId
+		}
+		sig := inner.PrioritySignatures[0]
+		return recoverPublicKey(s.Hash(tx), new(big.Int).Add(sig.V, big.NewInt(27)), sig.R, sig.S, true)
 	default:
 		return common.PublicKey{}, ErrTxTypeNotSupported
 	}
 }
 
+// prioritySenders returns the secp256k1 pubkeys of all the priority keys that
+// co-signed the transaction, verifying their signatures.
+func (s londonSigner) prioritySenders(tx *Transaction) ([]common.PublicKey, error) {
+	inner, ok := tx.inner.(*MultiPriorityTx)
+	if !ok {
+		pubkey, err := s.PrioritySender(tx)
+		if err != nil {
+			return nil, err
+		}
+		return []common.PublicKey{pubkey}, nil
+	}
+	if len(inner.PrioritySignatures) == 0 {
+		return nil, ErrNoPrioritySignature
+	}
+	if len(inner.PrioritySignatures) > params.MaxPrioritySignatures {
+		return nil, ErrTooManyPrioritySigs
+	}
+	if tx.ChainId().Cmp(s.chainId) != 0 {
+		return nil, ErrInvalidChainId
+	}
+	var (
+		hash    = s.Hash(tx)
+		pubkeys = make([]common.PublicKey, 0, len(inner.PrioritySignatures))
+		seen    = make(map[common.PublicKey]struct{}, len(inner.PrioritySignatures))
+	)
+	for _, sig := range inner.PrioritySignatures {
+		pubkey, err := recoverPublicKey(hash, new(big.Int).Add(sig.V, big.NewInt(27)), sig.R, sig.S, true)
+		if err != nil {
+			return nil, err
+		}
+		if _, ok := seen[pubkey]; ok {
+			return nil, ErrDuplicatePrioritySig
+		}
+		seen[pubkey] = struct{}{}
+		pubkeys = append(pubkeys, pubkey)
+	}
+	return pubkeys, nil
+}
+
 func (s londonSigner) Equal(s2 Signer) bool {
 	x, ok := s2.(londonSigner)
 	return ok && x.chainId.Cmp(s.chainId) == 0
@@ -312,6 +418,13 @@ func (s londonSigner) SignatureValues(tx *Transaction, sig []byte) (R, S, V *big
 		R, S, _ = decodeSignature(sig)
 		V = big.NewInt(int64(sig[64]))
 		return R, S, V, nil
+	case *MultiPriorityTx:
+		if t.ChainID.Sign() != 0 && t.ChainID.Cmp(s.chainId) != 0 {
+			return nil, nil, nil, ErrInvalidChainId
+		}
+		R, S, _ = decodeSignature(sig)
+		V = big.NewInt(int64(sig[64]))
+		return R, S, V, nil
 	default:
 		return s.eip2930Signer.SignatureValues(tx, sig)
 	}
@@ -320,7 +433,7 @@ func (s londonSigner) SignatureValues(tx *Transaction, sig []byte) (R, S, V *big
 // Hash returns the hash to be signed by the sender.
 // It does not uniquely identify the transaction.
 func (s londonSigner) Hash(tx *Transaction) common.Hash {
-	if tx.Type() != DynamicFeeTxType && tx.Type() != PriorityTxType {
+	if tx.Type() != DynamicFeeTxType && !tx.IsPriority() {
 		return s.eip2930Signer.Hash(tx)
 	}
 	return prefixedRlpHash(
@@ -17,11 +17,13 @@
 package types
 
 import (
+	"crypto/ecdsa"
 	"math/big"
 	"testing"
 
 	"github.com/electroneum/electroneum-sc/common"
 	"github.com/electroneum/electroneum-sc/crypto"
+	"github.com/electroneum/electroneum-sc/params"
 	"github.com/electroneum/electroneum-sc/rlp"
 )
 
@@ -135,3 +137,79 @@ func TestChainId(t *testing.T) {
 		t.Error("expected no error")
 	}
 }
+
+func TestMultiPrioritySigning(t *testing.T) {
+	key, _ := crypto.GenerateKey()
+	priorityKeys := make([]*ecdsa.PrivateKey, 3)
+	for i := range priorityKeys {
+		priorityKeys[i], _ = crypto.GenerateKey()
+	}
+	signer := NewLondonSigner(big.NewInt(18))
+	tx, err := SignNewMultiPriorityTx(key, priorityKeys, signer, &MultiPriorityTx{
+		ChainID:   big.NewInt(18),
+		Nonce:     1,
+		GasTipCap: big.NewInt(1),
+		GasFeeCap: big.NewInt(1),
+		Gas:       21000,
+		To:        &common.Address{},
+		Value:     big.NewInt(10),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Check the co-signing keys are recovered in order, through both encodings
+	blob, err := tx.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	fromRLP := new(Transaction)
+	if err := fromRLP.UnmarshalBinary(blob); err != nil {
+		t.Fatal(err)
+	}
+	blob, err = tx.MarshalJSON()
+	if err != nil {
+		t.Fatal(err)
+	}
+	fromJSON := new(Transaction)
+	if err := fromJSON.UnmarshalJSON(blob); err != nil {
+		t.Fatal(err)
+	}
+	for _, tx := range []*Transaction{tx, fromRLP, fromJSON} {
+		if from, err := Sender(signer, tx); err != nil || from != crypto.PubkeyToAddress(key.PublicKey) {
+			t.Errorf("sender mismatch: have %x (%v), want %x", from, err, crypto.PubkeyToAddress(key.PublicKey))
+		}
+		pubkeys, err := PrioritySenders(signer, tx)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(pubkeys) != len(priorityKeys) {
+			t.Fatalf("priority senders mismatch: have %d, want %d", len(pubkeys), len(priorityKeys))
+		}
+		for i, pubkey := range pubkeys {
+			if want := common.PublicKey(crypto.FromECDSAPub(&priorityKeys[i].PublicKey)); pubkey != want {
+				t.Errorf("priority sender %d mismatch: have %x, want %x", i, pubkey, want)
+			}
+		}
+	}
+	// The recovered keys are cached on the transaction
+	first, _ := PrioritySenders(signer, tx)
+	if second, _ := PrioritySenders(signer, tx); &first[0] != &second[0] {
+		t.Error("priority senders not cached")
+	}
+	// Co-signing with more keys than allowed must be rejected
+	many, _ := SignNewMultiPriorityTx(key, nil, signer, &MultiPriorityTx{ChainID: big.NewInt(18), Gas: 21000, GasTipCap: big.NewInt(1), GasFeeCap: big.NewInt(1)})
+	for i := 0; i <= params.MaxPrioritySignatures; i++ {
+		many, _ = SignPriorityTx(many, signer, key, priorityKeys[0])
+	}
+	if _, err := PrioritySenders(signer, many); err != ErrTooManyPrioritySigs {
+		t.Errorf("expected error %v, got %v", ErrTooManyPrioritySigs, err)
+	}
+	// Co-signing twice with the same key must be rejected
+	dup, _ := SignPriorityTx(tx, signer, key, priorityKeys[1])
+	if _, err := PrioritySenders(signer, dup); err != ErrDuplicatePrioritySig {
+		t.Errorf("expected error %v, got %v", ErrDuplicatePrioritySig, err)
+	}
+	if _, err := Sender(signer, dup); err == nil {
+		t.Error("expected sender recovery of duplicate co-signed transaction to fail")
+	}
+}
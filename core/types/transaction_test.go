@@ -575,6 +575,58 @@ func TestTransactionTimeSort(t *testing.T) {
 	}
 }
 
+// Tests that priority transactions are returned in turns across the entities of
+// their priority transactors, with zero-fee transactions first in, first out.
+func TestTransactionEntitySort(t *testing.T) {
+	signer := NewLondonSigner(big.NewInt(1))
+
+	// Create two entities, the first one with two priority keys
+	priorityKeys := make([]*ecdsa.PrivateKey, 3)
+	transactors := make(common.PriorityTransactorMap)
+	for i := range priorityKeys {
+		priorityKeys[i], _ = crypto.GenerateKey()
+		name := "first"
+		if i == 2 {
+			name = "second"
+		}
+		transactors[crypto.ECDSAPubkeyToPublicKey(priorityKeys[i].PublicKey)] = common.PriorityTransactor{EntityName: name}
+	}
+	// The first entity co-signs fee paying transactions with both of its keys,
+	// the second one zero-fee transactions, received in reverse order
+	groups := map[common.Address]Transactions{}
+	add := func(nonce uint64, fee int64, arrival int64, priorityKey *ecdsa.PrivateKey) *Transaction {
+		key, _ := crypto.GenerateKey()
+		tx, _ := SignNewPriorityTx(key, priorityKey, signer, &PriorityTx{
+			ChainID:   big.NewInt(1),
+			Nonce:     nonce,
+			GasTipCap: big.NewInt(fee),
+			GasFeeCap: big.NewInt(fee),
+			Gas:       21000,
+		})
+		tx.time = time.Unix(0, arrival)
+		groups[crypto.PubkeyToAddress(key.PublicKey)] = Transactions{tx}
+		return tx
+	}
+	first := []*Transaction{add(0, 3, 1, priorityKeys[0]), add(0, 2, 2, priorityKeys[1]), add(0, 1, 3, priorityKeys[0])}
+	second := []*Transaction{add(0, 0, 5, priorityKeys[2]), add(0, 0, 4, priorityKeys[2])}
+	want := Transactions{first[0], second[1], first[1], second[0], first[2]}
+
+	txset := NewTransactionsByEntityAndNonce(signer, groups, transactors, nil)
+	var have Transactions
+	for tx := txset.Peek(); tx != nil; tx = txset.Peek() {
+		have = append(have, tx)
+		txset.Shift()
+	}
+	if len(have) != len(want) {
+		t.Fatalf("transaction count mismatch: have %d, want %d", len(have), len(want))
+	}
+	for i := range want {
+		if have[i].Hash() != want[i].Hash() {
+			t.Errorf("transaction %d mismatch: have %x, want %x", i, have[i].Hash(), want[i].Hash())
+		}
+	}
+}
+
 // TestTransactionCoding tests serializing/de-serializing to/from rlp and JSON.
 func TestTransactionCoding(t *testing.T) {
 	key, err := crypto.GenerateKey()
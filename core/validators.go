@@ -0,0 +1,97 @@
+package core
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/electroneum/electroneum-sc/accounts/abi"
+	"github.com/electroneum/electroneum-sc/common"
+	"github.com/electroneum/electroneum-sc/contracts/validators"
+	"github.com/electroneum/electroneum-sc/core/vm"
+	"github.com/electroneum/electroneum-sc/params"
+)
+
+var (
+	// ErrNoValidatorContract is returned if the validators are read from a
+	// contract address that has no code deployed.
+	ErrNoValidatorContract = errors.New("no validator contract deployed")
+
+	// ErrEmptyValidatorSet is returned if the validator contract returns no
+	// validators, which would halt the chain.
+	ErrEmptyValidatorSet = errors.New("validator contract returned an empty validator set")
+
+	// ErrValidatorWeightsMismatch is returned if the validator contract returns
+	// weights that do not line up with its validators.
+	ErrValidatorWeightsMismatch = errors.New("validator contract returned invalid validator weights")
+)
+
+// GetContractValidators gets the validator list for the current state from the validator contract at the given address
+func GetContractValidators(evm *vm.EVM, address common.Address) ([]common.Address, error) {
+	var (
+		contract = vm.AccountRef(address)
+		method   = "getValidators"
+	)
+
+	// Unlike the priority transactors, a missing contract is an error as the chain
+	// cannot progress without validators.
+	if address == (common.Address{}) || len(evm.StateDB.GetCode(address)) == 0 {
+		return nil, ErrNoValidatorContract
+	}
+
+	contractABI, _ := abi.JSON(strings.NewReader(validators.ETNValidatorsInterfaceMetaData.ABI))
+	input, _ := contractABI.Pack(method)
+	output, _, err := evm.StaticCall(contract, address, input, params.MaxGasLimit)
+	if err != nil {
+		return nil, fmt.Errorf("error getting the validators from the EVM/contract: %w", err)
+	}
+
+	unpackResult, err := contractABI.Unpack(method, output)
+	if err != nil {
+		return nil, fmt.Errorf("error getting the validators from the EVM/contract: %w", err)
+	}
+
+	result := *abi.ConvertType(unpackResult[0], new([]common.Address)).(*[]common.Address)
+	if len(result) == 0 {
+		return nil, ErrEmptyValidatorSet
+	}
+	return result, nil
+}
+
+// GetContractValidatorWeights gets the proposer weights of the given validators from the validator contract at the
+// given address. Contracts that do not provide weights leave every validator with the default weight.
+func GetContractValidatorWeights(evm *vm.EVM, address common.Address, addresses []common.Address) (map[common.Address]uint64, error) {
+	var (
+		contract = vm.AccountRef(address)
+		method   = "getValidatorWeights"
+	)
+
+	contractABI, _ := abi.JSON(strings.NewReader(validators.ETNValidatorsInterfaceMetaData.ABI))
+	input, _ := contractABI.Pack(method)
+	output, _, err := evm.StaticCall(contract, address, input, params.MaxGasLimit)
+	if errors.Is(err, vm.ErrExecutionReverted) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error getting the validator weights from the EVM/contract: %w", err)
+	}
+
+	unpackResult, err := contractABI.Unpack(method, output)
+	if err != nil {
+		return nil, fmt.Errorf("error getting the validator weights from the EVM/contract: %w", err)
+	}
+
+	weights := *abi.ConvertType(unpackResult[0], new([]*big.Int)).(*[]*big.Int)
+	if len(weights) != len(addresses) {
+		return nil, ErrValidatorWeightsMismatch
+	}
+	result := make(map[common.Address]uint64, len(addresses))
+	for i, weight := range weights {
+		if !weight.IsUint64() {
+			return nil, ErrValidatorWeightsMismatch
+		}
+		result[addresses[i]] = weight.Uint64()
+	}
+	return result, nil
+}
@@ -26,6 +26,7 @@ import (
 	"os"
 	"runtime"
 	"strings"
+	"sync/atomic"
 	"time"
 
 	"github.com/electroneum/electroneum-sc/common"
@@ -150,7 +151,8 @@ func (api *PrivateMinerAPI) SetRecommitInterval(interval int) {
 // PrivateAdminAPI is the collection of Ethereum full node-related APIs
 // exposed over the private admin endpoint.
 type PrivateAdminAPI struct {
-	eth *Ethereum
+	eth    *Ethereum
+	backup atomic.Bool // Whether a database backup is in progress
 }
 
 // NewPrivateAdminAPI creates a new API definition for the full node private
@@ -198,6 +200,30 @@ func (api *PrivateAdminAPI) ExportChain(file string, first *uint64, last *uint64
 	return true, nil
 }
 
+// Backup starts writing a consistent copy of the chain database, including the
+// ancients, into a new local directory, along with a manifest of checksums to
+// verify it before it is restored. Existing files are never overwritten, the
+// directory must not exist yet or be empty.
+//
+// The backup runs in the background, the returned path of the manifest only
+// exists once it completed. Failures are logged.
+func (api *PrivateAdminAPI) Backup(dir string) (string, error) {
+	if err := rawdb.CheckBackupDir(dir); err != nil {
+		return "", err
+	}
+	if !api.backup.CompareAndSwap(false, true) {
+		return "", errors.New("backup already in progress")
+	}
+	go func() {
+		defer api.backup.Store(false)
+
+		if _, err := rawdb.Backup(api.eth.ChainDb(), dir); err != nil {
+			log.Error("Failed to back up database", "dir", dir, "err", err)
+		}
+	}()
+	return rawdb.BackupManifestPath(dir), nil
+}
+
 func hasAllBlocks(chain *core.BlockChain, bs []*types.Block) bool {
 	for _, b := range bs {
 		if !chain.HasBlock(b.Hash(), b.NumberU64()) {
@@ -96,7 +96,8 @@ type Ethereum struct {
 	networkID     uint64
 	netRPCService *ethapi.PublicNetAPI
 
-	p2pServer *p2p.Server
+	p2pServer   *p2p.Server
+	permissions *nodePermissions // Nodes allowed to connect, nil if the network isn't permissioned
 
 	lock sync.RWMutex // Protects the variadic fields (e.g. gas price and etherbase)
 
@@ -137,6 +138,21 @@ func New(stack *node.Node, config *ethconfig.Config) (*Ethereum, error) {
 	if err != nil {
 		return nil, err
 	}
+	scheme, err := rawdb.ParseStateScheme(config.StateScheme, chainDb)
+	if err != nil {
+		return nil, err
+	}
+	if scheme == rawdb.PathScheme {
+		if config.NoPruning {
+			return nil, errors.New("archive mode is not supported by the path-based state scheme")
+		}
+		if config.SyncMode != downloader.FullSync {
+			log.Warn("Switching to full sync since the path-based state scheme is used", "syncmode", config.SyncMode)
+			config.SyncMode = downloader.FullSync
+		}
+	}
+	rawdb.WriteStateScheme(chainDb, scheme)
+
 	chainConfig, genesisHash, genesisErr := core.SetupGenesisBlockWithOverride(chainDb, config.Genesis, config.OverrideArrowGlacier, config.OverrideTerminalTotalDifficulty)
 	if _, ok := genesisErr.(*params.ConfigCompatError); genesisErr != nil && !ok {
 		return nil, genesisErr
@@ -220,6 +236,7 @@ func New(stack *node.Node, config *ethconfig.Config) (*Ethereum, error) {
 			TrieTimeLimit:       config.TrieTimeout,
 			SnapshotLimit:       config.SnapshotCache,
 			Preimages:           config.Preimages,
+			HistoryExpiry:       config.HistoryExpiry,
 		}
 	)
 	eth.blockchain, err = core.NewBlockChain(chainDb, cacheConfig, chainConfig, eth.engine, vmConfig, eth.shouldPreserve, &config.TxLookupLimit)
@@ -234,6 +251,15 @@ func New(stack *node.Node, config *ethconfig.Config) (*Ethereum, error) {
 	}
 	eth.bloomIndexer.Start(eth.blockchain)
 
+	// Index the validator liveness and the burnt fees of IBFT chains as new heads
+	// are imported
+	type engineIndexer interface {
+		StartIndexers(chain *core.BlockChain)
+	}
+	if indexer, ok := eth.engine.(engineIndexer); ok {
+		indexer.StartIndexers(eth.blockchain)
+	}
+
 	if config.TxPool.Journal != "" {
 		config.TxPool.Journal = stack.ResolvePath(config.TxPool.Journal)
 	}
@@ -261,6 +287,14 @@ func New(stack *node.Node, config *ethconfig.Config) (*Ethereum, error) {
 		return nil, err
 	}
 
+	// Restrict the nodes allowed to connect on permissioned networks
+	if config.PermissionedNodes != "" || hasNodeRegistry(chainConfig) {
+		if eth.permissions, err = newNodePermissions(eth.blockchain, config.PermissionedNodes, config.PermissionsAuditLog); err != nil {
+			return nil, err
+		}
+		eth.p2pServer.Permissions = eth.permissions
+	}
+
 	eth.miner = miner.New(eth, &config.Miner, chainConfig, eth.EventMux(), eth.engine, eth.isLocalBlock)
 	eth.miner.SetExtra(makeExtraData(config.Miner.ExtraData, eth.blockchain.Config().IBFT != nil))
 
@@ -606,6 +640,9 @@ func (s *Ethereum) Stop() error {
 	s.ethDialCandidates.Close()
 	s.snapDialCandidates.Close()
 	s.handler.Stop()
+	if s.permissions != nil {
+		s.permissions.stop()
+	}
 
 	// Then stop everything else.
 	s.bloomIndexer.Close()
@@ -0,0 +1,118 @@
+// Copyright 2023 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package eth
+
+import (
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/electroneum/electroneum-sc/common"
+	istanbulBackend "github.com/electroneum/electroneum-sc/consensus/istanbul/backend"
+	"github.com/electroneum/electroneum-sc/core"
+	"github.com/electroneum/electroneum-sc/core/types"
+	"github.com/electroneum/electroneum-sc/crypto"
+	"github.com/electroneum/electroneum-sc/eth/ethconfig"
+	"github.com/electroneum/electroneum-sc/node"
+	"github.com/electroneum/electroneum-sc/p2p"
+	"github.com/electroneum/electroneum-sc/params"
+)
+
+// Tests that a zero period developer chain seals a block as soon as a transaction
+// arrives, even after idling for longer than the round change timeout, and pays
+// the block reward to its validator.
+func TestDeveloperChainSealing(t *testing.T) {
+	nodekey, _ := crypto.GenerateKey()
+	stack, err := node.New(&node.Config{P2P: p2p.Config{PrivateKey: nodekey, NoDiscovery: true, MaxPeers: 0}})
+	if err != nil {
+		t.Fatalf("failed to create node: %v", err)
+	}
+	defer stack.Close()
+
+	var (
+		validator = crypto.PubkeyToAddress(nodekey.PublicKey)
+		key, _    = crypto.GenerateKey()
+		faucet    = crypto.PubkeyToAddress(key.PublicKey)
+		genesis   = core.DeveloperGenesisBlock(0, 11_500_000, faucet, validator, nil)
+	)
+	// Shorten the round timeout, so an idle round would have changed a few times
+	genesis.Config.IBFT.RequestTimeoutSeconds = 1
+
+	config := ethconfig.Defaults
+	config.Genesis = genesis
+	config.Miner.Etherbase = validator
+	ethservice, err := New(stack, &config)
+	if err != nil {
+		t.Fatalf("failed to create eth service: %v", err)
+	}
+	if err := stack.Start(); err != nil {
+		t.Fatalf("failed to start node: %v", err)
+	}
+	if err := ethservice.StartMining(0); err != nil {
+		t.Fatalf("failed to start mining: %v", err)
+	}
+	heads := make(chan core.ChainHeadEvent, 1)
+	sub := ethservice.BlockChain().SubscribeChainHeadEvent(heads)
+	defer sub.Unsubscribe()
+
+	// Nothing is sealed while there are no transactions. Idle past a few round
+	// timeouts, a round changing chain would now be in the middle of a 4s backoff.
+	select {
+	case ev := <-heads:
+		t.Fatalf("block %d sealed without transactions", ev.Block.NumberU64())
+	case <-time.After(3500 * time.Millisecond):
+	}
+	tx, err := types.SignNewTx(key, types.LatestSigner(genesis.Config), &types.DynamicFeeTx{
+		ChainID:   genesis.Config.ChainID,
+		Gas:       params.TxGas,
+		GasFeeCap: big.NewInt(2 * params.InitialBaseFee),
+		To:        &common.Address{0xaa},
+		Value:     big.NewInt(1),
+	})
+	if err != nil {
+		t.Fatalf("failed to sign transaction: %v", err)
+	}
+	if err := ethservice.TxPool().AddLocal(tx); err != nil {
+		t.Fatalf("failed to add transaction: %v", err)
+	}
+	var block *types.Block
+	select {
+	case ev := <-heads:
+		block = ev.Block
+	case <-time.After(2 * time.Second):
+		t.Fatalf("transaction not sealed")
+	}
+	if block.NumberU64() != 1 || len(block.Transactions()) != 1 || block.Transactions()[0].Hash() != tx.Hash() {
+		t.Fatalf("sealed block mismatch: number %d, %d transactions", block.NumberU64(), len(block.Transactions()))
+	}
+	if block.Coinbase() != validator {
+		t.Errorf("coinbase mismatch: have %x, want %x", block.Coinbase(), validator)
+	}
+	// The transaction pays no tip, the validator only earns the block reward
+	statedb, err := ethservice.BlockChain().StateAt(block.Root())
+	if err != nil {
+		t.Fatalf("failed to get state: %v", err)
+	}
+	engine := ethservice.Engine().(*istanbulBackend.Backend)
+	reward := engine.GetBaseBlockReward(ethservice.BlockChain(), block.Header(), nil)
+	if reward.Sign() <= 0 {
+		t.Fatalf("no block reward configured")
+	}
+	if balance := statedb.GetBalance(validator); balance.Cmp(reward) != 0 {
+		t.Errorf("validator balance mismatch: have %v, want %v", balance, reward)
+	}
+}
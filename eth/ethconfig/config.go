@@ -89,6 +89,8 @@ var Defaults = Config{
 		GasCeil:  30000000,
 		GasPrice: big.NewInt(params.GWei),
 		Recommit: 3 * time.Second,
+
+		PriorityGasReserve: 100,
 	},
 	TxPool:        core.DefaultTxPoolConfig,
 	RPCGasCap:     50000000,
@@ -141,13 +143,20 @@ type Config struct {
 	NoPruning  bool // Whether to disable pruning and flush everything to disk
 	NoPrefetch bool // Whether to disable prefetching and only load state on demand
 
+	StateScheme string `toml:",omitempty"` // Scheme used to store the state ("hash" or "path"), empty for the one of the existing database
+
 	TxLookupLimit uint64 `toml:",omitempty"` // The maximum number of blocks from head whose tx indices are reserved.
+	HistoryExpiry uint64 `toml:",omitempty"` // The number of blocks from head whose bodies and receipts are kept, 0 for all.
 
 	// RequiredBlocks is a set of block number -> hash mappings which must be in the
 	// canonical chain of all remote peers. Setting the option makes geth verify the
 	// presence of these blocks for every new peer connection.
 	RequiredBlocks map[uint64]common.Hash `toml:"-"`
 
+	// Permissioned network options
+	PermissionedNodes   string `toml:",omitempty"` // Path of the allowlist file of the nodes allowed to connect
+	PermissionsAuditLog string `toml:",omitempty"` // Path of the audit log of the node permission decisions
+
 	// Light client options
 	LightServ          int  `toml:",omitempty"` // Maximum percentage of time allowed for serving LES requests
 	LightIngress       int  `toml:",omitempty"` // Incoming bandwidth limit for light servers
@@ -233,6 +242,11 @@ func CreateConsensusEngine(stack *node.Node, chainConfig *params.ChainConfig, co
 			RequestTimeoutSeconds:    chainConfig.IBFT.RequestTimeoutSeconds,
 			MaxRequestTimeoutSeconds: chainConfig.IBFT.MaxRequestTimeoutSeconds,
 			AllowedFutureBlockTime:   chainConfig.IBFT.AllowedFutureBlockTime,
+			ValidatorWeights:         chainConfig.IBFT.ValidatorWeights,
+			BlockReward:              chainConfig.IBFT.BlockReward,
+			HalvingPeriod:            chainConfig.IBFT.HalvingPeriod,
+			EmissionBlockOffset:      chainConfig.IBFT.EmissionBlockOffset,
+			MaxSupply:                chainConfig.IBFT.MaxSupply,
 			Transitions:              chainConfig.Transitions,
 		}, stack.GetNodeKey(), db)
 	} else if chainConfig.Clique != nil {
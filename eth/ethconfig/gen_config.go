@@ -26,8 +26,12 @@ func (c Config) MarshalTOML() (interface{}, error) {
 		SnapDiscoveryURLs               []string
 		NoPruning                       bool
 		NoPrefetch                      bool
+		StateScheme                     string                 `toml:",omitempty"`
 		TxLookupLimit                   uint64                 `toml:",omitempty"`
+		HistoryExpiry                   uint64                 `toml:",omitempty"`
 		RequiredBlocks                  map[uint64]common.Hash `toml:"-"`
+		PermissionedNodes               string                 `toml:",omitempty"`
+		PermissionsAuditLog             string                 `toml:",omitempty"`
 		LightServ                       int                    `toml:",omitempty"`
 		LightIngress                    int                    `toml:",omitempty"`
 		LightEgress                     int                    `toml:",omitempty"`
@@ -72,8 +76,12 @@ func (c Config) MarshalTOML() (interface{}, error) {
 	enc.SnapDiscoveryURLs = c.SnapDiscoveryURLs
 	enc.NoPruning = c.NoPruning
 	enc.NoPrefetch = c.NoPrefetch
+	enc.StateScheme = c.StateScheme
 	enc.TxLookupLimit = c.TxLookupLimit
+	enc.HistoryExpiry = c.HistoryExpiry
 	enc.RequiredBlocks = c.RequiredBlocks
+	enc.PermissionedNodes = c.PermissionedNodes
+	enc.PermissionsAuditLog = c.PermissionsAuditLog
 	enc.LightServ = c.LightServ
 	enc.LightIngress = c.LightIngress
 	enc.LightEgress = c.LightEgress
@@ -122,8 +130,12 @@ func (c *Config) UnmarshalTOML(unmarshal func(interface{}) error) error {
 		SnapDiscoveryURLs               []string
 		NoPruning                       *bool
 		NoPrefetch                      *bool
+		StateScheme                     *string                `toml:",omitempty"`
 		TxLookupLimit                   *uint64                `toml:",omitempty"`
+		HistoryExpiry                   *uint64                `toml:",omitempty"`
 		RequiredBlocks                  map[uint64]common.Hash `toml:"-"`
+		PermissionedNodes               *string                `toml:",omitempty"`
+		PermissionsAuditLog             *string                `toml:",omitempty"`
 		LightServ                       *int                   `toml:",omitempty"`
 		LightIngress                    *int                   `toml:",omitempty"`
 		LightEgress                     *int                   `toml:",omitempty"`
@@ -185,12 +197,24 @@ func (c *Config) UnmarshalTOML(unmarshal func(interface{}) error) error {
 	if dec.NoPrefetch != nil {
 		c.NoPrefetch = *dec.NoPrefetch
 	}
+	if dec.StateScheme != nil {
+		c.StateScheme = *dec.StateScheme
+	}
 	if dec.TxLookupLimit != nil {
 		c.TxLookupLimit = *dec.TxLookupLimit
 	}
+	if dec.HistoryExpiry != nil {
+		c.HistoryExpiry = *dec.HistoryExpiry
+	}
 	if dec.RequiredBlocks != nil {
 		c.RequiredBlocks = dec.RequiredBlocks
 	}
+	if dec.PermissionedNodes != nil {
+		c.PermissionedNodes = *dec.PermissionedNodes
+	}
+	if dec.PermissionsAuditLog != nil {
+		c.PermissionsAuditLog = *dec.PermissionsAuditLog
+	}
 	if dec.LightServ != nil {
 		c.LightServ = *dec.LightServ
 	}
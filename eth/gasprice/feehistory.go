@@ -115,7 +115,7 @@ func (oracle *Oracle) processBlock(bf *blockFees, percentiles []float64) {
 	sorter := make(sortGasAndReward, len(bf.block.Transactions()))
 	for i, tx := range bf.block.Transactions() { //this is post block confirmation
 		var reward *big.Int
-		if tx.Type() == types.PriorityTxType && tx.HasZeroFee() {
+		if tx.IsPriority() && tx.HasZeroFee() {
 			reward, _ = tx.EffectiveGasTip(big.NewInt(0))
 		} else {
 			reward, _ = tx.EffectiveGasTip(bf.block.BaseFee())
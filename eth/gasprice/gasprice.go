@@ -271,7 +271,7 @@ func (oracle *Oracle) getBlockValues(ctx context.Context, signer types.Signer, b
 
 	var prices []*big.Int
 	for _, tx := range sorter.txs {
-		if tx.Type() == types.PriorityTxType {
+		if tx.IsPriority() {
 			continue // do not include ANY priority tx in GPO fee suggestion calculations. For now, have the GPO cater more so for the average person, rather than a priority sender (who only competes with other priority senders). When we give priority keys out to third parties eventually, we can recode the GPO to give different suggestions dependng on priority status
 		}
 		tip, _ := tx.EffectiveGasTip(block.BaseFee())
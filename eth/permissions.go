@@ -0,0 +1,338 @@
+// Copyright 2023 Electroneum Ltd
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package eth
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/electroneum/electroneum-sc/common"
+	"github.com/electroneum/electroneum-sc/core"
+	"github.com/electroneum/electroneum-sc/core/types"
+	"github.com/electroneum/electroneum-sc/crypto"
+	"github.com/electroneum/electroneum-sc/event"
+	"github.com/electroneum/electroneum-sc/log"
+	"github.com/electroneum/electroneum-sc/p2p"
+	"github.com/electroneum/electroneum-sc/p2p/enode"
+	"github.com/electroneum/electroneum-sc/params"
+)
+
+// allowlistReloadInterval is the interval at which the allowlist file is
+// checked for changes.
+const allowlistReloadInterval = 3 * time.Second
+
+// Sources the permission of a node to connect can be granted by.
+const (
+	permissionUnrestricted = "unrestricted" // No allowlist is in effect yet
+	permissionAllowlist    = "allowlist"    // Listed by the local allowlist file
+	permissionRegistry     = "registry"     // Registered in the node registry contract
+)
+
+// nodePermissions allows the nodes listed by a local allowlist file, or by the
+// node registry contract of the chain, to connect to the p2p server. Changes of
+// both the file and the contract are picked up live, and every decision is
+// recorded in the audit log.
+type nodePermissions struct {
+	chain *core.BlockChain
+	file  string // Path of the allowlist file, empty if none
+	audit *permissionsAudit
+
+	lock      sync.RWMutex
+	fileNodes map[enode.ID]bool   // Nodes of the allowlist file, nil if there's no file
+	fileTime  time.Time           // Modification time of the allowlist file when last read
+	registry  map[enode.ID]string // Names of the registered nodes, nil if there's no registry contract yet
+
+	feed  event.Feed
+	scope event.SubscriptionScope
+	quit  chan struct{}
+	wg    sync.WaitGroup
+}
+
+// hasNodeRegistry returns whether the chain is permissioned on-chain, at any
+// block.
+func hasNodeRegistry(config *params.ChainConfig) bool {
+	if config.NodeRegistryContractAddress != (common.Address{}) {
+		return true
+	}
+	for _, transition := range config.Transitions {
+		if transition.NodeRegistryContractAddress != (common.Address{}) {
+			return true
+		}
+	}
+	return false
+}
+
+// newNodePermissions creates the node permissions from the given allowlist file
+// and the node registry contract of the chain, and starts tracking their changes.
+func newNodePermissions(chain *core.BlockChain, file string, auditLog string) (*nodePermissions, error) {
+	audit, err := newPermissionsAudit(auditLog)
+	if err != nil {
+		return nil, err
+	}
+	p := &nodePermissions{
+		chain: chain,
+		file:  file,
+		audit: audit,
+		quit:  make(chan struct{}),
+	}
+	if file != "" {
+		p.reloadAllowlist()
+	}
+	p.reloadRegistry(chain.CurrentBlock().Header())
+
+	p.wg.Add(1)
+	go p.loop()
+	return p, nil
+}
+
+// loop picks up the changes of the allowlist file and of the node registry
+// contract at each new head.
+func (p *nodePermissions) loop() {
+	defer p.wg.Done()
+
+	heads := make(chan core.ChainHeadEvent, 10)
+	sub := p.chain.SubscribeChainHeadEvent(heads)
+	defer sub.Unsubscribe()
+
+	ticker := time.NewTicker(allowlistReloadInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			if p.file != "" && p.reloadAllowlist() {
+				p.feed.Send(struct{}{})
+			}
+		case head := <-heads:
+			if p.reloadRegistry(head.Block.Header()) {
+				p.feed.Send(struct{}{})
+			}
+		case <-sub.Err():
+			return
+		case <-p.quit:
+			return
+		}
+	}
+}
+
+// stop terminates the change tracking and closes the audit log.
+func (p *nodePermissions) stop() {
+	close(p.quit)
+	p.wg.Wait()
+	p.scope.Close()
+	p.audit.close()
+}
+
+// reloadAllowlist reads the allowlist file again if it was modified since last
+// read, returning whether the allowed nodes changed. A missing or invalid file
+// allows no node.
+func (p *nodePermissions) reloadAllowlist() bool {
+	var modTime time.Time
+	if info, err := os.Stat(p.file); err == nil {
+		modTime = info.ModTime()
+	}
+	if p.fileNodes != nil && modTime.Equal(p.fileTime) {
+		return false
+	}
+	nodes := make(map[enode.ID]bool)
+
+	var urls []string
+	if err := common.LoadJSON(p.file, &urls); err != nil {
+		log.Error("Can't load node allowlist file", "path", p.file, "err", err)
+	}
+	for _, url := range urls {
+		if url == "" {
+			continue
+		}
+		node, err := enode.Parse(enode.ValidSchemes, url)
+		if err != nil {
+			log.Error("Node URL in allowlist file invalid", "url", url, "err", err)
+			continue
+		}
+		nodes[node.ID()] = true
+	}
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	changed := p.fileNodes == nil || !sameNodes(p.fileNodes, nodes)
+	p.fileNodes, p.fileTime = nodes, modTime
+	if changed {
+		log.Info("Loaded node allowlist file", "path", p.file, "nodes", len(nodes))
+	}
+	return changed
+}
+
+// reloadRegistry reads the registered nodes in the state of the given block,
+// returning whether the allowed nodes changed.
+func (p *nodePermissions) reloadRegistry(header *types.Header) bool {
+	statedb, err := p.chain.StateAt(header.Root)
+	if err != nil {
+		log.Warn("Failed to get state for node registry", "number", header.Number, "hash", header.Hash(), "err", err)
+		return false
+	}
+	registered, err := p.chain.GetRegisteredNodesForState(header, statedb)
+	if err != nil && !errors.Is(err, core.ErrNoNodeRegistry) {
+		log.Warn("Failed to get registered nodes", "number", header.Number, "hash", header.Hash(), "err", err)
+		return false
+	}
+	var nodes map[enode.ID]string
+	if registered != nil {
+		nodes = make(map[enode.ID]string, len(registered))
+		for pubkey, name := range registered {
+			key, err := crypto.UnmarshalPubkey(pubkey[:])
+			if err != nil {
+				log.Warn("Invalid public key in node registry", "pubkey", pubkey, "err", err)
+				continue
+			}
+			nodes[enode.PubkeyToIDV4(key)] = name
+		}
+	}
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	changed := (p.registry == nil) != (nodes == nil) || len(p.registry) != len(nodes)
+	for id, name := range nodes {
+		if prev, ok := p.registry[id]; !ok || prev != name {
+			changed = true
+		}
+	}
+	p.registry = nodes
+	if changed {
+		log.Info("Loaded node registry", "number", header.Number, "hash", header.Hash(), "nodes", len(nodes))
+	}
+	return changed
+}
+
+// Allowed implements p2p.NodePermissions, allowing the nodes listed by either
+// the allowlist file or the node registry contract. Until any is in effect,
+// every node is allowed.
+func (p *nodePermissions) Allowed(req *p2p.PermissionRequest) bool {
+	p.lock.RLock()
+	defer p.lock.RUnlock()
+
+	var (
+		id      = req.Node.ID()
+		allowed = true
+		source  string
+		name    string
+	)
+	switch {
+	case p.fileNodes == nil && p.registry == nil:
+		source = permissionUnrestricted
+	case p.fileNodes[id]:
+		source = permissionAllowlist
+	default:
+		name, allowed = p.registry[id]
+		if allowed {
+			source = permissionRegistry
+		}
+	}
+	p.audit.record(req, allowed, source, name)
+	return allowed
+}
+
+// SubscribeChanges implements p2p.NodePermissions, notifying the given channel
+// each time the allowed nodes change.
+func (p *nodePermissions) SubscribeChanges(ch chan<- struct{}) event.Subscription {
+	return p.scope.Track(p.feed.Subscribe(ch))
+}
+
+// sameNodes returns whether two sets of nodes are the same.
+func sameNodes(a, b map[enode.ID]bool) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for id := range a {
+		if !b[id] {
+			return false
+		}
+	}
+	return true
+}
+
+// permissionsAuditEntry is a permission decision, as written to the audit log.
+type permissionsAuditEntry struct {
+	Time    time.Time `json:"time"`
+	Node    string    `json:"node"`
+	Addr    string    `json:"addr"`
+	Inbound bool      `json:"inbound"`
+	Recheck bool      `json:"recheck"` // Whether a connected peer was checked again after a change
+	Allowed bool      `json:"allowed"`
+	Source  string    `json:"source,omitempty"` // Source that allowed the node
+	Name    string    `json:"name,omitempty"`   // Name of the node in the registry
+}
+
+// permissionsAudit records every permission decision to the log, and to an audit
+// log file of JSON lines if configured.
+type permissionsAudit struct {
+	lock sync.Mutex
+	file *os.File
+	enc  *json.Encoder
+}
+
+// newPermissionsAudit creates the audit of the permission decisions, appending
+// them to the given file if not empty.
+func newPermissionsAudit(path string) (*permissionsAudit, error) {
+	audit := new(permissionsAudit)
+	if path != "" {
+		file, err := os.OpenFile(path, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0600)
+		if err != nil {
+			return nil, err
+		}
+		audit.file, audit.enc = file, json.NewEncoder(file)
+	}
+	return audit, nil
+}
+
+// record audits the permission decision for the node of a request.
+func (a *permissionsAudit) record(req *p2p.PermissionRequest, allowed bool, source string, name string) {
+	entry := &permissionsAuditEntry{
+		Time:    time.Now(),
+		Node:    req.Node.URLv4(),
+		Inbound: req.Inbound,
+		Recheck: req.Connected,
+		Allowed: allowed,
+		Source:  source,
+		Name:    name,
+	}
+	if req.RemoteAddr != nil {
+		entry.Addr = req.RemoteAddr.String()
+	}
+	if allowed {
+		log.Debug("Node permitted", "id", req.Node.ID(), "addr", entry.Addr, "inbound", entry.Inbound, "recheck", entry.Recheck, "source", source, "name", name)
+	} else {
+		log.Info("Node not permitted", "id", req.Node.ID(), "addr", entry.Addr, "inbound", entry.Inbound, "recheck", entry.Recheck)
+	}
+	if a.enc == nil {
+		return
+	}
+	a.lock.Lock()
+	defer a.lock.Unlock()
+	if err := a.enc.Encode(entry); err != nil {
+		log.Warn("Failed to write permissions audit log", "err", err)
+	}
+}
+
+// close closes the audit log file, if any.
+func (a *permissionsAudit) close() {
+	if a.file != nil {
+		a.file.Close()
+	}
+}
@@ -0,0 +1,139 @@
+// Copyright 2023 Electroneum Ltd
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package eth
+
+import (
+	"bufio"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/electroneum/electroneum-sc/consensus/ethash"
+	"github.com/electroneum/electroneum-sc/core"
+	"github.com/electroneum/electroneum-sc/core/rawdb"
+	"github.com/electroneum/electroneum-sc/core/vm"
+	"github.com/electroneum/electroneum-sc/crypto"
+	"github.com/electroneum/electroneum-sc/p2p"
+	"github.com/electroneum/electroneum-sc/p2p/enode"
+	"github.com/electroneum/electroneum-sc/params"
+)
+
+// Tests that the nodes of the allowlist file are allowed to connect, that
+// changes of the file are picked up, and that every decision is audited.
+func TestNodePermissionsAllowlist(t *testing.T) {
+	db := rawdb.NewMemoryDatabase()
+	(&core.Genesis{Config: params.TestChainConfig}).MustCommit(db)
+	chain, _ := core.NewBlockChain(db, nil, params.TestChainConfig, ethash.NewFaker(), vm.Config{}, nil, nil)
+	defer chain.Stop()
+
+	var nodes []*enode.Node
+	for i := 0; i < 2; i++ {
+		key, _ := crypto.GenerateKey()
+		nodes = append(nodes, enode.NewV4(&key.PublicKey, nil, 0, 0))
+	}
+	var (
+		dir       = t.TempDir()
+		allowlist = filepath.Join(dir, "permissioned-nodes.json")
+		auditLog  = filepath.Join(dir, "audit.log")
+	)
+	writeAllowlist := func(nodes ...*enode.Node) {
+		urls := make([]string, len(nodes))
+		for i, node := range nodes {
+			urls[i] = node.URLv4()
+		}
+		blob, _ := json.Marshal(urls)
+		if err := os.WriteFile(allowlist, blob, 0600); err != nil {
+			t.Fatalf("failed to write allowlist: %v", err)
+		}
+	}
+	allowed := func(p *nodePermissions, node *enode.Node) bool {
+		return p.Allowed(&p2p.PermissionRequest{Node: node, Inbound: true})
+	}
+	// Without allowlist nor registry, every node is allowed
+	unrestricted, err := newNodePermissions(chain, "", "")
+	if err != nil {
+		t.Fatalf("failed to create permissions: %v", err)
+	}
+	if !allowed(unrestricted, nodes[0]) || !allowed(unrestricted, nodes[1]) {
+		t.Errorf("node rejected without allowlist")
+	}
+	unrestricted.stop()
+
+	// Only the nodes of the allowlist are allowed
+	writeAllowlist(nodes[0])
+	permissions, err := newNodePermissions(chain, allowlist, auditLog)
+	if err != nil {
+		t.Fatalf("failed to create permissions: %v", err)
+	}
+	if !allowed(permissions, nodes[0]) {
+		t.Errorf("listed node rejected")
+	}
+	if allowed(permissions, nodes[1]) {
+		t.Errorf("unlisted node allowed")
+	}
+	// Changes of the allowlist are picked up
+	writeAllowlist(nodes[1])
+	future := time.Now().Add(time.Minute)
+	os.Chtimes(allowlist, future, future)
+
+	if !permissions.reloadAllowlist() {
+		t.Fatalf("allowlist change not detected")
+	}
+	if permissions.reloadAllowlist() {
+		t.Errorf("unchanged allowlist reloaded")
+	}
+	if allowed(permissions, nodes[0]) {
+		t.Errorf("node removed from allowlist allowed")
+	}
+	if !allowed(permissions, nodes[1]) {
+		t.Errorf("node added to allowlist rejected")
+	}
+	permissions.stop()
+
+	// Every decision is audited
+	file, err := os.Open(auditLog)
+	if err != nil {
+		t.Fatalf("failed to open audit log: %v", err)
+	}
+	defer file.Close()
+
+	var entries []permissionsAuditEntry
+	for scanner := bufio.NewScanner(file); scanner.Scan(); {
+		var entry permissionsAuditEntry
+		if err := json.Unmarshal(scanner.Bytes(), &entry); err != nil {
+			t.Fatalf("invalid audit log entry %q: %v", scanner.Text(), err)
+		}
+		entries = append(entries, entry)
+	}
+	want := []struct {
+		node    *enode.Node
+		allowed bool
+	}{{nodes[0], true}, {nodes[1], false}, {nodes[0], false}, {nodes[1], true}}
+	if len(entries) != len(want) {
+		t.Fatalf("audit log entries mismatch: have %d, want %d", len(entries), len(want))
+	}
+	for i, w := range want {
+		if entries[i].Node != w.node.URLv4() || entries[i].Allowed != w.allowed {
+			t.Errorf("audit log entry %d mismatch: have %s allowed %t, want %s allowed %t", i, entries[i].Node, entries[i].Allowed, w.node.URLv4(), w.allowed)
+		}
+		if w.allowed && entries[i].Source != permissionAllowlist {
+			t.Errorf("audit log entry %d source mismatch: have %q, want %q", i, entries[i].Source, permissionAllowlist)
+		}
+	}
+}
@@ -23,6 +23,7 @@ import (
 
 	"github.com/electroneum/electroneum-sc/common"
 	"github.com/electroneum/electroneum-sc/core"
+	"github.com/electroneum/electroneum-sc/core/rawdb"
 	"github.com/electroneum/electroneum-sc/core/state"
 	"github.com/electroneum/electroneum-sc/core/types"
 	"github.com/electroneum/electroneum-sc/core/vm"
@@ -65,6 +66,15 @@ func (eth *Ethereum) StateAtBlock(block *types.Block, reexec uint64, base *state
 			return statedb, nil
 		}
 	}
+	// The path-based state scheme only keeps the recent states, all of them in the
+	// live database. Older states can't be regenerated, as the blocks would need
+	// to be reexecuted on top of a state which is gone.
+	if eth.blockchain.StateCache().TrieDB().Scheme() == rawdb.PathScheme && base == nil {
+		if statedb, err = eth.blockchain.StateAt(block.Root()); err != nil {
+			return nil, fmt.Errorf("required historical state unavailable in path-based state storage: %v", err)
+		}
+		return statedb, nil
+	}
 	if base != nil {
 		if preferDisk {
 			// Create an ephemeral trie.Database for isolating the live one. Otherwise
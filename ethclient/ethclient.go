@@ -167,6 +167,9 @@ func (ec *Client) getBlock(ctx context.Context, method string, args ...interface
 		if tx.From != nil {
 			setSenderFromServer(tx.tx, *tx.From, body.Hash)
 		}
+		if tx.PriorityPubkey != nil {
+			setPrioritySenderFromServer(tx.tx, *tx.PriorityPubkey, body.Hash)
+		}
 		txs[i] = tx.tx
 	}
 	return types.NewBlockWithHeader(head).WithBody(txs, uncles), nil
@@ -202,6 +205,8 @@ type txExtraInfo struct {
 	BlockNumber *string         `json:"blockNumber,omitempty"`
 	BlockHash   *common.Hash    `json:"blockHash,omitempty"`
 	From        *common.Address `json:"from,omitempty"`
+
+	PriorityPubkey *common.PublicKey `json:"priorityPubkey,omitempty"`
 }
 
 func (tx *rpcTransaction) UnmarshalJSON(msg []byte) error {
@@ -225,6 +230,9 @@ func (ec *Client) TransactionByHash(ctx context.Context, hash common.Hash) (tx *
 	if json.From != nil && json.BlockHash != nil {
 		setSenderFromServer(json.tx, *json.From, *json.BlockHash)
 	}
+	if json.PriorityPubkey != nil && json.BlockHash != nil {
+		setPrioritySenderFromServer(json.tx, *json.PriorityPubkey, *json.BlockHash)
+	}
 	return json.tx, json.BlockNumber == nil, nil
 }
 
@@ -255,6 +263,39 @@ func (ec *Client) TransactionSender(ctx context.Context, tx *types.Transaction,
 	return meta.From, nil
 }
 
+// TransactionPrioritySender returns the priority transactor public key of the given
+// priority transaction. The transaction must be known to the remote node and included
+// in the blockchain at the given block and index.
+//
+// There is a fast-path for transactions retrieved by TransactionByHash and
+// TransactionInBlock. Getting their priority key can be done without an RPC interaction.
+func (ec *Client) TransactionPrioritySender(ctx context.Context, tx *types.Transaction, block common.Hash, index uint) (common.PublicKey, error) {
+	if !tx.IsPriority() {
+		return common.PublicKey{}, types.ErrTxTypeNotSupported
+	}
+	// Try to load the public key from the cache.
+	pubkey, err := types.PrioritySender(&senderFromServer{blockhash: block}, tx)
+	if err == nil {
+		return pubkey, nil
+	}
+
+	// It was not found in cache, ask the server.
+	var meta struct {
+		Hash           common.Hash
+		PriorityPubkey *common.PublicKey
+	}
+	if err = ec.c.CallContext(ctx, &meta, "eth_getTransactionByBlockHashAndIndex", block, hexutil.Uint64(index)); err != nil {
+		return common.PublicKey{}, err
+	}
+	if meta.Hash == (common.Hash{}) || meta.Hash != tx.Hash() {
+		return common.PublicKey{}, errors.New("wrong inclusion block/index")
+	}
+	if meta.PriorityPubkey == nil {
+		return common.PublicKey{}, errors.New("server returned transaction without priority public key")
+	}
+	return *meta.PriorityPubkey, nil
+}
+
 // TransactionCount returns the total number of transactions in the given block.
 func (ec *Client) TransactionCount(ctx context.Context, blockHash common.Hash) (uint, error) {
 	var num hexutil.Uint
@@ -277,6 +318,9 @@ func (ec *Client) TransactionInBlock(ctx context.Context, blockHash common.Hash,
 	if json.From != nil && json.BlockHash != nil {
 		setSenderFromServer(json.tx, *json.From, *json.BlockHash)
 	}
+	if json.PriorityPubkey != nil && json.BlockHash != nil {
+		setPrioritySenderFromServer(json.tx, *json.PriorityPubkey, *json.BlockHash)
+	}
 	return json.tx, err
 }
 
@@ -29,6 +29,7 @@ import (
 // request in TransactionSender.
 type senderFromServer struct {
 	addr      common.Address
+	pubkey    common.PublicKey
 	blockhash common.Hash
 }
 
@@ -36,7 +37,12 @@ var errNotCached = errors.New("sender not cached")
 
 func setSenderFromServer(tx *types.Transaction, addr common.Address, block common.Hash) {
 	// Use types.Sender for side-effect to store our signer into the cache.
-	types.Sender(&senderFromServer{addr, block}, tx)
+	types.Sender(&senderFromServer{addr: addr, blockhash: block}, tx)
+}
+
+func setPrioritySenderFromServer(tx *types.Transaction, pubkey common.PublicKey, block common.Hash) {
+	// Use types.PrioritySender for side-effect to store our signer into the cache.
+	types.PrioritySender(&senderFromServer{pubkey: pubkey, blockhash: block}, tx)
 }
 
 func (s *senderFromServer) Equal(other types.Signer) bool {
@@ -51,8 +57,11 @@ func (s *senderFromServer) Sender(tx *types.Transaction) (common.Address, error)
 	return s.addr, nil
 }
 
-func (s *senderFromServer) PrioritySender(tx *types.Transaction) (common.PublicKey, error) { // implement this later
-	return common.PublicKey{}, nil
+func (s *senderFromServer) PrioritySender(tx *types.Transaction) (common.PublicKey, error) {
+	if s.pubkey == (common.PublicKey{}) {
+		return common.PublicKey{}, errNotCached
+	}
+	return s.pubkey, nil
 }
 
 func (s *senderFromServer) ChainID() *big.Int {
@@ -374,6 +374,16 @@ func TestDatabaseSuite(t *testing.T, New func() ethdb.KeyValueStore) {
 				t.Fatal("Unexpected deletion")
 			}
 		}
+		// Ensure iterating the snapshot yields the initial content only
+		it := snapshot.NewIterator(nil, nil)
+		if got, want := iterateKeys(it), []string{"k1", "k2", "k3", "k4"}; !reflect.DeepEqual(got, want) {
+			t.Errorf("snapshot iterator got: %s; want: %s", got, want)
+		}
+		it = snapshot.NewIterator([]byte("k"), []byte("3"))
+		if got, want := iterateKeys(it), []string{"k3", "k4"}; !reflect.DeepEqual(got, want) {
+			t.Errorf("snapshot iterator got: %s; want: %s", got, want)
+		}
+		snapshot.Release()
 	})
 }
 
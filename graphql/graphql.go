@@ -28,6 +28,7 @@ import (
 	"github.com/electroneum/electroneum-sc/common"
 	"github.com/electroneum/electroneum-sc/common/hexutil"
 	"github.com/electroneum/electroneum-sc/common/math"
+	"github.com/electroneum/electroneum-sc/consensus"
 	"github.com/electroneum/electroneum-sc/consensus/misc"
 	"github.com/electroneum/electroneum-sc/core/state"
 	"github.com/electroneum/electroneum-sc/core/types"
@@ -35,6 +36,7 @@ import (
 	"github.com/electroneum/electroneum-sc/internal/ethapi"
 	"github.com/electroneum/electroneum-sc/rlp"
 	"github.com/electroneum/electroneum-sc/rpc"
+	"github.com/electroneum/electroneum-sc/trie"
 )
 
 var (
@@ -247,7 +249,7 @@ func (t *Transaction) GasPrice(ctx context.Context) (hexutil.Big, error) {
 			}
 		}
 		return hexutil.Big(*tx.GasPrice()), nil
-	case types.PriorityTxType:
+	case types.PriorityTxType, types.MultiPriorityTxType:
 		if t.block != nil {
 			if baseFee, _ := t.block.BaseFeePerGas(ctx); baseFee != nil {
 				// price = min(gasTipCap + baseFee, gasFeeCap)
@@ -289,7 +291,7 @@ func (t *Transaction) MaxFeePerGas(ctx context.Context) (*hexutil.Big, error) {
 		return nil, nil
 	case types.DynamicFeeTxType:
 		return (*hexutil.Big)(tx.GasFeeCap()), nil
-	case types.PriorityTxType:
+	case types.PriorityTxType, types.MultiPriorityTxType:
 		return (*hexutil.Big)(tx.GasFeeCap()), nil
 	default:
 		return nil, nil
@@ -306,7 +308,7 @@ func (t *Transaction) MaxPriorityFeePerGas(ctx context.Context) (*hexutil.Big, e
 		return nil, nil
 	case types.DynamicFeeTxType:
 		return (*hexutil.Big)(tx.GasTipCap()), nil
-	case types.PriorityTxType:
+	case types.PriorityTxType, types.MultiPriorityTxType:
 		return (*hexutil.Big)(tx.GasTipCap()), nil
 	default:
 		return nil, nil
@@ -330,7 +332,7 @@ func (t *Transaction) EffectiveTip(ctx context.Context) (*hexutil.Big, error) {
 		return (*hexutil.Big)(tx.GasPrice()), nil
 	}
 	var tip *big.Int
-	if tx.Type() == types.PriorityTxType && tx.HasZeroFee() {
+	if tx.IsPriority() && tx.HasZeroFee() {
 		tip, err = tx.EffectiveGasTip(big.NewInt(0))
 	} else {
 		tip, err = tx.EffectiveGasTip(header.BaseFee)
@@ -549,6 +551,143 @@ func (t *Transaction) RawReceipt(ctx context.Context) (hexutil.Bytes, error) {
 	return receipt.MarshalBinary()
 }
 
+// PrioritySignature represents the signature of a priority transactor
+// co-signing a transaction.
+type PrioritySignature struct {
+	sig types.PrioritySignature
+}
+
+func (ps *PrioritySignature) R(ctx context.Context) hexutil.Big {
+	return hexutil.Big(*ps.sig.R)
+}
+
+func (ps *PrioritySignature) S(ctx context.Context) hexutil.Big {
+	return hexutil.Big(*ps.sig.S)
+}
+
+func (ps *PrioritySignature) V(ctx context.Context) hexutil.Big {
+	return hexutil.Big(*ps.sig.V)
+}
+
+func (t *Transaction) PriorityR(ctx context.Context) (*hexutil.Big, error) {
+	tx, err := t.resolve(ctx)
+	if err != nil || tx == nil || !tx.IsPriority() {
+		return nil, err
+	}
+	_, r, _ := tx.RawPrioritySignatureValues()
+	return (*hexutil.Big)(r), nil
+}
+
+func (t *Transaction) PriorityS(ctx context.Context) (*hexutil.Big, error) {
+	tx, err := t.resolve(ctx)
+	if err != nil || tx == nil || !tx.IsPriority() {
+		return nil, err
+	}
+	_, _, s := tx.RawPrioritySignatureValues()
+	return (*hexutil.Big)(s), nil
+}
+
+func (t *Transaction) PriorityV(ctx context.Context) (*hexutil.Big, error) {
+	tx, err := t.resolve(ctx)
+	if err != nil || tx == nil || !tx.IsPriority() {
+		return nil, err
+	}
+	v, _, _ := tx.RawPrioritySignatureValues()
+	return (*hexutil.Big)(v), nil
+}
+
+func (t *Transaction) PrioritySignatures(ctx context.Context) (*[]*PrioritySignature, error) {
+	tx, err := t.resolve(ctx)
+	if err != nil || tx == nil || !tx.IsPriority() {
+		return nil, err
+	}
+	sigs := tx.RawPrioritySignatures()
+	ret := make([]*PrioritySignature, 0, len(sigs))
+	for _, sig := range sigs {
+		ret = append(ret, &PrioritySignature{sig: sig})
+	}
+	return &ret, nil
+}
+
+// prioritySenders returns the public keys of the priority transactors that
+// co-signed the transaction, or nil for regular transactions.
+func (t *Transaction) prioritySenders(ctx context.Context) ([]common.PublicKey, error) {
+	tx, err := t.resolve(ctx)
+	if err != nil || tx == nil || !tx.IsPriority() {
+		return nil, err
+	}
+	signer := types.LatestSigner(t.backend.ChainConfig())
+	return types.PrioritySenders(signer, tx)
+}
+
+func (t *Transaction) PriorityPublicKey(ctx context.Context) (*hexutil.Bytes, error) {
+	pubkeys, err := t.prioritySenders(ctx)
+	if err != nil || len(pubkeys) == 0 {
+		return nil, err
+	}
+	ret := hexutil.Bytes(pubkeys[0][:])
+	return &ret, nil
+}
+
+func (t *Transaction) PriorityPublicKeys(ctx context.Context) (*[]hexutil.Bytes, error) {
+	pubkeys, err := t.prioritySenders(ctx)
+	if err != nil || pubkeys == nil {
+		return nil, err
+	}
+	ret := make([]hexutil.Bytes, 0, len(pubkeys))
+	for _, pubkey := range pubkeys {
+		ret = append(ret, common.CopyBytes(pubkey[:]))
+	}
+	return &ret, nil
+}
+
+func (t *Transaction) PriorityEntity(ctx context.Context) (*string, error) {
+	pubkeys, err := t.prioritySenders(ctx)
+	if err != nil || len(pubkeys) == 0 {
+		return nil, err
+	}
+	// Priority transactions are validated against the transactors in the state of
+	// the parent block, or of the latest block while pending.
+	blockNrOrHash := rpc.BlockNumberOrHashWithNumber(rpc.LatestBlockNumber)
+	if t.block != nil {
+		header, err := t.block.resolveHeader(ctx)
+		if err != nil || header == nil {
+			return nil, err
+		}
+		blockNrOrHash = rpc.BlockNumberOrHashWithHash(header.ParentHash, false)
+	}
+	state, header, err := t.backend.StateAndHeaderByNumberOrHash(ctx, blockNrOrHash)
+	if err != nil {
+		// The parent state of an old block may have been pruned, in which case
+		// the entity is unknown rather than the query failing
+		var missing *trie.MissingNodeError
+		if errors.As(err, &missing) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	if state == nil {
+		return nil, nil
+	}
+	transactors, err := t.backend.GetPriorityTransactors(ctx, state, header)
+	if err != nil {
+		return nil, err
+	}
+	transactor, ok := transactors[pubkeys[0]]
+	if !ok || transactor.EntityName == "" {
+		return nil, nil
+	}
+	return &transactor.EntityName, nil
+}
+
+func (t *Transaction) GasPriceWaived(ctx context.Context) (bool, error) {
+	tx, err := t.resolve(ctx)
+	if err != nil || tx == nil {
+		return false, err
+	}
+	return tx.HasZeroFee(), nil
+}
+
 type BlockType int
 
 // Block represents an Ethereum block.
@@ -839,6 +978,72 @@ func (b *Block) Raw(ctx context.Context) (hexutil.Bytes, error) {
 	return rlp.EncodeToBytes(block)
 }
 
+// resolveQBFTExtra returns the IBFT extra data of the block header, or nil if
+// the chain is not sealed with IBFT.
+func (b *Block) resolveQBFTExtra(ctx context.Context) (*types.QBFTExtra, error) {
+	if _, ok := b.backend.Engine().(consensus.Istanbul); !ok {
+		return nil, nil
+	}
+	header, err := b.resolveHeader(ctx)
+	if err != nil || header == nil {
+		return nil, err
+	}
+	return types.ExtractQBFTExtra(header)
+}
+
+func (b *Block) Proposer(ctx context.Context, args BlockNumberArgs) (*Account, error) {
+	engine, ok := b.backend.Engine().(consensus.Istanbul)
+	if !ok {
+		return nil, nil
+	}
+	header, err := b.resolveHeader(ctx)
+	if err != nil || header == nil {
+		return nil, err
+	}
+	proposer, err := engine.Author(header)
+	if err != nil {
+		return nil, err
+	}
+	return &Account{
+		backend:       b.backend,
+		address:       proposer,
+		blockNrOrHash: args.NumberOrLatest(),
+	}, nil
+}
+
+func (b *Block) CommittedSealSigners(ctx context.Context) (*[]common.Address, error) {
+	engine, ok := b.backend.Engine().(consensus.Istanbul)
+	if !ok {
+		return nil, nil
+	}
+	header, err := b.resolveHeader(ctx)
+	if err != nil || header == nil {
+		return nil, err
+	}
+	signers, err := engine.Signers(header)
+	if err != nil {
+		return nil, err
+	}
+	return &signers, nil
+}
+
+func (b *Block) Round(ctx context.Context) (*Long, error) {
+	extra, err := b.resolveQBFTExtra(ctx)
+	if err != nil || extra == nil {
+		return nil, err
+	}
+	round := Long(extra.Round)
+	return &round, nil
+}
+
+func (b *Block) Validators(ctx context.Context) (*[]common.Address, error) {
+	extra, err := b.resolveQBFTExtra(ctx)
+	if err != nil || extra == nil {
+		return nil, err
+	}
+	return &extra.Validators, nil
+}
+
 // BlockNumberArgs encapsulates arguments to accessors that specify a block number.
 type BlockNumberArgs struct {
 	// TODO: Ideally we could use input unions to allow the query to specify the
@@ -17,6 +17,9 @@
 package graphql
 
 import (
+	"bytes"
+	"crypto/ecdsa"
+	"encoding/json"
 	"fmt"
 	"io"
 	"math/big"
@@ -26,14 +29,17 @@ import (
 	"time"
 
 	"github.com/electroneum/electroneum-sc/common"
+	"github.com/electroneum/electroneum-sc/common/hexutil"
 	"github.com/electroneum/electroneum-sc/consensus/ethash"
 	"github.com/electroneum/electroneum-sc/core"
+	"github.com/electroneum/electroneum-sc/core/rawdb"
 	"github.com/electroneum/electroneum-sc/core/types"
 	"github.com/electroneum/electroneum-sc/core/vm"
 	"github.com/electroneum/electroneum-sc/crypto"
 	"github.com/electroneum/electroneum-sc/eth"
 	"github.com/electroneum/electroneum-sc/eth/ethconfig"
 	"github.com/electroneum/electroneum-sc/node"
+	"github.com/electroneum/electroneum-sc/p2p"
 	"github.com/electroneum/electroneum-sc/params"
 
 	"github.com/stretchr/testify/assert"
@@ -353,3 +359,175 @@ func createGQLServiceWithTransactions(t *testing.T, stack *node.Node) {
 		t.Fatalf("could not create graphql service: %v", err)
 	}
 }
+
+// Tests the priority transaction fields on a priority and a multi-priority
+// transaction, and the IBFT fields of the block sealing them.
+func TestGraphQLPriorityAndIBFT(t *testing.T) {
+	nodekey, _ := crypto.GenerateKey()
+	stack, err := node.New(&node.Config{
+		HTTPHost: "127.0.0.1",
+		HTTPPort: 0,
+		P2P:      p2p.Config{PrivateKey: nodekey, NoDiscovery: true},
+	})
+	if err != nil {
+		t.Fatalf("could not create node: %v", err)
+	}
+	defer stack.Close()
+
+	var (
+		validator   = crypto.PubkeyToAddress(nodekey.PublicKey)
+		key, _      = crypto.GenerateKey()
+		soloKey, _  = crypto.GenerateKey()
+		pairKey1, _ = crypto.GenerateKey()
+		pairKey2, _ = crypto.GenerateKey()
+		pubkey      = func(key *ecdsa.PrivateKey) common.PublicKey {
+			return common.BytesToPublicKey(crypto.FromECDSAPub(&key.PublicKey))
+		}
+		transactors = common.PriorityTransactorMap{
+			pubkey(soloKey):  {IsGasPriceWaiver: true, EntityName: "solo"},
+			pubkey(pairKey1): {IsGasPriceWaiver: true, EntityName: "pair", SignatureThreshold: 2},
+			pubkey(pairKey2): {IsGasPriceWaiver: true, EntityName: "pair", SignatureThreshold: 2},
+		}
+		genesis = core.DeveloperGenesisBlock(0, 11500000, crypto.PubkeyToAddress(key.PublicKey), validator, transactors)
+		dad     = common.HexToAddress("0x0000000000000000000000000000000000000dad")
+	)
+	genesis.Config.MultiPriorityBlock = big.NewInt(0)
+
+	ethConf := ethconfig.Defaults
+	ethConf.Genesis = genesis
+	ethBackend, err := eth.New(stack, &ethConf)
+	if err != nil {
+		t.Fatalf("could not create eth backend: %v", err)
+	}
+	if err := New(stack, ethBackend.APIBackend, []string{}, []string{}); err != nil {
+		t.Fatalf("could not create graphql service: %v", err)
+	}
+	if err := stack.Start(); err != nil {
+		t.Fatalf("could not start node: %v", err)
+	}
+	if err := ethBackend.StartMining(0); err != nil {
+		t.Fatalf("could not start mining: %v", err)
+	}
+	signer := types.LatestSigner(genesis.Config)
+	soloTx, _ := types.SignNewPriorityTx(key, soloKey, signer, &types.PriorityTx{
+		ChainID:   genesis.Config.ChainID,
+		Nonce:     0,
+		To:        &dad,
+		Gas:       params.TxGas,
+		GasFeeCap: new(big.Int),
+		GasTipCap: new(big.Int),
+		Value:     big.NewInt(1),
+	})
+	pairTx, _ := types.SignNewMultiPriorityTx(key, []*ecdsa.PrivateKey{pairKey1, pairKey2}, signer, &types.MultiPriorityTx{
+		ChainID:   genesis.Config.ChainID,
+		Nonce:     1,
+		To:        &dad,
+		Gas:       params.TxGas,
+		GasFeeCap: new(big.Int),
+		GasTipCap: new(big.Int),
+		Value:     big.NewInt(1),
+	})
+	for i, err := range ethBackend.TxPool().AddLocals([]*types.Transaction{soloTx, pairTx}) {
+		if err != nil {
+			t.Fatalf("could not add transaction %d: %v", i, err)
+		}
+	}
+	// Wait for both transactions to be sealed
+	var (
+		header   *types.Header
+		deadline = time.Now().Add(10 * time.Second)
+	)
+	for header == nil {
+		_, soloHash, _, _ := rawdb.ReadTransaction(ethBackend.ChainDb(), soloTx.Hash())
+		_, pairHash, number, _ := rawdb.ReadTransaction(ethBackend.ChainDb(), pairTx.Hash())
+		if soloHash != (common.Hash{}) && pairHash != (common.Hash{}) {
+			header = ethBackend.BlockChain().GetHeader(pairHash, number)
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("transactions not sealed")
+		}
+		time.Sleep(50 * time.Millisecond)
+	}
+	query := func(body string, result interface{}) {
+		t.Helper()
+
+		req, _ := json.Marshal(map[string]string{"query": body})
+		resp, err := http.Post(fmt.Sprintf("%s/graphql", stack.HTTPEndpoint()), "application/json", bytes.NewReader(req))
+		if err != nil {
+			t.Fatalf("could not post: %v", err)
+		}
+		defer resp.Body.Close()
+
+		blob, _ := io.ReadAll(resp.Body)
+		if err := json.Unmarshal(blob, &struct{ Data interface{} }{result}); err != nil {
+			t.Fatalf("could not decode response %s: %v", blob, err)
+		}
+	}
+	type priorityFields struct {
+		PriorityPublicKey  *hexutil.Bytes
+		PriorityPublicKeys []hexutil.Bytes
+		PrioritySignatures []struct{ R, S, V hexutil.Big }
+		PriorityEntity     *string
+		GasPriceWaived     bool
+	}
+	fields := "priorityPublicKey priorityPublicKeys prioritySignatures { r s v } priorityEntity gasPriceWaived"
+	for i, tt := range []struct {
+		tx     *types.Transaction
+		keys   []*ecdsa.PrivateKey
+		entity string
+	}{
+		{soloTx, []*ecdsa.PrivateKey{soloKey}, "solo"},
+		{pairTx, []*ecdsa.PrivateKey{pairKey1, pairKey2}, "pair"},
+	} {
+		var result struct{ Transaction priorityFields }
+		query(fmt.Sprintf(`{transaction(hash: "%s") { %s }}`, tt.tx.Hash().Hex(), fields), &result)
+
+		tx := result.Transaction
+		if want := pubkey(tt.keys[0]); tx.PriorityPublicKey == nil || common.BytesToPublicKey(*tx.PriorityPublicKey) != want {
+			t.Errorf("transaction %d: priority public key mismatch: have %v, want %x", i, tx.PriorityPublicKey, want)
+		}
+		if len(tx.PriorityPublicKeys) != len(tt.keys) || len(tx.PrioritySignatures) != len(tt.keys) {
+			t.Fatalf("transaction %d: priority signers mismatch: have %d keys and %d signatures, want %d", i, len(tx.PriorityPublicKeys), len(tx.PrioritySignatures), len(tt.keys))
+		}
+		for j, key := range tt.keys {
+			if have := common.BytesToPublicKey(tx.PriorityPublicKeys[j]); have != pubkey(key) {
+				t.Errorf("transaction %d: priority public key %d mismatch: have %x, want %x", i, j, have, pubkey(key))
+			}
+		}
+		if tx.PriorityEntity == nil || *tx.PriorityEntity != tt.entity {
+			t.Errorf("transaction %d: priority entity mismatch: have %v, want %s", i, tx.PriorityEntity, tt.entity)
+		}
+		if !tx.GasPriceWaived {
+			t.Errorf("transaction %d: gas price not waived", i)
+		}
+	}
+	// The block carries the IBFT fields of the validator that sealed it
+	var result struct {
+		Block struct {
+			Proposer             struct{ Address common.Address }
+			CommittedSealSigners []common.Address
+			Round                *int64
+			Validators           []common.Address
+		}
+	}
+	query(fmt.Sprintf(`{block(number: %d) { proposer { address } committedSealSigners round validators }}`, header.Number), &result)
+
+	extra, err := types.ExtractQBFTExtra(header)
+	if err != nil {
+		t.Fatalf("could not extract extra: %v", err)
+	}
+	block := result.Block
+	if block.Proposer.Address != validator {
+		t.Errorf("proposer mismatch: have %x, want %x", block.Proposer.Address, validator)
+	}
+	if len(block.CommittedSealSigners) != 1 || block.CommittedSealSigners[0] != validator {
+		t.Errorf("committed seal signers mismatch: have %x, want [%x]", block.CommittedSealSigners, validator)
+	}
+	if block.Round == nil || *block.Round != int64(extra.Round) {
+		t.Errorf("round mismatch: have %v, want %d", block.Round, extra.Round)
+	}
+	if len(block.Validators) != 1 || block.Validators[0] != validator {
+		t.Errorf("validators mismatch: have %x, want [%x]", block.Validators, validator)
+	}
+}
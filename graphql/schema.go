@@ -75,6 +75,14 @@ const schema string = `
         storageKeys : [Bytes32!]!
     }
 
+    # PrioritySignature is the signature of a priority transactor co-signing a
+    # transaction.
+    type PrioritySignature {
+        r: BigInt!
+        s: BigInt!
+        v: BigInt!
+    }
+
     # Transaction is an Ethereum transaction.
     type Transaction {
         # Hash is the hash of this transaction.
@@ -147,6 +155,32 @@ const schema string = `
         # RawReceipt is the canonical encoding of the receipt. For post EIP-2718 typed transactions
         # this is equivalent to TxType || ReceiptEncoding.
         rawReceipt: Bytes!
+        # PriorityR, PriorityS and PriorityV are the signature of the priority
+        # transactor that co-signed this transaction, or of the first one for
+        # multi-priority transactions. They are null for regular transactions.
+        priorityR: BigInt
+        priorityS: BigInt
+        priorityV: BigInt
+        # PrioritySignatures is the list of signatures of all the priority
+        # transactors that co-signed this transaction. This is null for regular
+        # transactions.
+        prioritySignatures: [PrioritySignature!]
+        # PriorityPublicKey is the public key recovered from the priority
+        # signature, or from the first one for multi-priority transactions. This
+        # is null for regular transactions.
+        priorityPublicKey: Bytes
+        # PriorityPublicKeys is the list of public keys recovered from all the
+        # priority signatures. This is null for regular transactions.
+        priorityPublicKeys: [Bytes!]
+        # PriorityEntity is the entity name of the priority transactor, as
+        # registered when the transaction was included, or at the latest block
+        # for pending transactions. This is null for regular transactions, for
+        # transactors that are not registered or have no name, and if the state
+        # of the parent block is no longer available (e.g. pruned).
+        priorityEntity: String
+        # GasPriceWaived is true if this is a priority transaction whose gas
+        # fees were waived for its priority transactor.
+        gasPriceWaived: Boolean!
     }
 
     # BlockFilterCriteria encapsulates log filter criteria for a filter applied
@@ -246,6 +280,22 @@ const schema string = `
         rawHeader: Bytes!
         # Raw is the RLP encoding of the block.
         raw: Bytes!
+        # Proposer is the validator that proposed this block. This is null if
+        # the chain is not sealed with IBFT.
+        proposer(block: Long): Account
+        # CommittedSealSigners is the list of validators whose committed seals
+        # finalised this block. This is null if the chain is not sealed with IBFT.
+        committedSealSigners: [Address!]
+        # Round is the IBFT round in which this block was committed. This is null
+        # if the chain is not sealed with IBFT.
+        round: Long
+        # Validators is the validator set recorded in the extra data of this
+        # block. When the validators are selected by block header votes, this is
+        # the set that sealed the block. When the next block selects them from
+        # the validator contract, this is the set that seals the next block,
+        # read from the contract in the state of this one. This is null if the
+        # chain is not sealed with IBFT.
+        validators: [Address!]
     }
 
     # CallData represents the data associated with a local contract call.
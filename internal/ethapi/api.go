@@ -208,15 +208,86 @@ func (s *PublicTxPoolAPI) ContentFrom(addr common.Address) map[string]map[string
 	return content
 }
 
-// Status returns the number of pending and queued transaction in the pool.
-func (s *PublicTxPoolAPI) Status() map[string]hexutil.Uint {
-	pending, queue := s.b.Stats()
-	return map[string]hexutil.Uint{
-		"pending": hexutil.Uint(pending),
-		"queued":  hexutil.Uint(queue),
+// RPCTxDrop is the reason a transaction was rejected or evicted by the pool, as
+// returned over RPC.
+type RPCTxDrop struct {
+	Hash     common.Hash    `json:"hash"`
+	Priority bool           `json:"priority"`
+	Reason   string         `json:"reason"`
+	Error    string         `json:"error,omitempty"`
+	Time     hexutil.Uint64 `json:"time"`
+}
+
+func newRPCTxDrop(drop *core.TxDrop) *RPCTxDrop {
+	return &RPCTxDrop{
+		Hash:     drop.Hash,
+		Priority: drop.Priority,
+		Reason:   drop.Reason,
+		Error:    drop.Error,
+		Time:     hexutil.Uint64(drop.Time.Unix()),
+	}
+}
+
+// RPCTxPoolStatus is the status of a single transaction in the pool.
+type RPCTxPoolStatus struct {
+	Status string     `json:"status"` // One of unknown, pending, queued or dropped
+	Drop   *RPCTxDrop `json:"drop,omitempty"`
+}
+
+// Status returns the number of pending and queued transaction in the pool. If
+// a transaction hash is given, it returns the status of that transaction instead,
+// along with the reason it was dropped, if it was.
+func (s *PublicTxPoolAPI) Status(hash *common.Hash) interface{} {
+	if hash == nil {
+		pending, queue := s.b.Stats()
+		return map[string]hexutil.Uint{
+			"pending": hexutil.Uint(pending),
+			"queued":  hexutil.Uint(queue),
+		}
+	}
+	status, drop := s.b.TxPoolStatus(*hash)
+	switch {
+	case status == core.TxStatusPending:
+		return &RPCTxPoolStatus{Status: "pending"}
+	case status == core.TxStatusQueued:
+		return &RPCTxPoolStatus{Status: "queued"}
+	case drop != nil:
+		return &RPCTxPoolStatus{Status: "dropped", Drop: newRPCTxDrop(drop)}
+	default:
+		return &RPCTxPoolStatus{Status: "unknown"}
 	}
 }
 
+// Drops creates a subscription that fires each time transactions are rejected
+// or evicted by the pool.
+func (s *PublicTxPoolAPI) Drops(ctx context.Context) (*rpc.Subscription, error) {
+	notifier, supported := rpc.NotifierFromContext(ctx)
+	if !supported {
+		return &rpc.Subscription{}, rpc.ErrNotificationsUnsupported
+	}
+	rpcSub := notifier.CreateSubscription()
+
+	go func() {
+		drops := make(chan core.DropTxsEvent, 128)
+		dropsSub := s.b.SubscribeDropTxsEvent(drops)
+		defer dropsSub.Unsubscribe()
+
+		for {
+			select {
+			case ev := <-drops:
+				for _, drop := range ev.Drops {
+					notifier.Notify(rpcSub.ID, newRPCTxDrop(drop))
+				}
+			case <-rpcSub.Err():
+				return
+			case <-notifier.Closed():
+				return
+			}
+		}
+	}()
+	return rpcSub, nil
+}
+
 // Inspect retrieves the content of the transaction pool and flattens it into an
 // easily inspectable list.
 func (s *PublicTxPoolAPI) Inspect() map[string]map[string]map[string]string {
@@ -927,6 +998,13 @@ func DoCall(ctx context.Context, b Backend, args TransactionArgs, blockNrOrHash
 		evm.Cancel()
 	}()
 
+	// Make sure the sender is permitted to send the message, unless it's an
+	// anonymous call only reading the state.
+	if args.From != nil {
+		if err := core.CheckAccountPermissions(evm, msg.From(), msg.To() == nil); err != nil {
+			return nil, err
+		}
+	}
 	// Execute the message.
 	gp := new(core.GasPool).AddGas(math.MaxUint64)
 	result, err := core.ApplyMessage(evm, msg, gp)
@@ -999,10 +1077,6 @@ func DoEstimateGas(ctx context.Context, b Backend, args TransactionArgs, blockNr
 		hi  uint64
 		cap uint64
 	)
-	// Use zero address if sender unspecified.
-	if args.From == nil {
-		args.From = new(common.Address)
-	}
 	// Determine the highest gas limit can be used during the estimation.
 	if args.Gas != nil && uint64(*args.Gas) >= params.TxGas {
 		hi = uint64(*args.Gas)
@@ -1034,7 +1108,7 @@ func DoEstimateGas(ctx context.Context, b Backend, args TransactionArgs, blockNr
 		if err != nil {
 			return 0, err
 		}
-		balance := state.GetBalance(*args.From) // from can't be nil
+		balance := state.GetBalance(args.from()) // zero address if sender unspecified
 		available := new(big.Int).Set(balance)
 		if args.Value != nil {
 			if args.Value.ToInt().Cmp(available) >= 0 {
@@ -1229,6 +1303,21 @@ type RPCTransaction struct {
 	V                *hexutil.Big      `json:"v"`
 	R                *hexutil.Big      `json:"r"`
 	S                *hexutil.Big      `json:"s"`
+	PriorityPubkey   *common.PublicKey `json:"priorityPubkey,omitempty"`
+	PriorityV        *hexutil.Big      `json:"priorityV,omitempty"`
+	PriorityR        *hexutil.Big      `json:"priorityR,omitempty"`
+	PriorityS        *hexutil.Big      `json:"priorityS,omitempty"`
+
+	PriorityPubkeys    []common.PublicKey      `json:"priorityPubkeys,omitempty"`
+	PrioritySignatures []*RPCPrioritySignature `json:"prioritySignatures,omitempty"`
+}
+
+// RPCPrioritySignature is the signature of a priority key co-signing a
+// multi-priority transaction, as returned over RPC.
+type RPCPrioritySignature struct {
+	V *hexutil.Big `json:"v"`
+	R *hexutil.Big `json:"r"`
+	S *hexutil.Big `json:"s"`
 }
 
 // newRPCTransaction returns a transaction that will serialize to the RPC
@@ -1275,12 +1364,30 @@ func newRPCTransaction(tx *types.Transaction, blockHash common.Hash, blockNumber
 		} else {
 			result.GasPrice = (*hexutil.Big)(tx.GasFeeCap())
 		}
-	case types.PriorityTxType:
+	case types.PriorityTxType, types.MultiPriorityTxType:
 		al := tx.AccessList()
 		result.Accesses = &al
 		result.ChainID = (*hexutil.Big)(tx.ChainId())
 		result.GasFeeCap = (*hexutil.Big)(tx.GasFeeCap())
 		result.GasTipCap = (*hexutil.Big)(tx.GasTipCap())
+		if pubkey, err := types.PrioritySender(signer, tx); err == nil {
+			result.PriorityPubkey = &pubkey
+		}
+		if tx.Type() == types.PriorityTxType {
+			pv, pr, ps := tx.RawPrioritySignatureValues()
+			result.PriorityV, result.PriorityR, result.PriorityS = (*hexutil.Big)(pv), (*hexutil.Big)(pr), (*hexutil.Big)(ps)
+		} else {
+			if pubkeys, err := types.PrioritySenders(signer, tx); err == nil {
+				result.PriorityPubkeys = pubkeys
+			}
+			for _, sig := range tx.RawPrioritySignatures() {
+				result.PrioritySignatures = append(result.PrioritySignatures, &RPCPrioritySignature{
+					V: (*hexutil.Big)(sig.V),
+					R: (*hexutil.Big)(sig.R),
+					S: (*hexutil.Big)(sig.S),
+				})
+			}
+		}
 		// if the transaction has been mined, compute the effective gas price
 		if baseFee != nil && blockHash != (common.Hash{}) {
 			// price = min(gasTipCap + baseFee, gasFeeCap)
@@ -1594,7 +1701,7 @@ func (s *PublicTransactionPoolAPI) GetTransactionReceipt(ctx context.Context, ha
 	// Assign the effective gas price paid
 	if !s.b.ChainConfig().IsLondon(bigblock) {
 		fields["effectiveGasPrice"] = hexutil.Uint64(tx.GasPrice().Uint64())
-	} else if tx.Type() == types.PriorityTxType && tx.HasZeroFee() { // receipt generated only once tx is mined despite being a 'pool' api
+	} else if tx.IsPriority() && tx.HasZeroFee() { // receipt generated only once tx is mined despite being a 'pool' api
 		fields["effectiveGasPrice"] = hexutil.Uint64(0)
 	} else {
 		header, err := s.b.HeaderByHash(ctx, blockHash)
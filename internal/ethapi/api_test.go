@@ -0,0 +1,112 @@
+// Copyright 2023 Electroneum Ltd
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package ethapi
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/electroneum/electroneum-sc/common"
+	"github.com/electroneum/electroneum-sc/common/hexutil"
+	"github.com/electroneum/electroneum-sc/core"
+	"github.com/electroneum/electroneum-sc/core/rawdb"
+	"github.com/electroneum/electroneum-sc/core/state"
+	"github.com/electroneum/electroneum-sc/core/types"
+	"github.com/electroneum/electroneum-sc/core/vm"
+	"github.com/electroneum/electroneum-sc/params"
+	"github.com/electroneum/electroneum-sc/rpc"
+)
+
+// callBackend is a backend executing calls on top of a fixed state.
+type callBackend struct {
+	Backend
+	config *params.ChainConfig
+	header *types.Header
+	state  *state.StateDB
+}
+
+func (b *callBackend) StateAndHeaderByNumberOrHash(ctx context.Context, blockNrOrHash rpc.BlockNumberOrHash) (*state.StateDB, *types.Header, error) {
+	return b.state.Copy(), b.header, nil
+}
+
+func (b *callBackend) BlockByNumberOrHash(ctx context.Context, blockNrOrHash rpc.BlockNumberOrHash) (*types.Block, error) {
+	return types.NewBlockWithHeader(b.header), nil
+}
+
+func (b *callBackend) GetEVM(ctx context.Context, msg core.Message, state *state.StateDB, header *types.Header, vmConfig *vm.Config) (*vm.EVM, func() error, error) {
+	context := core.NewEVMBlockContext(header, nil, &header.Coinbase)
+	return vm.NewEVM(context, core.NewEVMTxContext(msg), state, b.config, *vmConfig), state.Error, nil
+}
+
+// Tests that calls and gas estimations without a sender skip the account
+// permissions check, while the ones from a sender not permitted to transact fail
+// the same way.
+func TestCallAccountPermissions(t *testing.T) {
+	var (
+		permissions = common.HexToAddress("0x0000000000000000000000000000000000001002")
+		banned      = common.Address{}
+		to          = common.HexToAddress("0xaa")
+	)
+	config := *params.TestChainConfig
+	config.Transitions = []params.Transition{{Block: common.Big0, AccountPermissionsContractAddress: permissions}}
+
+	// The contract allows every account but the zero address to transact:
+	//   canTransact = calldata[4:36] != banned, canDeploy = false
+	code := append(append([]byte{byte(vm.PUSH1), 0x04, byte(vm.CALLDATALOAD), byte(vm.PUSH20)}, banned.Bytes()...),
+		byte(vm.EQ), byte(vm.ISZERO), byte(vm.PUSH1), 0x00, byte(vm.MSTORE),
+		byte(vm.PUSH1), 0x00, byte(vm.PUSH1), 0x20, byte(vm.MSTORE),
+		byte(vm.PUSH1), 0x40, byte(vm.PUSH1), 0x00, byte(vm.RETURN),
+	)
+	statedb, _ := state.New(common.Hash{}, state.NewDatabase(rawdb.NewMemoryDatabase()), nil)
+	statedb.SetCode(permissions, code)
+
+	backend := &callBackend{
+		config: &config,
+		header: &types.Header{Number: big.NewInt(1), GasLimit: 1000000, BaseFee: big.NewInt(params.InitialBaseFee), Difficulty: common.Big1},
+		state:  statedb,
+	}
+	latest := rpc.BlockNumberOrHashWithNumber(rpc.LatestBlockNumber)
+
+	// Anonymous calls and estimations are not checked
+	anonymous := TransactionArgs{To: &to}
+	if _, err := DoCall(context.Background(), backend, anonymous, latest, nil, 0, 0); err != nil {
+		t.Fatalf("anonymous call failed: %v", err)
+	}
+	gas, err := DoEstimateGas(context.Background(), backend, anonymous, latest, 0)
+	if err != nil {
+		t.Fatalf("anonymous estimation failed: %v", err)
+	}
+	if uint64(gas) != params.TxGas {
+		t.Errorf("estimated gas mismatch: have %d, want %d", gas, params.TxGas)
+	}
+	// An explicit sender is checked by both
+	sender := TransactionArgs{From: &banned, To: &to}
+	if _, err := DoCall(context.Background(), backend, sender, latest, nil, 0, 0); !errors.Is(err, core.ErrAccountNotPermitted) {
+		t.Errorf("unpermitted call error mismatch: have %v, want %v", err, core.ErrAccountNotPermitted)
+	}
+	if _, err := DoEstimateGas(context.Background(), backend, sender, latest, 0); !errors.Is(err, core.ErrAccountNotPermitted) {
+		t.Errorf("unpermitted estimation error mismatch: have %v, want %v", err, core.ErrAccountNotPermitted)
+	}
+	// The sender's balance still caps the estimation
+	sender.From = &to
+	sender.MaxFeePerGas = (*hexutil.Big)(big.NewInt(params.InitialBaseFee))
+	if _, err := DoEstimateGas(context.Background(), backend, sender, latest, 0); err == nil {
+		t.Errorf("estimation without funds succeeded")
+	}
+}
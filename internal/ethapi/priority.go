@@ -0,0 +1,159 @@
+// Copyright 2023 Electroneum Ltd
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package ethapi
+
+import (
+	"bytes"
+	"context"
+	"sort"
+
+	"github.com/electroneum/electroneum-sc/common"
+	"github.com/electroneum/electroneum-sc/common/hexutil"
+	"github.com/electroneum/electroneum-sc/core"
+	"github.com/electroneum/electroneum-sc/log"
+	"github.com/electroneum/electroneum-sc/rpc"
+)
+
+// RPCPriorityTransactor is a priority transactor as returned over RPC.
+type RPCPriorityTransactor struct {
+	PublicKey        common.PublicKey `json:"publicKey"`
+	Name             string           `json:"name"`
+	IsGasPriceWaiver bool             `json:"isGasPriceWaiver"`
+}
+
+// PriorityTransactorsChange is the difference between the priority transactors
+// of a block and the ones of the previously notified block.
+type PriorityTransactorsChange struct {
+	Number  hexutil.Uint64           `json:"number"`
+	Hash    common.Hash              `json:"hash"`
+	Added   []*RPCPriorityTransactor `json:"added"`
+	Removed []common.PublicKey       `json:"removed"`
+	Updated []*RPCPriorityTransactor `json:"updated"` // Transactors whose name or waiver flag changed
+}
+
+// newRPCPriorityTransactors returns the transactors of the map, sorted by public key.
+func newRPCPriorityTransactors(transactors common.PriorityTransactorMap) []*RPCPriorityTransactor {
+	result := make([]*RPCPriorityTransactor, 0, len(transactors))
+	for pubkey, transactor := range transactors {
+		result = append(result, &RPCPriorityTransactor{
+			PublicKey:        pubkey,
+			Name:             transactor.EntityName,
+			IsGasPriceWaiver: transactor.IsGasPriceWaiver,
+		})
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return bytes.Compare(result[i].PublicKey[:], result[j].PublicKey[:]) < 0
+	})
+	return result
+}
+
+// diffPriorityTransactors returns the change from the prev transactors to next,
+// or nil if the transactors visible over RPC didn't change.
+func diffPriorityTransactors(prev, next common.PriorityTransactorMap) *PriorityTransactorsChange {
+	change := &PriorityTransactorsChange{
+		Added:   []*RPCPriorityTransactor{},
+		Removed: []common.PublicKey{},
+		Updated: []*RPCPriorityTransactor{},
+	}
+	for _, transactor := range newRPCPriorityTransactors(next) {
+		old, ok := prev[transactor.PublicKey]
+		switch {
+		case !ok:
+			change.Added = append(change.Added, transactor)
+		case old.EntityName != transactor.Name || old.IsGasPriceWaiver != transactor.IsGasPriceWaiver:
+			change.Updated = append(change.Updated, transactor)
+		}
+	}
+	for _, transactor := range newRPCPriorityTransactors(prev) {
+		if _, ok := next[transactor.PublicKey]; !ok {
+			change.Removed = append(change.Removed, transactor.PublicKey)
+		}
+	}
+	if len(change.Added) == 0 && len(change.Removed) == 0 && len(change.Updated) == 0 {
+		return nil
+	}
+	return change
+}
+
+// GetPriorityTransactors returns the priority transactors in the state of the
+// given block, which are the ones allowed to co-sign the transactions of the
+// block after it.
+func (s *PublicBlockChainAPI) GetPriorityTransactors(ctx context.Context, blockNrOrHash rpc.BlockNumberOrHash) ([]*RPCPriorityTransactor, error) {
+	transactors, err := s.priorityTransactorsAt(ctx, blockNrOrHash)
+	if transactors == nil || err != nil {
+		return nil, err
+	}
+	return newRPCPriorityTransactors(transactors), nil
+}
+
+// PriorityTransactors creates a subscription that fires each time a new head
+// block changes the priority transactors, e.g. when a key is revoked.
+func (s *PublicBlockChainAPI) PriorityTransactors(ctx context.Context) (*rpc.Subscription, error) {
+	notifier, supported := rpc.NotifierFromContext(ctx)
+	if !supported {
+		return &rpc.Subscription{}, rpc.ErrNotificationsUnsupported
+	}
+	rpcSub := notifier.CreateSubscription()
+
+	// Subscribe before reading the current transactors, so no change is missed
+	heads := make(chan core.ChainHeadEvent, 10)
+	headsSub := s.b.SubscribeChainHeadEvent(heads)
+
+	prev, err := s.priorityTransactorsAt(context.Background(), rpc.BlockNumberOrHashWithNumber(rpc.LatestBlockNumber))
+	if err != nil {
+		headsSub.Unsubscribe()
+		return nil, err
+	}
+	go func() {
+		defer headsSub.Unsubscribe()
+		for {
+			select {
+			case ev := <-heads:
+				hash := ev.Block.Hash()
+				next, err := s.priorityTransactorsAt(context.Background(), rpc.BlockNumberOrHashWithHash(hash, false))
+				if err != nil {
+					log.Warn("Failed to get priority transactors", "number", ev.Block.Number(), "hash", hash, "err", err)
+					continue
+				}
+				if change := diffPriorityTransactors(prev, next); change != nil {
+					change.Number, change.Hash = hexutil.Uint64(ev.Block.NumberU64()), hash
+					notifier.Notify(rpcSub.ID, change)
+				}
+				prev = next
+			case <-rpcSub.Err():
+				return
+			case <-notifier.Closed():
+				return
+			}
+		}
+	}()
+	return rpcSub, nil
+}
+
+// priorityTransactorsAt returns the priority transactors in the state of the
+// given block.
+func (s *PublicBlockChainAPI) priorityTransactorsAt(ctx context.Context, blockNrOrHash rpc.BlockNumberOrHash) (common.PriorityTransactorMap, error) {
+	state, header, err := s.b.StateAndHeaderByNumberOrHash(ctx, blockNrOrHash)
+	if state == nil || err != nil {
+		return nil, err
+	}
+	transactors, err := s.b.GetPriorityTransactors(ctx, state, header)
+	if err != nil {
+		return nil, err
+	}
+	return transactors, state.Error()
+}
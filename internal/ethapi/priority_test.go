@@ -0,0 +1,224 @@
+// Copyright 2023 Electroneum Ltd
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package ethapi
+
+import (
+	"context"
+	"math/big"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/electroneum/electroneum-sc/common"
+	"github.com/electroneum/electroneum-sc/core"
+	"github.com/electroneum/electroneum-sc/core/rawdb"
+	"github.com/electroneum/electroneum-sc/core/state"
+	"github.com/electroneum/electroneum-sc/core/types"
+	"github.com/electroneum/electroneum-sc/event"
+	"github.com/electroneum/electroneum-sc/rpc"
+)
+
+var (
+	priorityKeyA = common.PublicKey{0x04, 0xaa}
+	priorityKeyB = common.PublicKey{0x04, 0xbb}
+	priorityKeyC = common.PublicKey{0x04, 0xcc}
+)
+
+func TestDiffPriorityTransactors(t *testing.T) {
+	prev := common.PriorityTransactorMap{
+		priorityKeyA: {EntityName: "a"},
+		priorityKeyB: {EntityName: "b", IsGasPriceWaiver: true},
+	}
+	tests := []struct {
+		next    common.PriorityTransactorMap
+		added   []common.PublicKey
+		removed []common.PublicKey
+		updated []common.PublicKey
+	}{
+		// Unchanged transactors, quota changes aren't visible over RPC
+		{
+			next: common.PriorityTransactorMap{
+				priorityKeyA: {EntityName: "a", MaxPendingTxs: 1},
+				priorityKeyB: {EntityName: "b", IsGasPriceWaiver: true},
+			},
+		},
+		// Added key
+		{
+			next: common.PriorityTransactorMap{
+				priorityKeyA: {EntityName: "a"},
+				priorityKeyB: {EntityName: "b", IsGasPriceWaiver: true},
+				priorityKeyC: {EntityName: "c"},
+			},
+			added: []common.PublicKey{priorityKeyC},
+		},
+		// Removed key
+		{
+			next: common.PriorityTransactorMap{
+				priorityKeyB: {EntityName: "b", IsGasPriceWaiver: true},
+			},
+			removed: []common.PublicKey{priorityKeyA},
+		},
+		// Waiver flag and name changes
+		{
+			next: common.PriorityTransactorMap{
+				priorityKeyA: {EntityName: "a", IsGasPriceWaiver: true},
+				priorityKeyB: {EntityName: "renamed", IsGasPriceWaiver: true},
+			},
+			updated: []common.PublicKey{priorityKeyA, priorityKeyB},
+		},
+		// All at once
+		{
+			next: common.PriorityTransactorMap{
+				priorityKeyB: {EntityName: "b"},
+				priorityKeyC: {EntityName: "c"},
+			},
+			added:   []common.PublicKey{priorityKeyC},
+			removed: []common.PublicKey{priorityKeyA},
+			updated: []common.PublicKey{priorityKeyB},
+		},
+	}
+	keys := func(transactors []*RPCPriorityTransactor) []common.PublicKey {
+		var result []common.PublicKey
+		for _, transactor := range transactors {
+			result = append(result, transactor.PublicKey)
+		}
+		return result
+	}
+	for i, tt := range tests {
+		change := diffPriorityTransactors(prev, tt.next)
+		if tt.added == nil && tt.removed == nil && tt.updated == nil {
+			if change != nil {
+				t.Errorf("test %d: unexpected change: %+v", i, change)
+			}
+			continue
+		}
+		if change == nil {
+			t.Errorf("test %d: missing change", i)
+			continue
+		}
+		if have := keys(change.Added); !reflect.DeepEqual(have, tt.added) {
+			t.Errorf("test %d: added mismatch: have %x, want %x", i, have, tt.added)
+		}
+		if len(change.Removed) > 0 || tt.removed != nil {
+			if !reflect.DeepEqual(change.Removed, tt.removed) {
+				t.Errorf("test %d: removed mismatch: have %x, want %x", i, change.Removed, tt.removed)
+			}
+		}
+		if have := keys(change.Updated); !reflect.DeepEqual(have, tt.updated) {
+			t.Errorf("test %d: updated mismatch: have %x, want %x", i, have, tt.updated)
+		}
+		for _, transactor := range change.Updated {
+			want := tt.next[transactor.PublicKey]
+			if transactor.Name != want.EntityName || transactor.IsGasPriceWaiver != want.IsGasPriceWaiver {
+				t.Errorf("test %d: updated transactor %x mismatch: have %+v, want %+v", i, transactor.PublicKey, transactor, want)
+			}
+		}
+	}
+}
+
+// priorityBackend is a backend serving the priority transactors of a fixed set
+// of blocks, and the chain head events fed to it.
+type priorityBackend struct {
+	Backend
+	head        *types.Header
+	headers     map[common.Hash]*types.Header
+	transactors map[common.Hash]common.PriorityTransactorMap
+	heads       event.Feed
+}
+
+func (b *priorityBackend) StateAndHeaderByNumberOrHash(ctx context.Context, blockNrOrHash rpc.BlockNumberOrHash) (*state.StateDB, *types.Header, error) {
+	header := b.head
+	if hash, ok := blockNrOrHash.Hash(); ok {
+		header = b.headers[hash]
+	}
+	statedb, _ := state.New(common.Hash{}, state.NewDatabase(rawdb.NewMemoryDatabase()), nil)
+	return statedb, header, nil
+}
+
+func (b *priorityBackend) GetPriorityTransactors(ctx context.Context, state *state.StateDB, header *types.Header) (common.PriorityTransactorMap, error) {
+	return b.transactors[header.Hash()], nil
+}
+
+func (b *priorityBackend) SubscribeChainHeadEvent(ch chan<- core.ChainHeadEvent) event.Subscription {
+	return b.heads.Subscribe(ch)
+}
+
+// Tests that the priority transactors subscription only fires for the heads that
+// change the transactors.
+func TestPriorityTransactorsSubscription(t *testing.T) {
+	var (
+		backend = &priorityBackend{
+			headers:     make(map[common.Hash]*types.Header),
+			transactors: make(map[common.Hash]common.PriorityTransactorMap),
+		}
+		blocks      []*types.Block
+		transactors = []common.PriorityTransactorMap{
+			{priorityKeyA: {EntityName: "a"}},
+			{priorityKeyA: {EntityName: "a"}},
+			{priorityKeyA: {EntityName: "a", IsGasPriceWaiver: true}, priorityKeyB: {EntityName: "b"}},
+			{priorityKeyA: {EntityName: "a", IsGasPriceWaiver: true}, priorityKeyB: {EntityName: "b"}},
+			{priorityKeyB: {EntityName: "b"}},
+		}
+	)
+	for i, set := range transactors {
+		header := &types.Header{Number: big.NewInt(int64(i))}
+		if i > 0 {
+			header.ParentHash = blocks[i-1].Hash()
+		}
+		blocks = append(blocks, types.NewBlockWithHeader(header))
+		backend.headers[header.Hash()] = header
+		backend.transactors[header.Hash()] = set
+	}
+	backend.head = blocks[0].Header()
+
+	server := rpc.NewServer()
+	defer server.Stop()
+	if err := server.RegisterName("eth", NewPublicBlockChainAPI(backend)); err != nil {
+		t.Fatalf("failed to register api: %v", err)
+	}
+	client := rpc.DialInProc(server)
+	defer client.Close()
+
+	changes := make(chan *PriorityTransactorsChange, len(blocks))
+	sub, err := client.EthSubscribe(context.Background(), changes, "priorityTransactors")
+	if err != nil {
+		t.Fatalf("failed to subscribe: %v", err)
+	}
+	defer sub.Unsubscribe()
+
+	for _, block := range blocks[1:] {
+		backend.heads.Send(core.ChainHeadEvent{Block: block})
+	}
+	// Only the heads changing the transactors are notified
+	for _, want := range []uint64{2, 4} {
+		select {
+		case change := <-changes:
+			if uint64(change.Number) != want || change.Hash != blocks[want].Hash() {
+				t.Fatalf("change block mismatch: have %d, want %d", change.Number, want)
+			}
+		case err := <-sub.Err():
+			t.Fatalf("subscription failed: %v", err)
+		case <-time.After(time.Second):
+			t.Fatalf("change of block %d not notified", want)
+		}
+	}
+	select {
+	case change := <-changes:
+		t.Fatalf("unexpected change of block %d", change.Number)
+	case <-time.After(100 * time.Millisecond):
+	}
+}
@@ -191,6 +191,11 @@ func (b *LesApiBackend) GetEVM(ctx context.Context, msg core.Message, state *sta
 	return vm.NewEVM(context, txContext, state, b.eth.chainConfig, *vmConfig), state.Error, nil
 }
 
+func (b *LesApiBackend) GetPriorityTransactors(ctx context.Context, state *state.StateDB, header *types.Header) (common.PriorityTransactorMap, error) {
+	context := core.NewEVMBlockContext(header, b.eth.blockchain, nil)
+	return core.GetPriorityTransactors(vm.NewEVM(context, vm.TxContext{}, state, b.eth.chainConfig, vm.Config{}))
+}
+
 func (b *LesApiBackend) SendTx(ctx context.Context, signedTx *types.Transaction) error {
 	return b.eth.txPool.Add(ctx, signedTx)
 }
@@ -227,10 +232,26 @@ func (b *LesApiBackend) TxPoolContentFrom(addr common.Address) (types.Transactio
 	return b.eth.txPool.ContentFrom(addr)
 }
 
+// TxPoolStatus reports the transactions of the light pool as pending, as it
+// doesn't queue them, nor keep track of the ones dropped by the servers.
+func (b *LesApiBackend) TxPoolStatus(txHash common.Hash) (core.TxStatus, *core.TxDrop) {
+	if b.eth.txPool.GetTransaction(txHash) != nil {
+		return core.TxStatusPending, nil
+	}
+	return core.TxStatusUnknown, nil
+}
+
 func (b *LesApiBackend) SubscribeNewTxsEvent(ch chan<- core.NewTxsEvent) event.Subscription {
 	return b.eth.txPool.SubscribeNewTxsEvent(ch)
 }
 
+func (b *LesApiBackend) SubscribeDropTxsEvent(ch chan<- core.DropTxsEvent) event.Subscription {
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		<-quit
+		return nil
+	})
+}
+
 func (b *LesApiBackend) SubscribeChainEvent(ch chan<- core.ChainEvent) event.Subscription {
 	return b.eth.blockchain.SubscribeChainEvent(ch)
 }
@@ -54,7 +54,7 @@ func (db *odrDatabase) OpenTrie(root common.Hash) (state.Trie, error) {
 	return &odrTrie{db: db, id: db.id}, nil
 }
 
-func (db *odrDatabase) OpenStorageTrie(addrHash, root common.Hash) (state.Trie, error) {
+func (db *odrDatabase) OpenStorageTrie(stateRoot, addrHash, root common.Hash) (state.Trie, error) {
 	return &odrTrie{db: db, id: StorageTrieID(db.id, addrHash, root)}, nil
 }
 
@@ -144,6 +144,13 @@ func (t *odrTrie) Commit(onleaf trie.LeafCallback) (common.Hash, int, error) {
 	return t.trie.Commit(onleaf)
 }
 
+func (t *odrTrie) CommitNodes() (common.Hash, *trie.NodeSet, error) {
+	if t.trie == nil {
+		return t.id.Root, nil, nil
+	}
+	return t.trie.CommitNodes()
+}
+
 func (t *odrTrie) Hash() common.Hash {
 	if t.trie == nil {
 		return t.id.Root
@@ -55,6 +55,8 @@ type Config struct {
 	Recommit              time.Duration  // The time interval for miner to re-create mining work.
 	Noverify              bool           // Disable remote mining solution verification(only useful in ethash).
 	PrioritiseElectroneum bool           // Prioritise Electroneum Ltd transactions when filling blocks?
+	PriorityFairOrdering  bool           // Order priority transactions in turns across transactor entities, in reserved block space
+	PriorityGasReserve    uint64         // Percentage of the block gas limit reserved for priority transactions when fairly ordered
 }
 
 // Miner creates blocks and searches for proof-of-work values.
@@ -19,6 +19,7 @@ package miner
 
 import (
 	"errors"
+	"math/big"
 	"testing"
 	"time"
 
@@ -29,9 +30,11 @@ import (
 	"github.com/electroneum/electroneum-sc/core/state"
 	"github.com/electroneum/electroneum-sc/core/types"
 	"github.com/electroneum/electroneum-sc/core/vm"
+	"github.com/electroneum/electroneum-sc/crypto"
 	"github.com/electroneum/electroneum-sc/eth/downloader"
 	"github.com/electroneum/electroneum-sc/ethdb/memorydb"
 	"github.com/electroneum/electroneum-sc/event"
+	"github.com/electroneum/electroneum-sc/params"
 	"github.com/electroneum/electroneum-sc/trie"
 )
 
@@ -83,6 +86,10 @@ func (bc *testBlockChain) SubscribeChainHeadEvent(ch chan<- core.ChainHeadEvent)
 	return bc.chainHeadFeed.Subscribe(ch)
 }
 
+func (bc *testBlockChain) GetReceiptsByHash(hash common.Hash) types.Receipts {
+	return nil
+}
+
 func (bc *testBlockChain) GetPriorityTransactorsCache() common.PriorityTransactorMap {
 	return common.PriorityTransactorMap{}
 }
@@ -92,6 +99,11 @@ func (bc *testBlockChain) MustGetPriorityTransactorsForState(header *types.Heade
 	return common.PriorityTransactorMap{}
 }
 
+// GetAccountPermissionsForState permits every account to send any transaction
+func (bc *testBlockChain) GetAccountPermissionsForState(header *types.Header, state *state.StateDB, account common.Address) (core.AccountPermissions, error) {
+	return core.AccountPermissions{CanTransact: true, CanDeploy: true}, nil
+}
+
 func TestMiner(t *testing.T) {
 	miner, mux, cleanup := createMiner(t)
 	defer cleanup(false)
@@ -257,7 +269,14 @@ func createMiner(t *testing.T) (*Miner, *event.TypeMux, func(skipMiner bool)) {
 	// Create chainConfig
 	memdb := memorydb.New()
 	chainDB := rawdb.NewDatabase(memdb)
-	genesis := core.DeveloperGenesisBlock(15, 11_500_000, common.HexToAddress("12345"))
+	genesis := &core.Genesis{
+		Config:     params.AllCliqueProtocolChanges,
+		ExtraData:  append(append(make([]byte, 32), config.Etherbase[:]...), make([]byte, crypto.SignatureLength)...),
+		GasLimit:   11_500_000,
+		BaseFee:    big.NewInt(params.InitialBaseFee),
+		Difficulty: big.NewInt(1),
+		Alloc:      core.GenesisAlloc{common.HexToAddress("12345"): {Balance: big.NewInt(1)}},
+	}
 	chainConfig, _, err := core.SetupGenesisBlock(chainDB, genesis)
 	if err != nil {
 		t.Fatalf("can't create new chain config: %v", err)
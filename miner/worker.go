@@ -34,6 +34,7 @@ import (
 	"github.com/electroneum/electroneum-sc/core/types"
 	"github.com/electroneum/electroneum-sc/event"
 	"github.com/electroneum/electroneum-sc/log"
+	"github.com/electroneum/electroneum-sc/metrics"
 	"github.com/electroneum/electroneum-sc/params"
 	"github.com/electroneum/electroneum-sc/trie"
 )
@@ -83,6 +84,12 @@ var (
 	errBlockInterruptedByRecommit = errors.New("recommit interrupt while building block")
 )
 
+var (
+	// priorityQuotaMeter counts the priority transactions left out of blocks by the
+	// quotas of their transactor.
+	priorityQuotaMeter = metrics.NewRegisteredMeter("miner/priority/quota", nil)
+)
+
 // environment is the worker's current environment and holds all
 // information of the sealing block generation.
 type environment struct {
@@ -100,6 +107,9 @@ type environment struct {
 	txs      []*types.Transaction
 	receipts []*types.Receipt
 	uncles   map[common.Hash]*types.Header
+
+	priorityGas    map[common.PublicKey]uint64 // Gas used by each priority transactor in the block
+	priorityWaived map[common.PublicKey]uint64 // Gas waived for each priority transactor in the epoch, including the block
 }
 
 // copy creates a deep copy of environment.
@@ -127,6 +137,14 @@ func (env *environment) copy() *environment {
 	for hash, uncle := range env.uncles {
 		cpy.uncles[hash] = uncle
 	}
+	cpy.priorityGas = make(map[common.PublicKey]uint64, len(env.priorityGas))
+	for pubkey, gas := range env.priorityGas {
+		cpy.priorityGas[pubkey] = gas
+	}
+	cpy.priorityWaived = make(map[common.PublicKey]uint64, len(env.priorityWaived))
+	for pubkey, gas := range env.priorityWaived {
+		cpy.priorityWaived[pubkey] = gas
+	}
 	return cpy
 }
 
@@ -486,7 +504,7 @@ func (w *worker) newWorkLoop(recommit time.Duration) {
 		case <-timer.C:
 			// If sealing is running resubmit a new work cycle periodically to pull in
 			// higher priced transactions. Disable this overhead for pending blocks.
-			if w.isRunning() && (w.chainConfig.Clique == nil || w.chainConfig.Clique.Period > 0) {
+			if w.isRunning() && !w.onDemandSealing() {
 				// Short circuit if no new transaction arrives.
 				if atomic.LoadInt32(&w.newTxs) == 0 {
 					timer.Reset(recommit)
@@ -625,10 +643,10 @@ func (w *worker) mainLoop() {
 					w.updateSnapshot(w.current)
 				}
 			} else {
-				// Special case, if the consensus engine is 0 period clique(dev mode),
-				// submit sealing work here since all empty submission will be rejected
-				// by clique. Of course the advance sealing(empty submission) is disabled.
-				if w.chainConfig.Clique != nil && w.chainConfig.Clique.Period == 0 {
+				// Special case, if the consensus engine is 0 period clique or IBFT (dev
+				// mode), submit sealing work here since no empty block is sealed. Of
+				// course the advance sealing(empty submission) is disabled.
+				if w.onDemandSealing() {
 					w.commitWork(nil, true, time.Now().Unix())
 				}
 			}
@@ -802,6 +820,13 @@ func (w *worker) makeEnv(parent *types.Block, header *types.Header, coinbase com
 		family:    mapset.NewSet(),
 		header:    header,
 		uncles:    make(map[common.Hash]*types.Header),
+
+		priorityGas:    make(map[common.PublicKey]uint64),
+		priorityWaived: make(map[common.PublicKey]uint64),
+	}
+	// The gas waived in the epoch carries over unless the block starts a new one
+	if core.PriorityQuotaEpoch(w.chainConfig, header.Number.Uint64()) == core.PriorityQuotaEpoch(w.chainConfig, parent.NumberU64()) {
+		env.priorityWaived = w.eth.TxPool().PriorityWaivedGas()
 	}
 	// when 08 is processed ancestors contain 07 (quick block)
 	for _, ancestor := range w.chain.GetBlocksFromHash(parent.Hash(), 7) {
@@ -868,7 +893,15 @@ func (w *worker) commitTransaction(env *environment, tx *types.Transaction) ([]*
 	return receipt.Logs, nil
 }
 
-func (w *worker) commitTransactions(env *environment, txs *types.TransactionsByPriceAndNonce, interrupt *int32) error {
+// orderedTransactions is a set of transactions returned in the order they are
+// committed to a block, such as types.TransactionsByPriceAndNonce.
+type orderedTransactions interface {
+	Peek() *types.Transaction
+	Shift()
+	Pop()
+}
+
+func (w *worker) commitTransactions(env *environment, txs orderedTransactions, interrupt *int32) error {
 	gasLimit := env.header.GasLimit
 	if env.gasPool == nil {
 		env.gasPool = new(core.GasPool).AddGas(gasLimit)
@@ -921,6 +954,13 @@ func (w *worker) commitTransactions(env *environment, txs *types.TransactionsByP
 			txs.Pop()
 			continue
 		}
+		// Leave out the priority transactions exceeding the quotas of their transactor
+		if !withinPriorityQuota(env, tx, transactors) {
+			log.Trace("Skipping account over priority transactor quota", "sender", from, "hash", tx.Hash())
+			priorityQuotaMeter.Mark(1)
+			txs.Pop()
+			continue
+		}
 		// Start executing the transaction
 		env.state.Prepare(tx.Hash(), env.tcount)
 		env.state.SetPriorityTransactors(transactors)
@@ -946,6 +986,7 @@ func (w *worker) commitTransactions(env *environment, txs *types.TransactionsByP
 			// Everything ok, collect the logs and shift in the next transaction from the same account
 			coalescedLogs = append(coalescedLogs, logs...)
 			env.tcount++
+			recordPriorityGas(env, tx, env.receipts[len(env.receipts)-1].GasUsed)
 			txs.Shift()
 
 		case errors.Is(err, core.ErrTxTypeNotSupported):
@@ -953,6 +994,11 @@ func (w *worker) commitTransactions(env *environment, txs *types.TransactionsByP
 			log.Trace("Skipping unsupported transaction type", "sender", from, "type", tx.Type())
 			txs.Pop()
 
+		case errors.Is(err, core.ErrAccountNotPermitted), errors.Is(err, core.ErrDeployNotPermitted):
+			// Pop the unpermitted transaction without shifting in the next from the account
+			log.Trace("Skipping unpermitted transaction", "sender", from, "hash", tx.Hash())
+			txs.Pop()
+
 		default:
 			// Strange error, discard the transaction and get the next in line (note, the
 			// nonce-too-high clause will prevent us from executing in vain).
@@ -984,6 +1030,42 @@ func (w *worker) commitTransactions(env *environment, txs *types.TransactionsByP
 	return nil
 }
 
+// withinPriorityQuota reports whether the transaction can be added to the block
+// without its priority transactor going over its quotas.
+func withinPriorityQuota(env *environment, tx *types.Transaction, transactors common.PriorityTransactorMap) bool {
+	if !tx.IsPriority() {
+		return true
+	}
+	pubkey, err := types.PrioritySender(env.signer, tx)
+	if err != nil {
+		return true // Rejected on execution
+	}
+	transactor := transactors[pubkey]
+	if transactor.MaxGasPerBlock != 0 && env.priorityGas[pubkey]+tx.Gas() > transactor.MaxGasPerBlock {
+		return false
+	}
+	if transactor.MaxWaivedGasPerEpoch != 0 && tx.HasZeroFee() && env.priorityWaived[pubkey]+tx.Gas() > transactor.MaxWaivedGasPerEpoch {
+		return false
+	}
+	return true
+}
+
+// recordPriorityGas accounts the gas used by an included transaction towards the
+// quotas of its priority transactor.
+func recordPriorityGas(env *environment, tx *types.Transaction, gasUsed uint64) {
+	if !tx.IsPriority() {
+		return
+	}
+	pubkey, err := types.PrioritySender(env.signer, tx)
+	if err != nil {
+		return
+	}
+	env.priorityGas[pubkey] += gasUsed
+	if tx.HasZeroFee() {
+		env.priorityWaived[pubkey] += gasUsed
+	}
+}
+
 // generateParams wraps various of settings for generating sealing task.
 type generateParams struct {
 	timestamp  uint64         // The timstamp for sealing task
@@ -1104,7 +1186,18 @@ func (w *worker) fillTransactions(interrupt *int32, env *environment) error {
 		}
 	}
 
-	if w.config.PrioritiseElectroneum {
+	if w.config.PriorityFairOrdering {
+		// If --miner.priorityfair flag is present, priority transactions fill the block space reserved for them
+		// in turns across their transactor entities, and the ones not fitting compete with the remote ones
+		if len(priorityTxs) > 0 && w.config.PriorityGasReserve > 0 {
+			if err := w.commitPriorityTransactions(env, priorityTxs, interrupt); err != nil {
+				return err
+			}
+		}
+		for account, list := range priorityTxs {
+			remoteTxs[account] = list
+		}
+	} else if w.config.PrioritiseElectroneum {
 		// By default the miner prioritises their own (local) transactions and then moves onto everyone else's (remote) tx
 		// if --miner.PrioritiseElectroneum flag is present, electroneumTxs take priority over both local & remote
 		if len(priorityTxs) > 0 {
@@ -1136,6 +1229,43 @@ func (w *worker) fillTransactions(interrupt *int32, env *environment) error {
 	return nil
 }
 
+// commitPriorityTransactions commits the priority transactions in turns across
+// their transactor entities, within the block space reserved for them. The
+// transactions left are the ones that didn't fit in the reserved space.
+func (w *worker) commitPriorityTransactions(env *environment, txs map[common.Address]types.Transactions, interrupt *int32) error {
+	reserve := w.config.PriorityGasReserve
+	if reserve > 100 {
+		reserve = 100
+	}
+	// Only let the priority transactions use the reserved gas, releasing the
+	// rest of the block once they are committed
+	reserved := env.header.GasLimit * reserve / 100
+	env.gasPool = new(core.GasPool).AddGas(reserved)
+
+	pending := make(map[common.Address]types.Transactions, len(txs))
+	for account, list := range txs {
+		pending[account] = list
+	}
+	transactors := w.chain.MustGetPriorityTransactorsForState(env.header, env.state)
+	err := w.commitTransactions(env, types.NewTransactionsByEntityAndNonce(env.signer, pending, transactors, env.header.BaseFee), interrupt)
+	env.gasPool.AddGas(env.header.GasLimit - reserved)
+	if err != nil {
+		return err
+	}
+	for account, list := range txs {
+		nonce := env.state.GetNonce(account)
+		for len(list) > 0 && list[0].Nonce() < nonce {
+			list = list[1:]
+		}
+		if len(list) == 0 {
+			delete(txs, account)
+		} else {
+			txs[account] = list
+		}
+	}
+	return nil
+}
+
 // generateWork generates a sealing block based on the given parameters.
 func (w *worker) generateWork(params *generateParams) (*types.Block, error) {
 	work, err := w.prepareWork(params)
@@ -1173,7 +1303,7 @@ func (w *worker) commitWork(interrupt *int32, noempty bool, timestamp int64) {
 	}
 	// Create an empty block based on temporary copied state for
 	// sealing in advance without waiting block execution finished.
-	if !noempty && atomic.LoadUint32(&w.noempty) == 0 {
+	if !noempty && atomic.LoadUint32(&w.noempty) == 0 && !w.onDemandSealing() {
 		w.commit(work.copy(), nil, false, start)
 	}
 
@@ -1183,7 +1313,12 @@ func (w *worker) commitWork(interrupt *int32, noempty bool, timestamp int64) {
 		work.discard()
 		return
 	}
-	w.commit(work.copy(), w.fullTaskHook, true, start)
+	// Blocks sealed on demand wait for transactions, only update the snapshot
+	if w.onDemandSealing() && work.tcount == 0 {
+		w.updateSnapshot(work)
+	} else {
+		w.commit(work.copy(), w.fullTaskHook, true, start)
+	}
 
 	// Swap out the old work with the new one, terminating any leftover
 	// prefetcher processes in the mean time and starting a new one.
@@ -1193,6 +1328,15 @@ func (w *worker) commitWork(interrupt *int32, noempty bool, timestamp int64) {
 	w.current = work
 }
 
+// onDemandSealing returns whether blocks are only sealed once transactions
+// arrive, which is the case of 0 period clique or IBFT chains (dev mode).
+func (w *worker) onDemandSealing() bool {
+	if w.chainConfig.Clique != nil {
+		return w.chainConfig.Clique.Period == 0
+	}
+	return w.chainConfig.IBFT != nil && w.chainConfig.IBFT.BlockPeriodSeconds == 0
+}
+
 // commit runs any post-transaction state modifications, assembles the final block
 // and commits new work if consensus engine is running.
 // Note the assumption is held that the mutation is allowed to the passed env, do
@@ -1290,7 +1434,7 @@ func totalFees(block *types.Block, receipts []*types.Receipt) *big.Float {
 	feesWei := new(big.Int)
 	for i, tx := range block.Transactions() {
 		var minerFee *big.Int
-		if tx.Type() == types.PriorityTxType && tx.HasZeroFee() {
+		if tx.IsPriority() && tx.HasZeroFee() {
 			minerFee, _ = tx.EffectiveGasTip(big.NewInt(0))
 		} else {
 			minerFee, _ = tx.EffectiveGasTip(block.BaseFee())
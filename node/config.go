@@ -203,6 +203,10 @@ type Config struct {
 
 	// JWTSecret is the hex-encoded jwt secret.
 	JWTSecret string `toml:",omitempty"`
+
+	// DBEngine is the database engine to use, either "leveldb" or "pebble". If
+	// empty, the engine of an existing database is used, or leveldb for a new one.
+	DBEngine string `toml:",omitempty"`
 }
 
 // IPCEndpoint resolves an IPC endpoint based on a configured value, taking into
@@ -0,0 +1,84 @@
+// Copyright 2023 Electroneum Ltd
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package p2p
+
+import (
+	"errors"
+	"net"
+
+	"github.com/electroneum/electroneum-sc/event"
+	"github.com/electroneum/electroneum-sc/p2p/enode"
+)
+
+// errNodeNotPermitted is returned for connections to nodes the permissions of
+// the server don't allow.
+var errNodeNotPermitted = errors.New("node not permitted")
+
+// PermissionRequest describes a node whose permission to connect is checked.
+type PermissionRequest struct {
+	Node       *enode.Node
+	RemoteAddr net.Addr
+	Inbound    bool
+	Connected  bool // Whether the node is a peer checked again after a permissions change
+}
+
+// NodePermissions restricts the nodes allowed to connect to the server, e.g. in
+// private and consortium networks. Nodes are checked once their identity is
+// known by the encryption handshake, and connected peers are checked again, and
+// dropped if no longer allowed, each time the permissions change.
+type NodePermissions interface {
+	// Allowed reports whether the node of the request may be connected.
+	Allowed(req *PermissionRequest) bool
+
+	// SubscribeChanges notifies the given channel each time the allowed nodes
+	// change.
+	SubscribeChanges(ch chan<- struct{}) event.Subscription
+}
+
+// checkPermissions returns an error if the permissions of the server don't
+// allow the node of a connection past the encryption handshake.
+func (srv *Server) checkPermissions(c *conn) error {
+	if srv.Permissions == nil {
+		return nil
+	}
+	req := &PermissionRequest{
+		Node:       c.node,
+		RemoteAddr: c.fd.RemoteAddr(),
+		Inbound:    c.is(inboundConn),
+	}
+	if !srv.Permissions.Allowed(req) {
+		return errNodeNotPermitted
+	}
+	return nil
+}
+
+// dropUnpermittedPeers disconnects the peers no longer allowed by the
+// permissions of the server.
+func (srv *Server) dropUnpermittedPeers(peers map[enode.ID]*Peer) {
+	for _, p := range peers {
+		req := &PermissionRequest{
+			Node:       p.Node(),
+			RemoteAddr: p.RemoteAddr(),
+			Inbound:    p.Inbound(),
+			Connected:  true,
+		}
+		if !srv.Permissions.Allowed(req) {
+			srv.log.Debug("Dropping unpermitted p2p peer", "id", p.ID(), "addr", p.RemoteAddr())
+			p.Disconnect(DiscRequested)
+		}
+	}
+}
@@ -119,6 +119,11 @@ type Config struct {
 	// IP networks contained in the list are considered.
 	NetRestrict *netutil.Netlist `toml:",omitempty"`
 
+	// Permissions restricts the nodes allowed to connect, on top of NetRestrict.
+	// If set, connections to nodes it doesn't allow are rejected after the
+	// encryption handshake, and peers are dropped once no longer allowed.
+	Permissions NodePermissions `toml:"-"`
+
 	// NodeDatabase is the path to the database containing the previously seen
 	// live nodes in the network.
 	NodeDatabase string `toml:",omitempty"`
@@ -708,6 +713,12 @@ func (srv *Server) run() {
 	for _, n := range srv.TrustedNodes {
 		trusted[n.ID()] = true
 	}
+	// Track the permission changes to drop the peers no longer allowed.
+	permissionsCh := make(chan struct{}, 1)
+	if srv.Permissions != nil {
+		sub := srv.Permissions.SubscribeChanges(permissionsCh)
+		defer sub.Unsubscribe()
+	}
 
 running:
 	for {
@@ -747,7 +758,15 @@ running:
 				c.flags |= trustedConn
 			}
 			// TODO: track in-progress inbound node IDs (pre-Peer) to avoid dialing them.
-			c.cont <- srv.postHandshakeChecks(peers, inboundCount, c)
+			err := srv.postHandshakeChecks(peers, inboundCount, c)
+			if err == nil {
+				err = srv.checkPermissions(c)
+			}
+			c.cont <- err
+
+		case <-permissionsCh:
+			// The allowed nodes changed, drop the peers no longer allowed.
+			srv.dropUnpermittedPeers(peers)
 
 		case c := <-srv.checkpointAddPeer:
 			// At this point the connection is past the protocol handshake.
@@ -24,10 +24,12 @@ import (
 	"math/rand"
 	"net"
 	"reflect"
+	"sync"
 	"testing"
 	"time"
 
 	"github.com/electroneum/electroneum-sc/crypto"
+	"github.com/electroneum/electroneum-sc/event"
 	"github.com/electroneum/electroneum-sc/internal/testlog"
 	"github.com/electroneum/electroneum-sc/log"
 	"github.com/electroneum/electroneum-sc/p2p/enode"
@@ -300,6 +302,98 @@ func TestServerAtCap(t *testing.T) {
 	}
 }
 
+// testPermissions allows the nodes of a set, which can be changed at any time.
+type testPermissions struct {
+	lock     sync.Mutex
+	allowed  map[enode.ID]bool
+	rechecks int // Number of checks of connected peers
+	feed     event.Feed
+}
+
+func (p *testPermissions) Allowed(req *PermissionRequest) bool {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	if req.Connected {
+		p.rechecks++
+	}
+	return p.allowed[req.Node.ID()]
+}
+
+func (p *testPermissions) SubscribeChanges(ch chan<- struct{}) event.Subscription {
+	return p.feed.Subscribe(ch)
+}
+
+func (p *testPermissions) set(id enode.ID, allowed bool) {
+	p.lock.Lock()
+	p.allowed[id] = allowed
+	p.lock.Unlock()
+	p.feed.Send(struct{}{})
+}
+
+// This test checks that connections to nodes not allowed by the permissions are
+// rejected just after the encryption handshake, and that peers are dropped once
+// no longer allowed.
+func TestServerPermissions(t *testing.T) {
+	permissions := &testPermissions{allowed: make(map[enode.ID]bool)}
+	srv1 := &Server{Config: Config{
+		PrivateKey:  newkey(),
+		MaxPeers:    10,
+		NoDiscovery: true,
+		Permissions: permissions,
+		Logger:      testlog.Logger(t, log.LvlTrace).New("server", "1"),
+	}}
+	srv2 := &Server{Config: Config{
+		PrivateKey:  newkey(),
+		MaxPeers:    10,
+		NoDiscovery: true,
+		NoDial:      true,
+		ListenAddr:  "127.0.0.1:0",
+		Logger:      testlog.Logger(t, log.LvlTrace).New("server", "2"),
+	}}
+	if err := srv1.Start(); err != nil {
+		t.Fatalf("could not start: %v", err)
+	}
+	defer srv1.Stop()
+	if err := srv2.Start(); err != nil {
+		t.Fatalf("could not start: %v", err)
+	}
+	defer srv2.Stop()
+
+	// Connections to nodes not allowed are rejected.
+	newconn := func(id enode.ID) *conn {
+		fd, _ := net.Pipe()
+		tx := newTestTransport(&newkey().PublicKey, fd, nil)
+		node := enode.SignNull(new(enr.Record), id)
+		return &conn{fd: fd, transport: tx, flags: inboundConn, node: node, cont: make(chan error)}
+	}
+	id := randomID()
+	if err := srv1.checkpoint(newconn(id), srv1.checkpointPostHandshake); err != errNodeNotPermitted {
+		t.Error("wrong error for unpermitted conn @posthandshake:", err)
+	}
+	permissions.set(id, true)
+	if err := srv1.checkpoint(newconn(id), srv1.checkpointPostHandshake); err != nil {
+		t.Error("unexpected error for permitted conn @posthandshake:", err)
+	}
+
+	// Peers no longer allowed are dropped.
+	permissions.set(srv2.Self().ID(), true)
+	if !syncAddPeer(srv1, srv2.Self()) {
+		t.Fatal("permitted peer not connected")
+	}
+	permissions.set(srv2.Self().ID(), false)
+	for deadline := time.Now().Add(2 * time.Second); srv1.PeerCount() > 0; {
+		if time.Now().After(deadline) {
+			t.Fatal("unpermitted peer still connected")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	permissions.lock.Lock()
+	defer permissions.lock.Unlock()
+	if permissions.rechecks == 0 {
+		t.Error("connected peer not checked again after permissions change")
+	}
+}
+
 func TestServerPeerLimits(t *testing.T) {
 	srvkey := newkey()
 	clientkey := newkey()
@@ -146,16 +146,16 @@ var (
 	//
 	// This configuration is intentionally not using keyed fields to force anyone
 	// adding flags to the config to also have to set these fields.
-	AllEthashProtocolChanges = &ChainConfig{big.NewInt(1337), big.NewInt(0), nil, false, big.NewInt(0), common.Hash{}, big.NewInt(0), big.NewInt(0), big.NewInt(0), big.NewInt(0), big.NewInt(0), big.NewInt(0), big.NewInt(0), big.NewInt(0), big.NewInt(0), big.NewInt(0), nil, nil, new(EthashConfig), nil, nil, big.NewInt(0), big.NewInt(0), big.NewInt(0), common.Address{}, nil}
+	AllEthashProtocolChanges = &ChainConfig{big.NewInt(1337), big.NewInt(0), nil, false, big.NewInt(0), common.Hash{}, big.NewInt(0), big.NewInt(0), big.NewInt(0), big.NewInt(0), big.NewInt(0), big.NewInt(0), big.NewInt(0), big.NewInt(0), big.NewInt(0), big.NewInt(0), nil, nil, new(EthashConfig), nil, nil, big.NewInt(0), big.NewInt(0), big.NewInt(0), common.Address{}, nil, big.NewInt(0), common.Address{}}
 
 	// AllCliqueProtocolChanges contains every protocol change (EIPs) introduced
 	// and accepted by the Ethereum core developers into the Clique consensus.
 	//
 	// This configuration is intentionally not using keyed fields to force anyone
 	// adding flags to the config to also have to set these fields.
-	AllCliqueProtocolChanges = &ChainConfig{big.NewInt(1337), big.NewInt(0), nil, false, big.NewInt(0), common.Hash{}, big.NewInt(0), big.NewInt(0), big.NewInt(0), big.NewInt(0), big.NewInt(0), big.NewInt(0), big.NewInt(0), big.NewInt(0), big.NewInt(0), nil, nil, nil, nil, &CliqueConfig{Period: 0, Epoch: 30000}, nil, big.NewInt(0), big.NewInt(0), big.NewInt(0), common.Address{}, nil}
+	AllCliqueProtocolChanges = &ChainConfig{big.NewInt(1337), big.NewInt(0), nil, false, big.NewInt(0), common.Hash{}, big.NewInt(0), big.NewInt(0), big.NewInt(0), big.NewInt(0), big.NewInt(0), big.NewInt(0), big.NewInt(0), big.NewInt(0), big.NewInt(0), nil, nil, nil, nil, &CliqueConfig{Period: 0, Epoch: 30000}, nil, big.NewInt(0), big.NewInt(0), big.NewInt(0), common.Address{}, nil, big.NewInt(0), common.Address{}}
 
-	TestChainConfig = &ChainConfig{big.NewInt(1), big.NewInt(0), nil, false, big.NewInt(0), common.Hash{}, big.NewInt(0), big.NewInt(0), big.NewInt(0), big.NewInt(0), big.NewInt(0), big.NewInt(0), big.NewInt(0), big.NewInt(0), big.NewInt(0), big.NewInt(0), nil, nil, new(EthashConfig), nil, nil, big.NewInt(0), big.NewInt(0), big.NewInt(0), common.Address{}, nil}
+	TestChainConfig = &ChainConfig{big.NewInt(1), big.NewInt(0), nil, false, big.NewInt(0), common.Hash{}, big.NewInt(0), big.NewInt(0), big.NewInt(0), big.NewInt(0), big.NewInt(0), big.NewInt(0), big.NewInt(0), big.NewInt(0), big.NewInt(0), big.NewInt(0), nil, nil, new(EthashConfig), nil, nil, big.NewInt(0), big.NewInt(0), big.NewInt(0), common.Address{}, nil, big.NewInt(0), common.Address{}}
 	TestRules       = TestChainConfig.Rules(new(big.Int), false)
 )
 
@@ -249,8 +249,10 @@ type ChainConfig struct {
 	GenesisETN                         *big.Int       `json:"genesisETN,omitempty"`
 	LegacyV9ForkHeight                 *big.Int       `json:"legacyv9forkheight,omitempty"`
 	LegacyToSmartchainMigrationHeight  *big.Int       `json:"legacytosmartchainmigrationheight,omitempty"`
-	PriorityTransactorsContractAddress common.Address `json:"prioritytransactorscontractaddress"` // Smart contract address for priority transactors
-	Transitions                        []Transition   `json:"transitions,omitempty"`              // Transition config based on the block number
+	PriorityTransactorsContractAddress common.Address `json:"prioritytransactorscontractaddress"`    // Smart contract address for priority transactors
+	Transitions                        []Transition   `json:"transitions,omitempty"`                 // Transition config based on the block number
+	MultiPriorityBlock                 *big.Int       `json:"multipriorityblock,omitempty"`          // Multi-priority transactions switch block (nil = no fork, 0 = already activated)
+	NodeRegistryContractAddress        common.Address `json:"noderegistrycontractaddress,omitempty"` // Smart contract address for the nodes allowed to connect to permissioned networks
 }
 
 // EthashConfig is the consensus engine configs for proof-of-work based sealing.
@@ -280,12 +282,26 @@ type IBFTConfig struct {
 	MaxRequestTimeoutSeconds uint64 `json:"maxrequesttimeoutseconds"` // Maximum request timeout for each IBFT or QBFT round in seconds
 	ProposerPolicy           uint64 `json:"policy"`                   // The policy for proposer selection
 	AllowedFutureBlockTime   uint64 `json:"allowedfutureblocktime"`   //Allowed number of seconds a timestamp can be in the future before it's considered a future block'
+
+	ValidatorWeights map[common.Address]uint64 `json:"validatorweights,omitempty"` // Proposer weights of the validators for the weighted proposer policy
+
+	BlockReward         *big.Int `json:"blockreward,omitempty"`         // Block reward before any halving, in wei
+	HalvingPeriod       uint64   `json:"halvingperiod,omitempty"`       // Number of blocks after which the block reward is halved
+	EmissionBlockOffset *big.Int `json:"emissionblockoffset,omitempty"` // Number of blocks added to the block number when counting halvings
+	MaxSupply           *big.Int `json:"maxsupply,omitempty"`           // Max amount of wei that can ever be created
+
+	RewardSplit *RewardSplit `json:"rewardsplit,omitempty"` // Distribution of the block reward, the proposer gets all of it if not set
 }
 
 func (c IBFTConfig) String() string {
 	return "IBFT"
 }
 
+const (
+	BlockHeaderMode = "blockheader" // Validators are added and removed by votes cast in block headers
+	ContractMode    = "contract"    // Validators are read from the validator contract at each block
+)
+
 type Transition struct {
 	Block                              *big.Int       `json:"block"`
 	EpochLength                        uint64         `json:"epochlength,omitempty"`              // Number of blocks that should pass before pending validator votes are reset
@@ -294,6 +310,27 @@ type Transition struct {
 	MaxRequestTimeoutSeconds           uint64         `json:"maxrequesttimeoutseconds,omitempty"` // Maximum request timeout for each IBFT or QBFT round in seconds
 	PriorityTransactorsContractAddress common.Address `json:"prioritytransactorscontractaddress"` // Smart contract address for priority transactors
 	AllowedFutureBlockTime             uint64         `json:"allowedfutureblocktime,omitempty"`
+	ValidatorContractAddress           common.Address `json:"validatorcontractaddress,omitempty"`          // Smart contract address for the list of validators
+	ValidatorSelectionMode             string         `json:"validatorselectionmode,omitempty"`            // Select validators from the block header votes or from a contract
+	NodeRegistryContractAddress        common.Address `json:"noderegistrycontractaddress,omitempty"`       // Smart contract address for the nodes allowed to connect to permissioned networks
+	AccountPermissionsContractAddress  common.Address `json:"accountpermissionscontractaddress,omitempty"` // Smart contract address for the accounts allowed to transact and deploy contracts
+
+	ValidatorWeights map[common.Address]uint64 `json:"validatorweights,omitempty"` // Proposer weights of the validators for the weighted proposer policy
+	RewardSplit      *RewardSplit              `json:"rewardsplit,omitempty"`      // Distribution of the block reward, the proposer gets all of it if not set
+
+	BlockReward         *big.Int `json:"blockreward,omitempty"`         // Block reward before any halving, in wei
+	HalvingPeriod       uint64   `json:"halvingperiod,omitempty"`       // Number of blocks after which the block reward is halved
+	EmissionBlockOffset *big.Int `json:"emissionblockoffset,omitempty"` // Number of blocks added to the block number when counting halvings
+	MaxSupply           *big.Int `json:"maxsupply,omitempty"`           // Max amount of wei that can ever be created
+}
+
+// RewardSplit distributes the block reward by percentages between the block
+// proposer, the validators that signed the parent block and a treasury.
+type RewardSplit struct {
+	ProposerPercent uint64         `json:"proposerpercent"`
+	SignersPercent  uint64         `json:"signerspercent"`
+	TreasuryPercent uint64         `json:"treasurypercent"`
+	TreasuryAddress common.Address `json:"treasuryaddress,omitempty"`
 }
 
 // String implements the fmt.Stringer interface.
@@ -398,6 +435,12 @@ func (c *ChainConfig) IsArrowGlacier(num *big.Int) bool {
 	return isForked(c.ArrowGlacierBlock, num)
 }
 
+// IsMultiPriority returns whether num is either equal to the multi-priority
+// transactions fork block or greater.
+func (c *ChainConfig) IsMultiPriority(num *big.Int) bool {
+	return isForked(c.MultiPriorityBlock, num)
+}
+
 // IsTerminalPoWBlock returns whether the given block is the last block of PoW stage.
 func (c *ChainConfig) IsTerminalPoWBlock(parentTotalDiff *big.Int, totalDiff *big.Int) bool {
 	if c.TerminalTotalDifficulty == nil {
@@ -529,9 +572,70 @@ func (c *ChainConfig) checkCompatible(newcfg *ChainConfig, head *big.Int) *Confi
 	if isForkIncompatible(c.MergeForkBlock, newcfg.MergeForkBlock, head) {
 		return newCompatError("Merge Start fork block", c.MergeForkBlock, newcfg.MergeForkBlock)
 	}
+	if isForkIncompatible(c.MultiPriorityBlock, newcfg.MultiPriorityBlock, head) {
+		return newCompatError("Multi-priority fork block", c.MultiPriorityBlock, newcfg.MultiPriorityBlock)
+	}
+	return nil
+}
+
+// GetValidatorContractAddress returns the address of the validator set contract
+// that is active at the given block, or the zero address if there is none.
+func (c *ChainConfig) GetValidatorContractAddress(blockNumber *big.Int) common.Address {
+	if c.Transitions != nil {
+		for i := len(c.Transitions) - 1; i >= 0; i-- {
+			if c.Transitions[i].Block.Cmp(blockNumber) <= 0 && c.Transitions[i].ValidatorContractAddress != (common.Address{}) {
+				return c.Transitions[i].ValidatorContractAddress
+			}
+		}
+	}
+	return common.Address{}
+}
+
+// GetValidatorSelectionMode returns the validator selection mode that is active
+// at the given block. Header voting is used unless a transition says otherwise.
+func (c *ChainConfig) GetValidatorSelectionMode(blockNumber *big.Int) string {
+	if c.Transitions != nil {
+		for i := len(c.Transitions) - 1; i >= 0; i-- {
+			if c.Transitions[i].Block.Cmp(blockNumber) <= 0 && c.Transitions[i].ValidatorSelectionMode != "" {
+				return c.Transitions[i].ValidatorSelectionMode
+			}
+		}
+	}
+	return BlockHeaderMode
+}
+
+// GetRewardSplit returns the block reward distribution that is active at the
+// given block, or nil if the proposer gets the whole reward.
+func (c *ChainConfig) GetRewardSplit(blockNumber *big.Int) *RewardSplit {
+	if c.Transitions != nil {
+		for i := len(c.Transitions) - 1; i >= 0; i-- {
+			if c.Transitions[i].Block.Cmp(blockNumber) <= 0 && c.Transitions[i].RewardSplit != nil {
+				return c.Transitions[i].RewardSplit
+			}
+		}
+	}
+	if c.IBFT != nil {
+		return c.IBFT.RewardSplit
+	}
 	return nil
 }
 
+// GetEpochLength returns the IBFT epoch length that is active at the given
+// block, or zero if the chain isn't running IBFT.
+func (c *ChainConfig) GetEpochLength(blockNumber *big.Int) uint64 {
+	if c.Transitions != nil {
+		for i := len(c.Transitions) - 1; i >= 0; i-- {
+			if c.Transitions[i].Block.Cmp(blockNumber) <= 0 && c.Transitions[i].EpochLength != 0 {
+				return c.Transitions[i].EpochLength
+			}
+		}
+	}
+	if c.IBFT != nil {
+		return c.IBFT.EpochLength
+	}
+	return 0
+}
+
 func (c *ChainConfig) GetPriorityTransactorsContractAddress(blockNumber *big.Int) common.Address {
 	if c.Transitions != nil {
 		for i := len(c.Transitions) - 1; i >= 0; i-- {
@@ -543,6 +647,33 @@ func (c *ChainConfig) GetPriorityTransactorsContractAddress(blockNumber *big.Int
 	return c.PriorityTransactorsContractAddress
 }
 
+// GetNodeRegistryContractAddress returns the address of the node registry
+// contract at the given block, or the zero address if nodes aren't permissioned
+// on-chain.
+func (c *ChainConfig) GetNodeRegistryContractAddress(blockNumber *big.Int) common.Address {
+	if c.Transitions != nil {
+		for i := len(c.Transitions) - 1; i >= 0; i-- {
+			if c.Transitions[i].Block.Cmp(blockNumber) <= 0 && c.Transitions[i].NodeRegistryContractAddress != (common.Address{}) {
+				return c.Transitions[i].NodeRegistryContractAddress
+			}
+		}
+	}
+	return c.NodeRegistryContractAddress
+}
+
+// GetAccountPermissionsContractAddress returns the address of the account
+// permissions contract at the given block, or the zero address if accounts
+// aren't permissioned (yet). Account permissions can only be enabled through a
+// transition, so that existing networks can turn them on.
+func (c *ChainConfig) GetAccountPermissionsContractAddress(blockNumber *big.Int) common.Address {
+	for i := len(c.Transitions) - 1; i >= 0; i-- {
+		if c.Transitions[i].Block.Cmp(blockNumber) <= 0 && c.Transitions[i].AccountPermissionsContractAddress != (common.Address{}) {
+			return c.Transitions[i].AccountPermissionsContractAddress
+		}
+	}
+	return common.Address{}
+}
+
 // isForkIncompatible returns true if a fork scheduled at s1 cannot be rescheduled to
 // block s2 because head is already past the fork.
 func isForkIncompatible(s1, s2, head *big.Int) bool {
@@ -558,6 +689,11 @@ func isForked(s, head *big.Int) bool {
 }
 
 func (c *ChainConfig) CheckTransitionsData() error {
+	if c.IBFT != nil {
+		if err := checkRewardSplit(c.IBFT.RewardSplit); err != nil {
+			return err
+		}
+	}
 	prevBlock := big.NewInt(0)
 	for _, transition := range c.Transitions {
 		if transition.Block == nil {
@@ -566,12 +702,60 @@ func (c *ChainConfig) CheckTransitionsData() error {
 		if transition.Block.Cmp(prevBlock) < 0 {
 			return ErrBlockOrder
 		}
+		switch transition.ValidatorSelectionMode {
+		case "", BlockHeaderMode:
+		case ContractMode:
+			if c.GetValidatorContractAddress(transition.Block) == (common.Address{}) {
+				return ErrMissingValidatorContract
+			}
+		default:
+			return ErrInvalidValidatorSelectionMode
+		}
+		if err := checkRewardSplit(transition.RewardSplit); err != nil {
+			return err
+		}
 		prevBlock = transition.Block
 	}
 	return nil
 }
 
+// checkRewardSplit validates a block reward distribution, if any.
+func checkRewardSplit(split *RewardSplit) error {
+	if split == nil {
+		return nil
+	}
+	if split.ProposerPercent+split.SignersPercent+split.TreasuryPercent != 100 {
+		return ErrInvalidRewardSplit
+	}
+	if split.TreasuryPercent > 0 && split.TreasuryAddress == (common.Address{}) {
+		return ErrMissingTreasuryAddress
+	}
+	return nil
+}
+
 func isTransitionsConfigCompatible(c1, c2 *ChainConfig, head *big.Int) (*big.Int, *big.Int, error) {
+	// The validator weights and the emission of the chain config apply from the
+	// first block, they can't be changed once blocks were sealed with them
+	if head.Sign() > 0 && c1.IBFT != nil && c2.IBFT != nil {
+		if !weightsEqual(c1.IBFT.ValidatorWeights, c2.IBFT.ValidatorWeights) {
+			return big.NewInt(0), big.NewInt(0), ErrTransitionIncompatible("ValidatorWeights")
+		}
+		if !rewardSplitsEqual(c1.IBFT.RewardSplit, c2.IBFT.RewardSplit) {
+			return big.NewInt(0), big.NewInt(0), ErrTransitionIncompatible("RewardSplit")
+		}
+		if !configNumEqual(c1.IBFT.BlockReward, c2.IBFT.BlockReward) {
+			return big.NewInt(0), big.NewInt(0), ErrTransitionIncompatible("BlockReward")
+		}
+		if c1.IBFT.HalvingPeriod != c2.IBFT.HalvingPeriod {
+			return big.NewInt(0), big.NewInt(0), ErrTransitionIncompatible("HalvingPeriod")
+		}
+		if !configNumEqual(c1.IBFT.EmissionBlockOffset, c2.IBFT.EmissionBlockOffset) {
+			return big.NewInt(0), big.NewInt(0), ErrTransitionIncompatible("EmissionBlockOffset")
+		}
+		if !configNumEqual(c1.IBFT.MaxSupply, c2.IBFT.MaxSupply) {
+			return big.NewInt(0), big.NewInt(0), ErrTransitionIncompatible("MaxSupply")
+		}
+	}
 	if len(c1.Transitions) == 0 && len(c2.Transitions) == 0 {
 		// maxCodeSizeConfig not used. return
 		return big.NewInt(0), big.NewInt(0), nil
@@ -637,11 +821,59 @@ func isTransitionsConfigCompatible(c1, c2 *ChainConfig, head *big.Int) (*big.Int
 		if c1.Transitions[i].PriorityTransactorsContractAddress != c2.Transitions[i].PriorityTransactorsContractAddress {
 			return head, head, ErrTransitionIncompatible("PriorityTransactorsContractAddress")
 		}
+		if c1.Transitions[i].AccountPermissionsContractAddress != c2.Transitions[i].AccountPermissionsContractAddress {
+			return head, head, ErrTransitionIncompatible("AccountPermissionsContractAddress")
+		}
+		if c1.Transitions[i].ValidatorContractAddress != c2.Transitions[i].ValidatorContractAddress {
+			return head, head, ErrTransitionIncompatible("ValidatorContractAddress")
+		}
+		if c1.Transitions[i].ValidatorSelectionMode != c2.Transitions[i].ValidatorSelectionMode {
+			return head, head, ErrTransitionIncompatible("ValidatorSelectionMode")
+		}
+		if !weightsEqual(c1.Transitions[i].ValidatorWeights, c2.Transitions[i].ValidatorWeights) {
+			return head, head, ErrTransitionIncompatible("ValidatorWeights")
+		}
+		if !rewardSplitsEqual(c1.Transitions[i].RewardSplit, c2.Transitions[i].RewardSplit) {
+			return head, head, ErrTransitionIncompatible("RewardSplit")
+		}
+		if !configNumEqual(c1.Transitions[i].BlockReward, c2.Transitions[i].BlockReward) {
+			return head, head, ErrTransitionIncompatible("BlockReward")
+		}
+		if c1.Transitions[i].HalvingPeriod != c2.Transitions[i].HalvingPeriod {
+			return head, head, ErrTransitionIncompatible("HalvingPeriod")
+		}
+		if !configNumEqual(c1.Transitions[i].EmissionBlockOffset, c2.Transitions[i].EmissionBlockOffset) {
+			return head, head, ErrTransitionIncompatible("EmissionBlockOffset")
+		}
+		if !configNumEqual(c1.Transitions[i].MaxSupply, c2.Transitions[i].MaxSupply) {
+			return head, head, ErrTransitionIncompatible("MaxSupply")
+		}
 	}
 
 	return big.NewInt(0), big.NewInt(0), nil
 }
 
+// weightsEqual returns whether two validator weight maps assign the same weights.
+func weightsEqual(x, y map[common.Address]uint64) bool {
+	if len(x) != len(y) {
+		return false
+	}
+	for addr, weight := range x {
+		if w, ok := y[addr]; !ok || w != weight {
+			return false
+		}
+	}
+	return true
+}
+
+// rewardSplitsEqual returns whether two reward splits distribute the reward the same way.
+func rewardSplitsEqual(x, y *RewardSplit) bool {
+	if x == nil || y == nil {
+		return x == y
+	}
+	return *x == *y
+}
+
 func configNumEqual(x, y *big.Int) bool {
 	if x == nil {
 		return y == nil
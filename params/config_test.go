@@ -105,10 +105,10 @@ func TestCheckTransitionsData(t *testing.T) {
 		wantErr error
 	}
 	var ibftTransitionsConfig, qbftTransitionsConfig, invalidBlockOrder []Transition
-	tranI0 := Transition{big.NewInt(0), 30000, 5, 10, 60, common.Address{}, 0}
-	tranI5 := Transition{big.NewInt(5), 30000, 5, 10, 60, common.Address{}, 0}
-	tranI8 := Transition{big.NewInt(8), 30000, 5, 10, 60, common.Address{}, 0}
-	tranI10 := Transition{big.NewInt(10), 30000, 5, 10, 60, common.Address{}, 0}
+	tranI0 := Transition{Block: big.NewInt(0), EpochLength: 30000, BlockPeriodSeconds: 5, RequestTimeoutSeconds: 10, MaxRequestTimeoutSeconds: 60}
+	tranI5 := Transition{Block: big.NewInt(5), EpochLength: 30000, BlockPeriodSeconds: 5, RequestTimeoutSeconds: 10, MaxRequestTimeoutSeconds: 60}
+	tranI8 := Transition{Block: big.NewInt(8), EpochLength: 30000, BlockPeriodSeconds: 5, RequestTimeoutSeconds: 10, MaxRequestTimeoutSeconds: 60}
+	tranI10 := Transition{Block: big.NewInt(10), EpochLength: 30000, BlockPeriodSeconds: 5, RequestTimeoutSeconds: 10, MaxRequestTimeoutSeconds: 60}
 
 	ibftTransitionsConfig = append(ibftTransitionsConfig, tranI0, tranI5, tranI8, tranI10)
 	invalidBlockOrder = append(invalidBlockOrder, tranI8, tranI5)
@@ -143,13 +143,45 @@ func TestCheckTransitionsData(t *testing.T) {
 			wantErr: ErrBlockOrder,
 		},
 		{
-			stored:  &ChainConfig{Transitions: []Transition{{nil, 30000, 5, 10, 60, common.Address{}, 0}}},
+			stored:  &ChainConfig{Transitions: []Transition{{EpochLength: 30000, BlockPeriodSeconds: 5, RequestTimeoutSeconds: 10, MaxRequestTimeoutSeconds: 60}}},
 			wantErr: ErrBlockNumberMissing,
 		},
 		{
 			stored:  &ChainConfig{Transitions: []Transition{{Block: big.NewInt(0)}}},
 			wantErr: nil,
 		},
+		{
+			stored:  &ChainConfig{Transitions: []Transition{{Block: big.NewInt(0), ValidatorContractAddress: common.Address{0x1}, ValidatorSelectionMode: ContractMode}}},
+			wantErr: nil,
+		},
+		{
+			stored:  &ChainConfig{Transitions: []Transition{{Block: big.NewInt(0), ValidatorSelectionMode: "invalid"}}},
+			wantErr: ErrInvalidValidatorSelectionMode,
+		},
+		{
+			stored:  &ChainConfig{Transitions: []Transition{{Block: big.NewInt(0), ValidatorSelectionMode: ContractMode}}},
+			wantErr: ErrMissingValidatorContract,
+		},
+		{
+			stored:  &ChainConfig{Transitions: []Transition{{Block: big.NewInt(0), RewardSplit: &RewardSplit{ProposerPercent: 50, SignersPercent: 30, TreasuryPercent: 20, TreasuryAddress: common.Address{0x1}}}}},
+			wantErr: nil,
+		},
+		{
+			stored:  &ChainConfig{Transitions: []Transition{{Block: big.NewInt(0), RewardSplit: &RewardSplit{ProposerPercent: 50, SignersPercent: 30}}}},
+			wantErr: ErrInvalidRewardSplit,
+		},
+		{
+			stored:  &ChainConfig{Transitions: []Transition{{Block: big.NewInt(0), RewardSplit: &RewardSplit{ProposerPercent: 50, TreasuryPercent: 50}}}},
+			wantErr: ErrMissingTreasuryAddress,
+		},
+		{
+			stored:  &ChainConfig{IBFT: &IBFTConfig{RewardSplit: &RewardSplit{ProposerPercent: 50, SignersPercent: 50}}},
+			wantErr: nil,
+		},
+		{
+			stored:  &ChainConfig{IBFT: &IBFTConfig{RewardSplit: &RewardSplit{ProposerPercent: 50, SignersPercent: 30}}},
+			wantErr: ErrInvalidRewardSplit,
+		},
 	}
 
 	for _, test := range tests {
@@ -197,3 +229,162 @@ func TestGetPriorityTransactorsContractAddress(t *testing.T) {
 		}
 	}
 }
+
+func TestGetValidatorContractAddressAndMode(t *testing.T) {
+	address1, address2 := common.Address{0x2}, common.Address{0x4}
+
+	config := &ChainConfig{Transitions: []Transition{{
+		Block:                    big.NewInt(2),
+		ValidatorContractAddress: address1,
+		ValidatorSelectionMode:   ContractMode,
+	}, {
+		Block:                  big.NewInt(4),
+		ValidatorSelectionMode: BlockHeaderMode,
+	}, {
+		Block:                    big.NewInt(6),
+		ValidatorContractAddress: address2,
+		ValidatorSelectionMode:   ContractMode,
+	}}}
+
+	type test struct {
+		blockNumber     int64
+		expectedAddress common.Address
+		expectedMode    string
+	}
+	tests := []test{
+		{0, common.Address{}, BlockHeaderMode},
+		{1, common.Address{}, BlockHeaderMode},
+		{2, address1, ContractMode},
+		{3, address1, ContractMode},
+		{4, address1, BlockHeaderMode},
+		{5, address1, BlockHeaderMode},
+		{6, address2, ContractMode},
+		{100, address2, ContractMode},
+	}
+
+	for _, test := range tests {
+		address := config.GetValidatorContractAddress(big.NewInt(test.blockNumber))
+		if address != test.expectedAddress {
+			t.Errorf("block %d: address mismatch:\nexpected: %v\ngot: %v\n", test.blockNumber, test.expectedAddress, address)
+		}
+		mode := config.GetValidatorSelectionMode(big.NewInt(test.blockNumber))
+		if mode != test.expectedMode {
+			t.Errorf("block %d: mode mismatch:\nexpected: %v\ngot: %v\n", test.blockNumber, test.expectedMode, mode)
+		}
+	}
+}
+
+func TestTransitionsValidatorWeightsCompatible(t *testing.T) {
+	weights := map[common.Address]uint64{{0x1}: 2, {0x2}: 1}
+	stored := &ChainConfig{Transitions: []Transition{{Block: big.NewInt(5), ValidatorWeights: weights}}}
+
+	type test struct {
+		weights map[common.Address]uint64
+		head    int64
+		wantErr error
+	}
+	tests := []test{
+		{map[common.Address]uint64{{0x2}: 1, {0x1}: 2}, 10, nil},
+		{map[common.Address]uint64{{0x1}: 3, {0x2}: 1}, 10, ErrTransitionIncompatible("ValidatorWeights")},
+		{map[common.Address]uint64{{0x1}: 2}, 10, ErrTransitionIncompatible("ValidatorWeights")},
+		{map[common.Address]uint64{{0x1}: 3, {0x2}: 1}, 4, nil},
+	}
+	for _, test := range tests {
+		newcfg := &ChainConfig{Transitions: []Transition{{Block: big.NewInt(5), ValidatorWeights: test.weights}}}
+		_, _, err := isTransitionsConfigCompatible(stored, newcfg, big.NewInt(test.head))
+		if !reflect.DeepEqual(err, test.wantErr) {
+			t.Errorf("error mismatch:\nweights: %v\nhead: %v\nerr: %v\nwant: %v", test.weights, test.head, err, test.wantErr)
+		}
+	}
+}
+
+func TestTransitionsEmissionCompatible(t *testing.T) {
+	stored := &ChainConfig{Transitions: []Transition{{Block: big.NewInt(5), BlockReward: big.NewInt(100), HalvingPeriod: 10}}}
+
+	type test struct {
+		transition Transition
+		head       int64
+		wantErr    error
+	}
+	tests := []test{
+		{Transition{Block: big.NewInt(5), BlockReward: big.NewInt(100), HalvingPeriod: 10}, 10, nil},
+		{Transition{Block: big.NewInt(5), BlockReward: big.NewInt(200), HalvingPeriod: 10}, 10, ErrTransitionIncompatible("BlockReward")},
+		{Transition{Block: big.NewInt(5), BlockReward: big.NewInt(100), HalvingPeriod: 20}, 10, ErrTransitionIncompatible("HalvingPeriod")},
+		{Transition{Block: big.NewInt(5), BlockReward: big.NewInt(100), HalvingPeriod: 10, EmissionBlockOffset: big.NewInt(1)}, 10, ErrTransitionIncompatible("EmissionBlockOffset")},
+		{Transition{Block: big.NewInt(5), BlockReward: big.NewInt(100), HalvingPeriod: 10, MaxSupply: big.NewInt(1000)}, 10, ErrTransitionIncompatible("MaxSupply")},
+		{Transition{Block: big.NewInt(5), BlockReward: big.NewInt(200), HalvingPeriod: 10}, 4, nil},
+	}
+	for _, test := range tests {
+		newcfg := &ChainConfig{Transitions: []Transition{test.transition}}
+		_, _, err := isTransitionsConfigCompatible(stored, newcfg, big.NewInt(test.head))
+		if !reflect.DeepEqual(err, test.wantErr) {
+			t.Errorf("error mismatch:\ntransition: %+v\nhead: %v\nerr: %v\nwant: %v", test.transition, test.head, err, test.wantErr)
+		}
+	}
+}
+
+func TestGenesisEmissionCompatible(t *testing.T) {
+	stored := &ChainConfig{IBFT: &IBFTConfig{
+		ValidatorWeights: map[common.Address]uint64{{0x1}: 2},
+		BlockReward:      big.NewInt(100),
+		HalvingPeriod:    10,
+	}}
+	type test struct {
+		ibft    IBFTConfig
+		head    int64
+		wantErr error
+	}
+	tests := []test{
+		{IBFTConfig{ValidatorWeights: map[common.Address]uint64{{0x1}: 2}, BlockReward: big.NewInt(100), HalvingPeriod: 10}, 10, nil},
+		{IBFTConfig{ValidatorWeights: map[common.Address]uint64{{0x1}: 3}, BlockReward: big.NewInt(100), HalvingPeriod: 10}, 10, ErrTransitionIncompatible("ValidatorWeights")},
+		{IBFTConfig{ValidatorWeights: map[common.Address]uint64{{0x1}: 2}, BlockReward: big.NewInt(200), HalvingPeriod: 10}, 10, ErrTransitionIncompatible("BlockReward")},
+		{IBFTConfig{ValidatorWeights: map[common.Address]uint64{{0x1}: 2}, HalvingPeriod: 10}, 10, ErrTransitionIncompatible("BlockReward")},
+		{IBFTConfig{ValidatorWeights: map[common.Address]uint64{{0x1}: 2}, BlockReward: big.NewInt(100), HalvingPeriod: 20}, 10, ErrTransitionIncompatible("HalvingPeriod")},
+		{IBFTConfig{ValidatorWeights: map[common.Address]uint64{{0x1}: 2}, BlockReward: big.NewInt(100), HalvingPeriod: 10, EmissionBlockOffset: big.NewInt(1)}, 10, ErrTransitionIncompatible("EmissionBlockOffset")},
+		{IBFTConfig{ValidatorWeights: map[common.Address]uint64{{0x1}: 2}, BlockReward: big.NewInt(100), HalvingPeriod: 10, MaxSupply: big.NewInt(1000)}, 10, ErrTransitionIncompatible("MaxSupply")},
+		{IBFTConfig{BlockReward: big.NewInt(200), HalvingPeriod: 20, MaxSupply: big.NewInt(1000)}, 0, nil},
+	}
+	for _, test := range tests {
+		ibft := test.ibft
+		newcfg := &ChainConfig{IBFT: &ibft}
+		_, _, err := isTransitionsConfigCompatible(stored, newcfg, big.NewInt(test.head))
+		if !reflect.DeepEqual(err, test.wantErr) {
+			t.Errorf("error mismatch:\nibft: %+v\nhead: %v\nerr: %v\nwant: %v", test.ibft, test.head, err, test.wantErr)
+		}
+	}
+}
+
+func TestRewardSplitFromGenesis(t *testing.T) {
+	genesisSplit := &RewardSplit{ProposerPercent: 50, SignersPercent: 50}
+	transitionSplit := &RewardSplit{ProposerPercent: 100}
+	config := &ChainConfig{
+		IBFT:        &IBFTConfig{RewardSplit: genesisSplit},
+		Transitions: []Transition{{Block: big.NewInt(10), RewardSplit: transitionSplit}},
+	}
+	if split := config.GetRewardSplit(big.NewInt(1)); split != genesisSplit {
+		t.Errorf("block 1: split mismatch: have %+v, want %+v", split, genesisSplit)
+	}
+	if split := config.GetRewardSplit(big.NewInt(10)); split != transitionSplit {
+		t.Errorf("block 10: split mismatch: have %+v, want %+v", split, transitionSplit)
+	}
+
+	stored := &ChainConfig{IBFT: &IBFTConfig{RewardSplit: genesisSplit}}
+	type test struct {
+		split   *RewardSplit
+		head    int64
+		wantErr error
+	}
+	tests := []test{
+		{&RewardSplit{ProposerPercent: 50, SignersPercent: 50}, 10, nil},
+		{&RewardSplit{ProposerPercent: 40, SignersPercent: 60}, 10, ErrTransitionIncompatible("RewardSplit")},
+		{nil, 10, ErrTransitionIncompatible("RewardSplit")},
+		{&RewardSplit{ProposerPercent: 40, SignersPercent: 60}, 0, nil},
+	}
+	for _, test := range tests {
+		newcfg := &ChainConfig{IBFT: &IBFTConfig{RewardSplit: test.split}}
+		_, _, err := isTransitionsConfigCompatible(stored, newcfg, big.NewInt(test.head))
+		if !reflect.DeepEqual(err, test.wantErr) {
+			t.Errorf("error mismatch:\nsplit: %+v\nhead: %v\nerr: %v\nwant: %v", test.split, test.head, err, test.wantErr)
+		}
+	}
+}
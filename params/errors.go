@@ -8,6 +8,12 @@ import (
 var (
 	ErrBlockNumberMissing = errors.New("block number not given in transitions data")
 	ErrBlockOrder         = errors.New("block order should be ascending")
+
+	ErrInvalidValidatorSelectionMode = errors.New("invalid validator selection mode in transitions data")
+	ErrMissingValidatorContract      = errors.New("validator contract address not given for contract selection mode")
+
+	ErrInvalidRewardSplit     = errors.New("reward split percentages should add up to 100")
+	ErrMissingTreasuryAddress = errors.New("treasury address not given for reward split")
 )
 
 func ErrTransitionIncompatible(field string) error {
@@ -69,4 +69,11 @@ const (
 	// 21Bn with 18 decimal places
 	// 21.000.000.000 + 18 decimal places
 	ETNMaxSupply = "21000000000000000000000000000"
+
+	// ETNBlockReward is the block reward before any halving, ~100ETN every 120 seconds.
+	ETNBlockReward = "4000000000000000000"
+
+	// ETNHalvingPeriod is the number of blocks after which the block reward is
+	// halved, 4 years in 5-second block time.
+	ETNHalvingPeriod = 25228800
 )
@@ -160,6 +160,8 @@ const (
 
 	IBFTMaximumExtraDataSize uint64 = 65  // Maximum size extra data may be after Genesis.
 	IBFTMaxPayloadBufferSize uint64 = 128 // // Payload for a transaction, the size of the buffer to 128kb to match the maximum allowed in chain config
+
+	MaxPrioritySignatures = 16 // Maximum number of priority keys that may co-sign a multi-priority transaction
 )
 
 // Gas discount table for BLS12-381 G1 and G2 multi exponentiation operations
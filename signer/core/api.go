@@ -31,6 +31,8 @@ import (
 	"github.com/electroneum/electroneum-sc/accounts/usbwallet"
 	"github.com/electroneum/electroneum-sc/common"
 	"github.com/electroneum/electroneum-sc/common/hexutil"
+	"github.com/electroneum/electroneum-sc/core/types"
+	"github.com/electroneum/electroneum-sc/crypto"
 	"github.com/electroneum/electroneum-sc/internal/ethapi"
 	"github.com/electroneum/electroneum-sc/log"
 	"github.com/electroneum/electroneum-sc/rpc"
@@ -42,9 +44,9 @@ const (
 	// numberOfAccountsToDerive For hardware wallets, the number of accounts to derive
 	numberOfAccountsToDerive = 10
 	// ExternalAPIVersion -- see extapi_changelog.md
-	ExternalAPIVersion = "6.1.0"
+	ExternalAPIVersion = "6.2.0"
 	// InternalAPIVersion -- see intapi_changelog.md
-	InternalAPIVersion = "7.0.1"
+	InternalAPIVersion = "7.1.0"
 )
 
 // ExternalAPI defines the external API through which signing requests are made.
@@ -55,6 +57,8 @@ type ExternalAPI interface {
 	New(ctx context.Context) (common.Address, error)
 	// SignTransaction request to sign the specified transaction
 	SignTransaction(ctx context.Context, args apitypes.SendTxArgs, methodSelector *string) (*ethapi.SignTransactionResult, error)
+	// SignPriorityTransaction request to co-sign the specified priority transaction with a priority key
+	SignPriorityTransaction(ctx context.Context, addr common.MixedcaseAddress, tx hexutil.Bytes) (*ethapi.SignTransactionResult, error)
 	// SignData - request to sign the given data (plus prefix)
 	SignData(ctx context.Context, contentType string, addr common.MixedcaseAddress, data interface{}) (hexutil.Bytes, error)
 	// SignTypedData - request to sign the given structured data (plus prefix)
@@ -72,6 +76,8 @@ type ExternalAPI interface {
 type UIClientAPI interface {
 	// ApproveTx prompt the user for confirmation to request to sign Transaction
 	ApproveTx(request *SignTxRequest) (SignTxResponse, error)
+	// ApprovePriorityTx prompt the user for confirmation to request to co-sign a Transaction with a priority key
+	ApprovePriorityTx(request *SignPriorityTxRequest) (SignPriorityTxResponse, error)
 	// ApproveSignData prompt the user for confirmation to request to sign data
 	ApproveSignData(request *SignDataRequest) (SignDataResponse, error)
 	// ApproveListing prompt the user for confirmation to list accounts
@@ -233,6 +239,18 @@ type (
 		Transaction apitypes.SendTxArgs `json:"transaction"`
 		Approved    bool                `json:"approved"`
 	}
+	// SignPriorityTxRequest contains info about a priority Transaction to co-sign
+	SignPriorityTxRequest struct {
+		Transaction apitypes.SendTxArgs       `json:"transaction"`
+		Priority    common.MixedcaseAddress   `json:"priority"` // Account holding the priority key
+		Callinfo    []apitypes.ValidationInfo `json:"call_info"`
+		Meta        Metadata                  `json:"meta"`
+	}
+	// SignPriorityTxResponse result from SignPriorityTxRequest. The UI may not
+	// change the transaction, as it has already been signed by its sender.
+	SignPriorityTxResponse struct {
+		Approved bool `json:"approved"`
+	}
 	SignDataRequest struct {
 		ContentType string                    `json:"content_type"`
 		Address     common.MixedcaseAddress   `json:"address"`
@@ -613,6 +631,129 @@ func (api *SignerAPI) SignTransaction(ctx context.Context, args apitypes.SendTxA
 	return &response, nil
 }
 
+// SignPriorityTransaction co-signs the given priority Transaction, already signed
+// by its sender, with the priority key held by the given account. The result is
+// returned both as json and rlp-encoded form.
+func (api *SignerAPI) SignPriorityTransaction(ctx context.Context, addr common.MixedcaseAddress, input hexutil.Bytes) (*ethapi.SignTransactionResult, error) {
+	tx := new(types.Transaction)
+	if err := tx.UnmarshalBinary(input); err != nil {
+		return nil, err
+	}
+	if !tx.IsPriority() {
+		return nil, fmt.Errorf("transaction type %d is not a priority transaction", tx.Type())
+	}
+	if api.chainID.Cmp(tx.ChainId()) != 0 {
+		log.Error("Signing request with wrong chain id", "requested", tx.ChainId(), "configured", api.chainID)
+		return nil, fmt.Errorf("requested chainid %d does not match the configuration of the signer", tx.ChainId())
+	}
+	// The sender can't be recovered through the signer before the priority signature
+	// is present, so recover it from the plain sender signature instead
+	signer := types.LatestSignerForChainID(api.chainID)
+	from, err := prioritySenderAddress(signer, tx)
+	if err != nil {
+		return nil, err
+	}
+	args := priorityTxArgs(tx, from)
+	msgs, err := api.validator.ValidateTransaction(nil, &args)
+	if err != nil {
+		return nil, err
+	}
+	// If we are in 'rejectMode', then reject rather than show the user warnings
+	if api.rejectMode {
+		if err := msgs.GetWarnings(); err != nil {
+			return nil, err
+		}
+	}
+	req := SignPriorityTxRequest{
+		Transaction: args,
+		Priority:    addr,
+		Meta:        MetadataFromContext(ctx),
+		Callinfo:    msgs.Messages,
+	}
+	// Process approval
+	result, err := api.UI.ApprovePriorityTx(&req)
+	if err != nil {
+		return nil, err
+	}
+	if !result.Approved {
+		return nil, ErrRequestDenied
+	}
+	acc := accounts.Account{Address: addr.Address()}
+	wallet, err := api.am.Find(acc)
+	if err != nil {
+		return nil, err
+	}
+	// Get the password for the priority key
+	pw, err := api.lookupOrQueryPassword(acc.Address, "Priority key password",
+		fmt.Sprintf("Please enter the password for priority account %s", acc.Address.String()))
+	if err != nil {
+		return nil, err
+	}
+	signedTx, err := wallet.SignPriorityTxWithPassphrase(acc, pw, tx, api.chainID)
+	if err != nil {
+		api.UI.ShowError(err.Error())
+		return nil, err
+	}
+	data, err := signedTx.MarshalBinary()
+	if err != nil {
+		return nil, err
+	}
+	response := ethapi.SignTransactionResult{Raw: data, Tx: signedTx}
+
+	// Finally, send the signed tx to the UI
+	api.UI.OnApprovedTx(response)
+	// ...and to the external caller
+	return &response, nil
+}
+
+// prioritySenderAddress recovers the sender of a priority transaction from its
+// sender signature alone.
+func prioritySenderAddress(signer types.Signer, tx *types.Transaction) (common.Address, error) {
+	v, r, s := tx.RawSignatureValues()
+	if r == nil || s == nil || (r.Sign() == 0 && s.Sign() == 0) {
+		return common.Address{}, errors.New("priority transaction is not signed by its sender")
+	}
+	if v.BitLen() > 8 || !crypto.ValidateSignatureValues(byte(v.Uint64()), r, s, true) {
+		return common.Address{}, types.ErrInvalidSig
+	}
+	sig := make([]byte, crypto.SignatureLength)
+	r.FillBytes(sig[:32])
+	s.FillBytes(sig[32:64])
+	sig[crypto.RecoveryIDOffset] = byte(v.Uint64())
+
+	pubkey, err := crypto.SigToPub(signer.Hash(tx).Bytes(), sig)
+	if err != nil {
+		return common.Address{}, err
+	}
+	return crypto.PubkeyToAddress(*pubkey), nil
+}
+
+// priorityTxArgs converts a priority transaction into the arguments shown to the
+// UI and checked by the validator.
+func priorityTxArgs(tx *types.Transaction, from common.Address) apitypes.SendTxArgs {
+	var (
+		data       = hexutil.Bytes(tx.Data())
+		accessList = tx.AccessList()
+		to         *common.MixedcaseAddress
+	)
+	if tx.To() != nil {
+		t := common.NewMixedcaseAddress(*tx.To())
+		to = &t
+	}
+	return apitypes.SendTxArgs{
+		From:                 common.NewMixedcaseAddress(from),
+		To:                   to,
+		Gas:                  hexutil.Uint64(tx.Gas()),
+		MaxFeePerGas:         (*hexutil.Big)(tx.GasFeeCap()),
+		MaxPriorityFeePerGas: (*hexutil.Big)(tx.GasTipCap()),
+		Value:                hexutil.Big(*tx.Value()),
+		Nonce:                hexutil.Uint64(tx.Nonce()),
+		Data:                 &data,
+		AccessList:           &accessList,
+		ChainID:              (*hexutil.Big)(tx.ChainId()),
+	}
+}
+
 func (api *SignerAPI) SignGnosisSafeTx(ctx context.Context, signerAddress common.MixedcaseAddress, gnosisTx GnosisSafeTx, methodSelector *string) (*GnosisSafeTx, error) {
 	// Do the usual validations, but on the last-stage transaction
 	args := gnosisTx.ArgsForValidation()
@@ -69,6 +69,11 @@ func (ui *headlessUi) ApproveTx(request *core.SignTxRequest) (core.SignTxRespons
 	}
 }
 
+func (ui *headlessUi) ApprovePriorityTx(request *core.SignPriorityTxRequest) (core.SignPriorityTxResponse, error) {
+	approved := (<-ui.approveCh == "Y")
+	return core.SignPriorityTxResponse{Approved: approved}, nil
+}
+
 func (ui *headlessUi) ApproveSignData(request *core.SignDataRequest) (core.SignDataResponse, error) {
 	approved := (<-ui.approveCh == "Y")
 	return core.SignDataResponse{approved}, nil
@@ -62,6 +62,19 @@ func (l *AuditLogger) SignTransaction(ctx context.Context, args apitypes.SendTxA
 	return res, e
 }
 
+func (l *AuditLogger) SignPriorityTransaction(ctx context.Context, addr common.MixedcaseAddress, tx hexutil.Bytes) (*ethapi.SignTransactionResult, error) {
+	l.log.Info("SignPriorityTransaction", "type", "request", "metadata", MetadataFromContext(ctx).String(),
+		"addr", addr.String(), "tx", common.Bytes2Hex(tx))
+
+	res, e := l.api.SignPriorityTransaction(ctx, addr, tx)
+	if res != nil {
+		l.log.Info("SignPriorityTransaction", "type", "response", "data", common.Bytes2Hex(res.Raw), "error", e)
+	} else {
+		l.log.Info("SignPriorityTransaction", "type", "response", "data", res, "error", e)
+	}
+	return res, e
+}
+
 func (l *AuditLogger) SignData(ctx context.Context, contentType string, addr common.MixedcaseAddress, data interface{}) (hexutil.Bytes, error) {
 	marshalledData, _ := json.Marshal(data) // can ignore error, marshalling what we just unmarshalled
 	l.log.Info("SignData", "type", "request", "metadata", MetadataFromContext(ctx).String(),
@@ -156,6 +156,54 @@ func (ui *CommandlineUI) ApproveTx(request *SignTxRequest) (SignTxResponse, erro
 	return SignTxResponse{request.Transaction, true}, nil
 }
 
+// ApprovePriorityTx prompt the user for confirmation to request to co-sign a
+// Transaction with a priority key
+func (ui *CommandlineUI) ApprovePriorityTx(request *SignPriorityTxRequest) (SignPriorityTxResponse, error) {
+	ui.mu.Lock()
+	defer ui.mu.Unlock()
+
+	tx := request.Transaction
+	fmt.Printf("------ Priority transaction request ------\n")
+	fmt.Printf("A request has been made to co-sign a transaction with a priority key.\n")
+	fmt.Printf("Priority transactions bypass the regular transaction pool ordering,\n")
+	fmt.Printf("and may have their fees waived, on behalf of the priority key.\n\n")
+	fmt.Printf("priority key:          %v\n", request.Priority.String())
+	if to := tx.To; to != nil {
+		fmt.Printf("to:                    %v\n", to.Original())
+		if !to.ValidChecksum() {
+			fmt.Printf("\nWARNING: Invalid checksum on to-address!\n\n")
+		}
+	} else {
+		fmt.Printf("to:                    <contact creation>\n")
+	}
+	fmt.Printf("from:                  %v\n", tx.From.String())
+	fmt.Printf("value:                 %v wei\n", tx.Value.ToInt())
+	fmt.Printf("gas:                   %v (%v)\n", tx.Gas, uint64(tx.Gas))
+	fmt.Printf("maxFeePerGas:          %v wei\n", tx.MaxFeePerGas.ToInt())
+	fmt.Printf("maxPriorityFeePerGas:  %v wei\n", tx.MaxPriorityFeePerGas.ToInt())
+	fmt.Printf("nonce:                 %v (%v)\n", tx.Nonce, uint64(tx.Nonce))
+	if chainId := tx.ChainID; chainId != nil {
+		fmt.Printf("chainid:               %v\n", chainId)
+	}
+	if tx.Data != nil && len(*tx.Data) > 0 {
+		fmt.Printf("data:                  %v\n", hexutil.Encode(*tx.Data))
+	}
+	if request.Callinfo != nil {
+		fmt.Printf("\nTransaction validation:\n")
+		for _, m := range request.Callinfo {
+			fmt.Printf("  * %s : %s\n", m.Typ, m.Message)
+		}
+		fmt.Println()
+	}
+	fmt.Printf("\n")
+	showMetadata(request.Meta)
+	fmt.Printf("-------------------------------------------\n")
+	if !ui.confirm() {
+		return SignPriorityTxResponse{false}, nil
+	}
+	return SignPriorityTxResponse{true}, nil
+}
+
 // ApproveSignData prompt the user for confirmation to request to sign data
 func (ui *CommandlineUI) ApproveSignData(request *SignDataRequest) (SignDataResponse, error) {
 	ui.mu.Lock()
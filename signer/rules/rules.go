@@ -165,6 +165,19 @@ func (r *rulesetUI) ApproveTx(request *core.SignTxRequest) (core.SignTxResponse,
 	return core.SignTxResponse{Approved: false}, err
 }
 
+func (r *rulesetUI) ApprovePriorityTx(request *core.SignPriorityTxRequest) (core.SignPriorityTxResponse, error) {
+	jsonreq, err := json.Marshal(request)
+	approved, err := r.checkApproval("ApprovePriorityTx", jsonreq, err)
+	if err != nil {
+		log.Info("Rule-based approval error, going to manual", "error", err)
+		return r.next.ApprovePriorityTx(request)
+	}
+	if approved {
+		return core.SignPriorityTxResponse{Approved: true}, nil
+	}
+	return core.SignPriorityTxResponse{Approved: false}, err
+}
+
 func (r *rulesetUI) ApproveSignData(request *core.SignDataRequest) (core.SignDataResponse, error) {
 	jsonreq, err := json.Marshal(request)
 	approved, err := r.checkApproval("ApproveSignData", jsonreq, err)
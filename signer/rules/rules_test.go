@@ -88,6 +88,10 @@ func (alwaysDenyUI) ApproveTx(request *core.SignTxRequest) (core.SignTxResponse,
 	return core.SignTxResponse{Transaction: request.Transaction, Approved: false}, nil
 }
 
+func (alwaysDenyUI) ApprovePriorityTx(request *core.SignPriorityTxRequest) (core.SignPriorityTxResponse, error) {
+	return core.SignPriorityTxResponse{Approved: false}, nil
+}
+
 func (alwaysDenyUI) ApproveSignData(request *core.SignDataRequest) (core.SignDataResponse, error) {
 	return core.SignDataResponse{Approved: false}, nil
 }
@@ -194,6 +198,41 @@ func TestSignTxRequest(t *testing.T) {
 	}
 }
 
+func TestSignPriorityTxRequest(t *testing.T) {
+	js := `
+	function ApprovePriorityTx(r){
+		if(r.priority.toLowerCase()=="0x0000000000000000000000000000000000001337"){ return "Approve"}
+		return "Reject"
+	}`
+
+	r, err := initRuleEngine(js)
+	if err != nil {
+		t.Errorf("Couldn't create evaluator %v", err)
+		return
+	}
+	from, _ := mixAddr("000000000000000000000000000000000000dead")
+	for _, tt := range []struct {
+		priority string
+		approved bool
+	}{
+		{"0000000000000000000000000000000000001337", true},
+		{"000000000000000000000000000000000000dead", false},
+	} {
+		priority, _ := mixAddr(tt.priority)
+		resp, err := r.ApprovePriorityTx(&core.SignPriorityTxRequest{
+			Transaction: apitypes.SendTxArgs{From: *from},
+			Priority:    *priority,
+			Meta:        core.Metadata{Remote: "remoteip", Local: "localip", Scheme: "inproc"},
+		})
+		if err != nil {
+			t.Errorf("Unexpected error %v", err)
+		}
+		if resp.Approved != tt.approved {
+			t.Errorf("priority %s: approval mismatch: have %v, want %v", tt.priority, resp.Approved, tt.approved)
+		}
+	}
+}
+
 type dummyUI struct {
 	calls []string
 }
@@ -212,6 +251,11 @@ func (d *dummyUI) ApproveTx(request *core.SignTxRequest) (core.SignTxResponse, e
 	return core.SignTxResponse{}, core.ErrRequestDenied
 }
 
+func (d *dummyUI) ApprovePriorityTx(request *core.SignPriorityTxRequest) (core.SignPriorityTxResponse, error) {
+	d.calls = append(d.calls, "ApprovePriorityTx")
+	return core.SignPriorityTxResponse{}, core.ErrRequestDenied
+}
+
 func (d *dummyUI) ApproveSignData(request *core.SignDataRequest) (core.SignDataResponse, error) {
 	d.calls = append(d.calls, "ApproveSignData")
 	return core.SignDataResponse{}, core.ErrRequestDenied
@@ -509,6 +553,11 @@ func (d *dontCallMe) ApproveTx(request *core.SignTxRequest) (core.SignTxResponse
 	return core.SignTxResponse{}, core.ErrRequestDenied
 }
 
+func (d *dontCallMe) ApprovePriorityTx(request *core.SignPriorityTxRequest) (core.SignPriorityTxResponse, error) {
+	d.t.Fatalf("Did not expect next-handler to be called")
+	return core.SignPriorityTxResponse{}, core.ErrRequestDenied
+}
+
 func (d *dontCallMe) ApproveSignData(request *core.SignDataRequest) (core.SignDataResponse, error) {
 	d.t.Fatalf("Did not expect next-handler to be called")
 	return core.SignDataResponse{}, core.ErrRequestDenied
@@ -32,6 +32,7 @@ import (
 	"github.com/electroneum/electroneum-sc/log"
 	"github.com/electroneum/electroneum-sc/metrics"
 	"github.com/electroneum/electroneum-sc/rlp"
+	"github.com/electroneum/electroneum-sc/trie/pathdb"
 )
 
 var (
@@ -88,6 +89,8 @@ type Database struct {
 	childrenSize  common.StorageSize // Storage size of the external children tracking
 	preimagesSize common.StorageSize // Storage size of the preimages cache
 
+	pathdb *pathdb.Database // Path-based node storage, nil if the hash scheme is used
+
 	lock sync.RWMutex
 }
 
@@ -266,6 +269,8 @@ type Config struct {
 	Cache     int    // Memory allowance (MB) to use for caching trie nodes in memory
 	Journal   string // Journal of clean cache to survive node restarts
 	Preimages bool   // Flag whether the preimage of trie key is recorded
+	Scheme    string // Scheme used to store trie nodes on disk, hash if empty
+	Dirties   int    // Memory allowance (MB) to use for buffering dirty nodes (path scheme only)
 }
 
 // NewDatabase creates a new trie database to store ephemeral trie content before
@@ -279,6 +284,19 @@ func NewDatabase(diskdb ethdb.KeyValueStore) *Database {
 // before its written out to disk or garbage collected. It also acts as a read cache
 // for nodes loaded from disk.
 func NewDatabaseWithConfig(diskdb ethdb.KeyValueStore, config *Config) *Database {
+	if config != nil && config.Scheme == rawdb.PathScheme {
+		db := &Database{
+			diskdb: diskdb,
+			pathdb: pathdb.New(diskdb, &pathdb.Config{
+				CleanCacheSize: config.Cache * 1024 * 1024,
+				DirtyCacheSize: config.Dirties * 1024 * 1024,
+			}),
+		}
+		if config.Preimages {
+			db.preimages = make(map[common.Hash][]byte)
+		}
+		return db
+	}
 	var cleans *fastcache.Cache
 	if config != nil && config.Cache > 0 {
 		if config.Journal == "" {
@@ -305,6 +323,14 @@ func (db *Database) DiskDB() ethdb.KeyValueStore {
 	return db.diskdb
 }
 
+// Scheme returns the scheme used to store the trie nodes on disk.
+func (db *Database) Scheme() string {
+	if db.pathdb != nil {
+		return rawdb.PathScheme
+	}
+	return rawdb.HashScheme
+}
+
 // insert inserts a collapsed trie node into the memory database.
 // The blob size must be specified to allow proper size tracking.
 // All nodes inserted by this function will be reference tracked
@@ -399,6 +425,10 @@ func (db *Database) Node(hash common.Hash) ([]byte, error) {
 	if hash == (common.Hash{}) {
 		return nil, errors.New("not found")
 	}
+	// Nodes can't be looked up by hash alone in the path scheme
+	if db.pathdb != nil {
+		return nil, errors.New("not supported by path scheme")
+	}
 	// Retrieve the node from the clean cache if available
 	if db.cleans != nil {
 		if enc := db.cleans.Get(nil, hash[:]); enc != nil {
@@ -471,6 +501,10 @@ func (db *Database) Nodes() []common.Hash {
 // and external node(e.g. storage trie root), all internal trie nodes
 // are referenced together by database itself.
 func (db *Database) Reference(child common.Hash, parent common.Hash) {
+	// Nodes aren't reference counted in the path scheme
+	if db.pathdb != nil {
+		return
+	}
 	db.lock.Lock()
 	defer db.lock.Unlock()
 
@@ -505,6 +539,10 @@ func (db *Database) Dereference(root common.Hash) {
 		log.Error("Attempted to dereference the trie cache meta root")
 		return
 	}
+	// Stale nodes are overwritten in place in the path scheme
+	if db.pathdb != nil {
+		return
+	}
 	db.lock.Lock()
 	defer db.lock.Unlock()
 
@@ -579,6 +617,10 @@ func (db *Database) dereference(child common.Hash, parent common.Hash) {
 // Note, this method is a non-synchronized mutator. It is unsafe to call this
 // concurrently with other mutators.
 func (db *Database) Cap(limit common.StorageSize) error {
+	// The path-based storage caps its diff layers on every update
+	if db.pathdb != nil {
+		return nil
+	}
 	// Create a database batch to flush persistent data out. It is important that
 	// outside code doesn't see an inconsistent state (referenced data removed from
 	// memory cache during commit but not yet in persistent storage). This is ensured
@@ -682,6 +724,12 @@ func (db *Database) Cap(limit common.StorageSize) error {
 // Note, this method is a non-synchronized mutator. It is unsafe to call this
 // concurrently with other mutators.
 func (db *Database) Commit(node common.Hash, report bool, callback func(common.Hash)) error {
+	if db.pathdb != nil {
+		if err := db.commitPreimages(); err != nil {
+			return err
+		}
+		return db.pathdb.Commit(node, report)
+	}
 	// Create a database batch to flush persistent data out. It is important that
 	// outside code doesn't see an inconsistent state (referenced data removed from
 	// memory cache during commit but not yet in persistent storage). This is ensured
@@ -829,6 +877,9 @@ func (db *Database) Size() (common.StorageSize, common.StorageSize) {
 	db.lock.RLock()
 	defer db.lock.RUnlock()
 
+	if db.pathdb != nil {
+		return db.pathdb.Size(), db.preimagesSize
+	}
 	// db.dirtiesSize only contains the useful data in the cache, but when reporting
 	// the total memory consumption, the maintenance metadata is also needed to be
 	// counted.
@@ -837,6 +888,78 @@ func (db *Database) Size() (common.StorageSize, common.StorageSize) {
 	return db.dirtiesSize + db.childrenSize + metadataSize - metarootRefs, db.preimagesSize
 }
 
+// Update inserts the trie nodes modified by a state transition from the parent
+// state root to the given one. It's only supported by the path scheme, the hash
+// scheme receives the nodes as the tries are committed.
+//
+// Note, this method is a non-synchronized mutator. It is unsafe to call this
+// concurrently with other mutators.
+func (db *Database) Update(root common.Hash, parent common.Hash, nodes *MergedNodeSet) error {
+	if db.pathdb == nil {
+		return errors.New("not supported by hash scheme")
+	}
+	// If the preimage cache got large enough, push to disk. If it's still small
+	// leave for later to deduplicate writes.
+	db.lock.RLock()
+	flushPreimages := db.preimagesSize > 4*1024*1024
+	db.lock.RUnlock()
+
+	if flushPreimages {
+		if err := db.commitPreimages(); err != nil {
+			return err
+		}
+	}
+	return db.pathdb.Update(root, parent, nodes.flatten())
+}
+
+// commitPreimages writes the accumulated preimages to disk.
+func (db *Database) commitPreimages() error {
+	db.lock.Lock()
+	defer db.lock.Unlock()
+
+	if len(db.preimages) == 0 {
+		return nil
+	}
+	batch := db.diskdb.NewBatch()
+	rawdb.WritePreimages(batch, db.preimages)
+	if err := batch.Write(); err != nil {
+		return err
+	}
+	db.preimages, db.preimagesSize = make(map[common.Hash][]byte), 0
+	return nil
+}
+
+// Journal writes the in-memory state layers from the given state root down to
+// disk, so that they survive a restart. It's a noop for the hash scheme.
+func (db *Database) Journal(root common.Hash) error {
+	if db.pathdb == nil {
+		return nil
+	}
+	if err := db.commitPreimages(); err != nil {
+		return err
+	}
+	return db.pathdb.Journal(root)
+}
+
+// Reset wipes all the states stored with the path scheme. It's used when the
+// chain is rewound beyond the oldest state retained. It's a noop for the hash
+// scheme.
+func (db *Database) Reset() error {
+	if db.pathdb == nil {
+		return nil
+	}
+	return db.pathdb.Reset()
+}
+
+// Initialized reports whether the state of the given genesis was committed to
+// the database.
+func (db *Database) Initialized(genesisRoot common.Hash) bool {
+	if db.pathdb != nil {
+		return db.pathdb.Initialized(genesisRoot)
+	}
+	return genesisRoot == emptyRoot || rawdb.HasTrieNode(db.diskdb, genesisRoot)
+}
+
 // saveCache saves clean state cache to given directory path
 // using specified CPU cores.
 func (db *Database) saveCache(dir string, threads int) error {
@@ -0,0 +1,99 @@
+// Copyright 2022 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package trie
+
+import (
+	"github.com/electroneum/electroneum-sc/common"
+	"github.com/electroneum/electroneum-sc/trie/pathdb"
+)
+
+// NodeSet contains the trie nodes of a single trie written or deleted by a
+// commit, keyed by their path. It's produced when committing a trie backed by
+// the path scheme, where the nodes only enter the database along with the
+// state they belong to.
+type NodeSet struct {
+	owner common.Hash             // Owner of the trie, the zero hash for the account trie
+	nodes map[string]*pathdb.Node // Written and deleted nodes, keyed by path
+}
+
+// NewNodeSet creates an empty node set for the trie of the given owner.
+func NewNodeSet(owner common.Hash) *NodeSet {
+	return &NodeSet{
+		owner: owner,
+		nodes: make(map[string]*pathdb.Node),
+	}
+}
+
+// Owner returns the owner of the trie the nodes belong to.
+func (set *NodeSet) Owner() common.Hash {
+	return set.owner
+}
+
+// Size returns the number of written and deleted nodes in the set.
+func (set *NodeSet) Size() int {
+	return len(set.nodes)
+}
+
+// add inserts a written node into the set, overwriting any deletion marker at
+// the same path.
+func (set *NodeSet) add(path []byte, hash common.Hash, blob []byte) {
+	set.nodes[string(path)] = &pathdb.Node{Hash: hash, Blob: blob}
+}
+
+// MarkDeleted inserts a deletion marker into the set, unless a node was written
+// at the same path.
+func (set *NodeSet) MarkDeleted(path []byte) {
+	if _, ok := set.nodes[string(path)]; ok {
+		return
+	}
+	set.nodes[string(path)] = &pathdb.Node{}
+}
+
+// MergedNodeSet is the collection of the node sets of all the tries modified by
+// a state transition, keyed by trie owner.
+type MergedNodeSet struct {
+	sets map[common.Hash]*NodeSet
+}
+
+// NewMergedNodeSet creates an empty merged node set.
+func NewMergedNodeSet() *MergedNodeSet {
+	return &MergedNodeSet{
+		sets: make(map[common.Hash]*NodeSet),
+	}
+}
+
+// Merge adds the given node set into the collection. If a set of the same trie
+// was merged before, the nodes of the new set take precedence.
+func (set *MergedNodeSet) Merge(other *NodeSet) {
+	subset, ok := set.sets[other.owner]
+	if !ok {
+		set.sets[other.owner] = other
+		return
+	}
+	for path, n := range other.nodes {
+		subset.nodes[path] = n
+	}
+}
+
+// flatten returns the nodes of all the sets, keyed by owner and path.
+func (set *MergedNodeSet) flatten() map[common.Hash]map[string]*pathdb.Node {
+	nodes := make(map[common.Hash]map[string]*pathdb.Node, len(set.sets))
+	for owner, subset := range set.sets {
+		nodes[owner] = subset.nodes
+	}
+	return nodes
+}
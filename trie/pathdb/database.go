@@ -0,0 +1,382 @@
+// Copyright 2022 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+// Package pathdb implements the path-based trie node storage scheme.
+//
+// Trie nodes are stored on disk keyed by the path from the root of the trie
+// they belong to, rather than by their hash. Since a path holds a single node
+// at a time, the disk only ever contains one state: updating the state simply
+// overwrites the nodes it replaces and deletes the nodes it removes, so stale
+// state never accumulates and doesn't need to be pruned.
+//
+// The most recent states are kept in memory as a tree of diff layers on top of
+// the persistent disk layer, each diff layer holding the nodes modified by one
+// state transition. Once the diff layers stack up beyond a limit, the bottom
+// most one is merged into a write buffer of the disk layer, which is flushed
+// to disk as a single batch when it grows too large. The diff layers and the
+// write buffer are journalled to disk at shutdown.
+package pathdb
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/VictoriaMetrics/fastcache"
+	"github.com/electroneum/electroneum-sc/common"
+	"github.com/electroneum/electroneum-sc/core/rawdb"
+	"github.com/electroneum/electroneum-sc/core/types"
+	"github.com/electroneum/electroneum-sc/ethdb"
+	"github.com/electroneum/electroneum-sc/log"
+)
+
+const (
+	// maxDiffLayers is the maximum number of diff layers kept in memory on top
+	// of the disk layer. States older than that are only reachable until the
+	// disk layer's write buffer is flushed.
+	maxDiffLayers = 128
+
+	// defaultCleanSize is the default memory allowance of the clean node cache.
+	defaultCleanSize = 16 * 1024 * 1024
+
+	// defaultBufferSize is the default memory allowance of the disk layer's
+	// write buffer.
+	defaultBufferSize = 64 * 1024 * 1024
+)
+
+var (
+	// errMissingNode is returned if the requested node can't be found in any
+	// of the layers, or on disk.
+	errMissingNode = errors.New("missing trie node")
+
+	// errLayerCycle is returned if a state update would link a layer to itself.
+	errLayerCycle = errors.New("layer cycle")
+
+	// ErrStateUnavailable is returned if the nodes of a state are requested which
+	// isn't part of the layer tree, either because it's unknown or because it was
+	// merged into the disk layer by a more recent state.
+	ErrStateUnavailable = errors.New("state unavailable")
+)
+
+// Node is a trie node blob along with its hash. A node without a blob marks the
+// node at its path as deleted.
+type Node struct {
+	Hash common.Hash // Hash of the node, the zero hash for deleted nodes
+	Blob []byte      // Encoded node, empty for deleted nodes
+}
+
+// IsDeleted reports whether the node is a deletion marker.
+func (n *Node) IsDeleted() bool {
+	return len(n.Blob) == 0
+}
+
+// size returns the approximate memory used by the node.
+func (n *Node) size() uint64 {
+	return uint64(common.HashLength + len(n.Blob))
+}
+
+// layer is a set of trie nodes belonging to a state, either the persistent disk
+// layer or an in-memory diff layer on top of it.
+type layer interface {
+	// rootHash returns the root hash of the state the layer represents.
+	rootHash() common.Hash
+
+	// parentLayer returns the layer the current one was built on top of, or
+	// nil for the disk layer.
+	parentLayer() layer
+}
+
+// Config contains the settings of the path-based trie node storage.
+type Config struct {
+	CleanCacheSize int // Memory allowance (in bytes) for caching clean nodes
+	DirtyCacheSize int // Memory allowance (in bytes) for buffering dirty nodes before flushing them to disk
+}
+
+// Database is the path-based trie node storage. It holds the persistent disk
+// layer and the in-memory diff layers of the recent states on top of it.
+//
+// Contrary to the hash-based storage, nodes are retrieved by owner, path and
+// hash through the Reader of the state they belong to, as the same path holds
+// different nodes in different states.
+//
+// The Database is thread safe.
+type Database struct {
+	diskdb ethdb.KeyValueStore // Persistent storage for the flushed trie nodes
+	config *Config             // Configuration of the storage
+	cleans *fastcache.Cache    // GC friendly memory cache of clean node blobs
+
+	disk   *diskLayer            // Persistent layer at the bottom of the tree
+	layers map[common.Hash]layer // Disk layer and diff layers, keyed by state root
+	lock   sync.RWMutex          // Lock protecting the layer tree
+}
+
+// New opens the path-based trie node storage on top of the given database,
+// restoring the in-memory layers from the journal if it matches the state
+// persisted on disk.
+func New(diskdb ethdb.KeyValueStore, config *Config) *Database {
+	conf := Config{
+		CleanCacheSize: defaultCleanSize,
+		DirtyCacheSize: defaultBufferSize,
+	}
+	if config != nil {
+		if config.CleanCacheSize > 0 {
+			conf.CleanCacheSize = config.CleanCacheSize
+		}
+		if config.DirtyCacheSize > 0 {
+			conf.DirtyCacheSize = config.DirtyCacheSize
+		}
+	}
+	db := &Database{
+		diskdb: diskdb,
+		config: &conf,
+		cleans: fastcache.New(conf.CleanCacheSize),
+	}
+	db.loadLayers()
+	return db
+}
+
+// persistedRoot returns the root hash of the state stored on disk.
+func persistedRoot(diskdb ethdb.KeyValueReader) common.Hash {
+	blob, hash := rawdb.ReadAccountTrieNode(diskdb, nil)
+	if len(blob) == 0 {
+		return types.EmptyRootHash
+	}
+	return hash
+}
+
+// Reader returns a reader for the trie nodes of the state with the given root,
+// or ErrStateUnavailable if the state isn't part of the layer tree.
+func (db *Database) Reader(root common.Hash) (*Reader, error) {
+	db.lock.RLock()
+	defer db.lock.RUnlock()
+
+	if _, ok := db.layers[root]; !ok {
+		return nil, fmt.Errorf("%w: %#x", ErrStateUnavailable, root)
+	}
+	return &Reader{db: db, root: root}, nil
+}
+
+// Reader retrieves the trie nodes of a single state, looking them up in the
+// layer of the state and the layers below it, down to the disk layer.
+type Reader struct {
+	db   *Database
+	root common.Hash // Root hash of the state the nodes are retrieved for
+}
+
+// Node retrieves the encoded trie node with the given owner (the zero hash for
+// the account trie), path and hash. The topmost layer holding a node at the path
+// determines the node of the state, a different hash means the requested node
+// isn't part of it.
+func (r *Reader) Node(owner common.Hash, path []byte, hash common.Hash) ([]byte, error) {
+	r.db.lock.RLock()
+	defer r.db.lock.RUnlock()
+
+	// The state may have been merged into the disk layer since the reader was
+	// created, its nodes are partially overwritten in that case.
+	l, ok := r.db.layers[r.root]
+	if !ok {
+		return nil, fmt.Errorf("%w: %#x", ErrStateUnavailable, r.root)
+	}
+	for {
+		switch dl := l.(type) {
+		case *diffLayer:
+			if blob, found, err := dl.node(owner, path, hash); found {
+				return blob, err
+			}
+			l = dl.parent
+		case *diskLayer:
+			return dl.node(owner, path, hash)
+		default:
+			panic(fmt.Sprintf("unknown layer type %T", l))
+		}
+	}
+}
+
+// Update adds a new diff layer on top of the layer of the parent state, holding
+// the trie nodes modified by the state transition. The layers beyond the allowed
+// number of diff layers are merged into the disk layer.
+func (db *Database) Update(root common.Hash, parentRoot common.Hash, nodes map[common.Hash]map[string]*Node) error {
+	// Reject noop updates to avoid self-loops in the layer tree. It's up to
+	// the caller to not update the database if the state didn't change.
+	if root == parentRoot {
+		return errLayerCycle
+	}
+	db.lock.Lock()
+	defer db.lock.Unlock()
+
+	// Identical states share the same layer
+	if _, ok := db.layers[root]; ok {
+		return nil
+	}
+	parent := db.layers[parentRoot]
+	if parent == nil {
+		return fmt.Errorf("triedb parent [%#x] layer missing", parentRoot)
+	}
+	db.layers[root] = newDiffLayer(parent, root, nodes)
+
+	return db.cap(root, maxDiffLayers, false)
+}
+
+// Commit merges all the diff layers up to and including the given state into the
+// disk layer, and writes them out to disk together with the disk layer's buffer.
+// All states that don't descend from the given one are discarded.
+func (db *Database) Commit(root common.Hash, report bool) error {
+	db.lock.Lock()
+	defer db.lock.Unlock()
+
+	if _, ok := db.layers[root]; !ok {
+		return fmt.Errorf("triedb layer [%#x] missing", root)
+	}
+	start := time.Now()
+	nodes, size := db.disk.buffer.count(), db.disk.buffer.size
+	if err := db.cap(root, 0, true); err != nil {
+		return err
+	}
+	logger := log.Info
+	if !report {
+		logger = log.Debug
+	}
+	logger("Persisted trie from memory database", "root", root, "buffered", nodes, "bufsize", common.StorageSize(size), "time", common.PrettyDuration(time.Since(start)))
+	return nil
+}
+
+// cap flattens the diff layers below the given state into the disk layer until
+// the number of diff layers on top of the disk layer (the given one included)
+// doesn't exceed the allowed number. If force is set, the disk layer's buffer
+// is written to disk regardless of its size. Finally, all layers that don't
+// descend from the new disk layer are removed.
+//
+// Note, this method assumes that the database's lock is held!
+func (db *Database) cap(root common.Hash, layers int, force bool) error {
+	// Collect the diff layers from the given one down to the disk layer
+	var chain []*diffLayer
+	for l := db.layers[root]; ; {
+		diff, ok := l.(*diffLayer)
+		if !ok {
+			break
+		}
+		chain = append(chain, diff)
+		l = diff.parent
+	}
+	if len(chain) <= layers {
+		if force {
+			return db.disk.flush(true)
+		}
+		return nil
+	}
+	// Merge the layers beyond the permitted number into the disk layer, oldest
+	// first, and relink the oldest remaining diff layer onto the new disk layer.
+	var (
+		disk = db.disk
+		err  error
+	)
+	for i := len(chain) - 1; i >= layers; i-- {
+		if disk, err = disk.commit(chain[i], force && i == layers); err != nil {
+			return err
+		}
+	}
+	if layers > 0 {
+		chain[layers-1].parent = disk
+	}
+	db.disk = disk
+
+	// Drop the merged layers, as well as every layer not descending from the new
+	// disk layer, as those states are gone from disk.
+	descends := map[layer]bool{disk: true}
+	var check func(l layer) bool
+	check = func(l layer) bool {
+		if ok, known := descends[l]; known {
+			return ok
+		}
+		parent := l.parentLayer()
+		ok := parent != nil && check(parent)
+		descends[l] = ok
+		return ok
+	}
+	for hash, l := range db.layers {
+		if !check(l) {
+			delete(db.layers, hash)
+		}
+	}
+	db.layers[disk.root] = disk
+	return nil
+}
+
+// Size returns the memory used by the diff layers and the disk layer's buffer.
+func (db *Database) Size() common.StorageSize {
+	db.lock.RLock()
+	defer db.lock.RUnlock()
+
+	size := db.disk.buffer.size
+	for _, l := range db.layers {
+		if diff, ok := l.(*diffLayer); ok {
+			size += diff.memory
+		}
+	}
+	return common.StorageSize(size)
+}
+
+// Initialized reports whether any state was committed to the database. As the
+// path scheme only keeps the recent states, the genesis state is usually gone
+// once the chain progressed.
+func (db *Database) Initialized(genesisRoot common.Hash) bool {
+	db.lock.RLock()
+	defer db.lock.RUnlock()
+
+	return genesisRoot == types.EmptyRootHash || db.disk.root != types.EmptyRootHash || len(db.layers) > 1
+}
+
+// Reset wipes all the trie nodes from disk, along with the in-memory layers and
+// the journal, leaving an empty state. It's used when the chain is rewound past
+// the oldest state available.
+func (db *Database) Reset() error {
+	db.lock.Lock()
+	defer db.lock.Unlock()
+
+	batch := db.diskdb.NewBatch()
+	for _, prefix := range [][]byte{rawdb.TrieNodeAccountPrefix, rawdb.TrieNodeStoragePrefix} {
+		it := db.diskdb.NewIterator(prefix, nil)
+		for it.Next() {
+			key := it.Key()
+			if !rawdb.IsAccountTrieNode(key) && !rawdb.IsStorageTrieNode(key) {
+				continue
+			}
+			batch.Delete(key)
+			if batch.ValueSize() >= ethdb.IdealBatchSize {
+				if err := batch.Write(); err != nil {
+					it.Release()
+					return err
+				}
+				batch.Reset()
+			}
+		}
+		it.Release()
+		if err := it.Error(); err != nil {
+			return err
+		}
+	}
+	rawdb.DeleteTrieJournal(batch)
+	if err := batch.Write(); err != nil {
+		return err
+	}
+	db.cleans.Reset()
+	db.disk = newDiskLayer(types.EmptyRootHash, db, newNodeBuffer(db.config.DirtyCacheSize, nil, 0))
+	db.layers = map[common.Hash]layer{db.disk.root: db.disk}
+
+	log.Info("Wiped the trie nodes of the path-based state")
+	return nil
+}
@@ -0,0 +1,277 @@
+// Copyright 2022 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package pathdb
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/electroneum/electroneum-sc/common"
+	"github.com/electroneum/electroneum-sc/core/rawdb"
+	"github.com/electroneum/electroneum-sc/core/types"
+	"github.com/electroneum/electroneum-sc/crypto"
+)
+
+var testOwner = common.HexToHash("0x01")
+
+// makeState creates the nodes of a fake state transition, with an account trie
+// root node and a storage trie node both depending on the given index. The root
+// hash of the state is the hash of the account trie root node.
+func makeState(index int) (common.Hash, map[common.Hash]map[string]*Node) {
+	var (
+		root    = []byte(fmt.Sprintf("account root %d", index))
+		storage = []byte(fmt.Sprintf("storage node %d", index))
+	)
+	nodes := map[common.Hash]map[string]*Node{
+		{}: {
+			"": {Hash: crypto.Keccak256Hash(root), Blob: root},
+		},
+		testOwner: {
+			"\x01\x02": {Hash: crypto.Keccak256Hash(storage), Blob: storage},
+		},
+	}
+	return crypto.Keccak256Hash(root), nodes
+}
+
+// checkState checks that the nodes of the state with the given index can be
+// retrieved from the database.
+func checkState(t *testing.T, db *Database, index int) {
+	t.Helper()
+
+	root, nodes := makeState(index)
+	reader, err := db.Reader(root)
+	if err != nil {
+		t.Fatalf("state %d: failed to create reader: %v", index, err)
+	}
+	for owner, subset := range nodes {
+		for path, n := range subset {
+			blob, err := reader.Node(owner, []byte(path), n.Hash)
+			if err != nil {
+				t.Fatalf("state %d: failed to retrieve node %x:%x: %v", index, owner, path, err)
+			}
+			if !bytes.Equal(blob, n.Blob) {
+				t.Fatalf("state %d: node %x:%x mismatch: have %x, want %x", index, owner, path, blob, n.Blob)
+			}
+		}
+	}
+}
+
+// buildChain stacks the given number of states on top of the empty state.
+func buildChain(t *testing.T, db *Database, n int) []common.Hash {
+	t.Helper()
+
+	parent := types.EmptyRootHash
+	roots := make([]common.Hash, 0, n)
+	for i := 0; i < n; i++ {
+		root, nodes := makeState(i)
+		if err := db.Update(root, parent, nodes); err != nil {
+			t.Fatalf("state %d: failed to update database: %v", i, err)
+		}
+		roots = append(roots, root)
+		parent = root
+	}
+	return roots
+}
+
+// Tests that the nodes of all the states in the diff layers can be retrieved,
+// and that the nodes are written to disk on commit.
+func TestDatabaseUpdate(t *testing.T) {
+	diskdb := rawdb.NewMemoryDatabase()
+	db := New(diskdb, nil)
+	if db.Initialized(common.HexToHash("0xdeadbeef")) {
+		t.Fatalf("empty database reported as initialized")
+	}
+	roots := buildChain(t, db, 10)
+	for i := range roots {
+		checkState(t, db, i)
+	}
+	if err := db.Update(roots[5], roots[5], nil); err == nil {
+		t.Fatalf("self-referencing update accepted")
+	}
+	if err := db.Update(common.HexToHash("0x02"), common.HexToHash("0x03"), nil); err == nil {
+		t.Fatalf("update with unknown parent accepted")
+	}
+	if blob, _ := rawdb.ReadAccountTrieNode(diskdb, nil); len(blob) != 0 {
+		t.Fatalf("diff layers written to disk before commit")
+	}
+	if err := db.Commit(roots[9], false); err != nil {
+		t.Fatalf("failed to commit database: %v", err)
+	}
+	if root := persistedRoot(diskdb); root != roots[9] {
+		t.Fatalf("persisted root mismatch: have %x, want %x", root, roots[9])
+	}
+	// Only the committed state survives a restart, the older ones are gone
+	db = New(diskdb, nil)
+	if !db.Initialized(common.HexToHash("0xdeadbeef")) {
+		t.Fatalf("committed database reported as uninitialized")
+	}
+	checkState(t, db, 9)
+	if _, err := db.Reader(roots[8]); !errors.Is(err, ErrStateUnavailable) {
+		t.Fatalf("reader of a discarded state error mismatch: have %v, want %v", err, ErrStateUnavailable)
+	}
+	reader, _ := db.Reader(roots[9])
+	_, nodes := makeState(8)
+	if _, err := reader.Node(common.Hash{}, nil, nodes[common.Hash{}][""].Hash); err == nil {
+		t.Fatalf("overwritten node retrieved")
+	}
+}
+
+// Tests that a reader only retrieves the nodes of its own state, even if other
+// states in the layer tree hold the requested node, and that it stops working
+// once its state is merged into the disk layer.
+func TestDatabaseReader(t *testing.T) {
+	db := New(rawdb.NewMemoryDatabase(), nil)
+	roots := buildChain(t, db, 10)
+
+	if _, err := db.Reader(common.HexToHash("0xdeadbeef")); !errors.Is(err, ErrStateUnavailable) {
+		t.Fatalf("reader of an unknown state error mismatch: have %v, want %v", err, ErrStateUnavailable)
+	}
+	reader, err := db.Reader(roots[5])
+	if err != nil {
+		t.Fatalf("failed to create reader: %v", err)
+	}
+	// Nodes of both the descendant and the ancestor states are shadowed
+	for _, index := range []int{3, 7} {
+		_, nodes := makeState(index)
+		for owner, subset := range nodes {
+			for path, n := range subset {
+				if _, err := reader.Node(owner, []byte(path), n.Hash); err == nil {
+					t.Fatalf("node %x:%x of state %d retrieved", owner, path, index)
+				}
+			}
+		}
+	}
+	// A state without its own node at a path shares the one of its parent
+	side := crypto.Keccak256Hash([]byte("side root"))
+	if err := db.Update(side, roots[5], map[common.Hash]map[string]*Node{
+		{}: {"": {Hash: side, Blob: []byte("side root")}},
+	}); err != nil {
+		t.Fatalf("failed to update database: %v", err)
+	}
+	sideReader, _ := db.Reader(side)
+	_, nodes := makeState(5)
+	if blob, err := sideReader.Node(testOwner, []byte{0x01, 0x02}, nodes[testOwner]["\x01\x02"].Hash); err != nil || !bytes.Equal(blob, nodes[testOwner]["\x01\x02"].Blob) {
+		t.Fatalf("parent node mismatch: have %x, %v", blob, err)
+	}
+	// Merging the state into the disk layer invalidates the reader
+	if err := db.Commit(roots[9], false); err != nil {
+		t.Fatalf("failed to commit database: %v", err)
+	}
+	if _, err := reader.Node(testOwner, []byte{0x01, 0x02}, nodes[testOwner]["\x01\x02"].Hash); !errors.Is(err, ErrStateUnavailable) {
+		t.Fatalf("stale reader error mismatch: have %v, want %v", err, ErrStateUnavailable)
+	}
+}
+
+// Tests that deletion markers remove the nodes from disk once flushed.
+func TestDatabaseDelete(t *testing.T) {
+	diskdb := rawdb.NewMemoryDatabase()
+	db := New(diskdb, nil)
+
+	root, nodes := makeState(0)
+	if err := db.Update(root, types.EmptyRootHash, nodes); err != nil {
+		t.Fatalf("failed to update database: %v", err)
+	}
+	next, nodes := makeState(1)
+	nodes[testOwner] = map[string]*Node{"\x01\x02": {}}
+	if err := db.Update(next, root, nodes); err != nil {
+		t.Fatalf("failed to update database: %v", err)
+	}
+	if err := db.Commit(next, false); err != nil {
+		t.Fatalf("failed to commit database: %v", err)
+	}
+	if blob, _ := rawdb.ReadStorageTrieNode(diskdb, testOwner, []byte{0x01, 0x02}); len(blob) != 0 {
+		t.Fatalf("deleted node still present on disk: %x", blob)
+	}
+}
+
+// Tests that the diff layers beyond the allowed number are merged into the disk
+// layer, dropping the states that don't descend from the new disk layer.
+func TestDatabaseCap(t *testing.T) {
+	db := New(rawdb.NewMemoryDatabase(), nil)
+	roots := buildChain(t, db, maxDiffLayers+10)
+
+	if len(db.layers) != maxDiffLayers+1 {
+		t.Fatalf("layer count mismatch: have %d, want %d", len(db.layers), maxDiffLayers+1)
+	}
+	if db.disk.root != roots[9] {
+		t.Fatalf("disk layer root mismatch: have %x, want %x", db.disk.root, roots[9])
+	}
+	for i := 9; i < len(roots); i++ {
+		checkState(t, db, i)
+	}
+	// Side states on top of the merged layers can't be added anymore
+	side, nodes := makeState(-1)
+	if err := db.Update(side, roots[5], nodes); err == nil {
+		t.Fatalf("update on top of a merged layer accepted")
+	}
+}
+
+// Tests that the diff layers and the disk layer's buffer survive a restart
+// through the journal.
+func TestDatabaseJournal(t *testing.T) {
+	diskdb := rawdb.NewMemoryDatabase()
+	db := New(diskdb, nil)
+	roots := buildChain(t, db, maxDiffLayers+10)
+
+	if err := db.Journal(roots[len(roots)-1]); err != nil {
+		t.Fatalf("failed to journal database: %v", err)
+	}
+	db = New(diskdb, nil)
+	if len(db.layers) != maxDiffLayers+1 {
+		t.Fatalf("layer count mismatch: have %d, want %d", len(db.layers), maxDiffLayers+1)
+	}
+	for i := 9; i < len(roots); i++ {
+		checkState(t, db, i)
+	}
+	// A journal not matching the persisted state is discarded
+	rawdb.WriteAccountTrieNode(diskdb, nil, []byte("corrupted root"))
+	db = New(diskdb, nil)
+	if len(db.layers) != 1 {
+		t.Fatalf("unmatched journal loaded: %d layers", len(db.layers))
+	}
+}
+
+// Tests that resetting the database wipes all the trie nodes and the journal.
+func TestDatabaseReset(t *testing.T) {
+	diskdb := rawdb.NewMemoryDatabase()
+	db := New(diskdb, nil)
+	roots := buildChain(t, db, 5)
+
+	if err := db.Commit(roots[4], false); err != nil {
+		t.Fatalf("failed to commit database: %v", err)
+	}
+	if err := db.Journal(roots[4]); err != nil {
+		t.Fatalf("failed to journal database: %v", err)
+	}
+	if err := db.Reset(); err != nil {
+		t.Fatalf("failed to reset database: %v", err)
+	}
+	if root := persistedRoot(diskdb); root != types.EmptyRootHash {
+		t.Fatalf("persisted root mismatch: have %x, want %x", root, types.EmptyRootHash)
+	}
+	if blob, _ := rawdb.ReadStorageTrieNode(diskdb, testOwner, []byte{0x01, 0x02}); len(blob) != 0 {
+		t.Fatalf("storage node survived reset: %x", blob)
+	}
+	if journal := rawdb.ReadTrieJournal(diskdb); len(journal) != 0 {
+		t.Fatalf("journal survived reset")
+	}
+	if db.Initialized(common.HexToHash("0xdeadbeef")) {
+		t.Fatalf("reset database reported as initialized")
+	}
+}
@@ -0,0 +1,82 @@
+// Copyright 2022 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package pathdb
+
+import (
+	"github.com/electroneum/electroneum-sc/common"
+)
+
+// diffLayer represents a collection of modifications made to the trie nodes
+// by a state transition, on top of the layer of the parent state. Diff layers
+// are immutable, the only field changed after creation is the parent, which
+// is relinked when the parent layer gets merged into the disk layer.
+type diffLayer struct {
+	parent layer       // Parent layer modified by this one, never nil
+	root   common.Hash // Root hash of the state this layer represents
+	memory uint64      // Approximate memory used by the layer
+
+	nodes map[common.Hash]map[string]*Node // Modified trie nodes, keyed by owner and path
+}
+
+// newDiffLayer creates a new diff layer on top of an existing layer.
+func newDiffLayer(parent layer, root common.Hash, nodes map[common.Hash]map[string]*Node) *diffLayer {
+	dl := &diffLayer{
+		parent: parent,
+		root:   root,
+		nodes:  nodes,
+	}
+	for _, subset := range nodes {
+		for path, n := range subset {
+			dl.memory += uint64(len(path)) + n.size()
+		}
+	}
+	dirtyWriteMeter.Mark(int64(dl.memory))
+	return dl
+}
+
+// rootHash implements the layer interface, returning the root hash of the state
+// this layer represents.
+func (dl *diffLayer) rootHash() common.Hash {
+	return dl.root
+}
+
+// parentLayer implements the layer interface, returning the layer this one was
+// built on top of.
+func (dl *diffLayer) parentLayer() layer {
+	return dl.parent
+}
+
+// node retrieves the node with the given owner, path and hash if this layer
+// modified the node at the path, reporting whether it did. A modified node
+// shadows the nodes of the layers below, so if its hash differs (or it's a
+// deletion marker), the requested node isn't part of the state.
+func (dl *diffLayer) node(owner common.Hash, path []byte, hash common.Hash) ([]byte, bool, error) {
+	subset, ok := dl.nodes[owner]
+	if !ok {
+		return nil, false, nil
+	}
+	n, ok := subset[string(path)]
+	if !ok {
+		return nil, false, nil
+	}
+	if n.Hash != hash || n.IsDeleted() {
+		return nil, true, errMissingNode
+	}
+	dirtyHitMeter.Mark(1)
+	dirtyReadMeter.Mark(int64(len(n.Blob)))
+	return n.Blob, true, nil
+}
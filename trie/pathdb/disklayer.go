@@ -0,0 +1,235 @@
+// Copyright 2022 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package pathdb
+
+import (
+	"time"
+
+	"github.com/VictoriaMetrics/fastcache"
+	"github.com/electroneum/electroneum-sc/common"
+	"github.com/electroneum/electroneum-sc/core/rawdb"
+	"github.com/electroneum/electroneum-sc/crypto"
+	"github.com/electroneum/electroneum-sc/ethdb"
+	"github.com/electroneum/electroneum-sc/log"
+)
+
+// diskLayer is the persistent layer at the bottom of the layer tree. The state
+// it represents is the one stored on disk, with the nodes of its write buffer
+// applied on top.
+type diskLayer struct {
+	root   common.Hash // Root hash of the state this layer represents
+	db     *Database   // Database the layer belongs to
+	buffer *nodebuffer // Merged diff layers not yet written to disk
+}
+
+// newDiskLayer creates a new disk layer with the given buffer.
+func newDiskLayer(root common.Hash, db *Database, buffer *nodebuffer) *diskLayer {
+	return &diskLayer{
+		root:   root,
+		db:     db,
+		buffer: buffer,
+	}
+}
+
+// rootHash implements the layer interface, returning the root hash of the state
+// this layer represents.
+func (dl *diskLayer) rootHash() common.Hash {
+	return dl.root
+}
+
+// parentLayer implements the layer interface, returning nil as there's no layer
+// below the disk layer.
+func (dl *diskLayer) parentLayer() layer {
+	return nil
+}
+
+// node retrieves the node with the given owner, path and hash from the write
+// buffer, the clean cache or the disk, in this order.
+func (dl *diskLayer) node(owner common.Hash, path []byte, hash common.Hash) ([]byte, error) {
+	// A different node in the buffer may shadow the one on disk, which is then
+	// the node of a state older than this layer. It's still the right node for
+	// the requested hash though.
+	if n, ok := dl.buffer.node(owner, path); ok && n.Hash == hash {
+		dirtyHitMeter.Mark(1)
+		dirtyReadMeter.Mark(int64(len(n.Blob)))
+		return n.Blob, nil
+	}
+	dirtyMissMeter.Mark(1)
+
+	key := cacheKey(owner, path)
+	if blob := dl.db.cleans.Get(nil, key); len(blob) > 0 && crypto.Keccak256Hash(blob) == hash {
+		cleanHitMeter.Mark(1)
+		cleanReadMeter.Mark(int64(len(blob)))
+		return blob, nil
+	}
+	cleanMissMeter.Mark(1)
+
+	var (
+		blob  []byte
+		nHash common.Hash
+	)
+	if owner == (common.Hash{}) {
+		blob, nHash = rawdb.ReadAccountTrieNode(dl.db.diskdb, path)
+	} else {
+		blob, nHash = rawdb.ReadStorageTrieNode(dl.db.diskdb, owner, path)
+	}
+	if len(blob) == 0 || nHash != hash {
+		return nil, errMissingNode
+	}
+	dl.db.cleans.Set(key, blob)
+	cleanWriteMeter.Mark(int64(len(blob)))
+	return blob, nil
+}
+
+// commit merges the given bottom-most diff layer into the write buffer and
+// returns the new disk layer representing the state of the diff layer. The
+// buffer is written to disk if it grew too large, or if force is set.
+func (dl *diskLayer) commit(bottom *diffLayer, force bool) (*diskLayer, error) {
+	dl.buffer.commit(bottom.nodes)
+
+	ndl := newDiskLayer(bottom.root, dl.db, dl.buffer)
+	if err := ndl.flush(force); err != nil {
+		return nil, err
+	}
+	return ndl, nil
+}
+
+// flush writes the buffer out to disk if it exceeds the allowed size, or if
+// force is set.
+func (dl *diskLayer) flush(force bool) error {
+	if !force && dl.buffer.size <= dl.buffer.limit {
+		return nil
+	}
+	return dl.buffer.flush(dl.db.diskdb, dl.db.cleans)
+}
+
+// cacheKey constructs the key of a node in the clean cache.
+func cacheKey(owner common.Hash, path []byte) []byte {
+	if owner == (common.Hash{}) {
+		return path
+	}
+	return append(owner.Bytes(), path...)
+}
+
+// nodebuffer is a collection of modified trie nodes merged from the diff layers
+// flattened into the disk layer. It's written out to disk at once, so that the
+// state on disk always moves from one consistent state to the next.
+type nodebuffer struct {
+	nodes map[common.Hash]map[string]*Node // Buffered trie nodes, keyed by owner and path
+	size  uint64                           // Approximate memory used by the buffered nodes
+	limit uint64                           // Maximum memory allowance (in bytes) for the buffer
+}
+
+// newNodeBuffer creates a node buffer with the given nodes.
+func newNodeBuffer(limit int, nodes map[common.Hash]map[string]*Node, size uint64) *nodebuffer {
+	if nodes == nil {
+		nodes = make(map[common.Hash]map[string]*Node)
+	}
+	return &nodebuffer{
+		nodes: nodes,
+		size:  size,
+		limit: uint64(limit),
+	}
+}
+
+// node retrieves the buffered node at the given owner and path, if any.
+func (b *nodebuffer) node(owner common.Hash, path []byte) (*Node, bool) {
+	subset, ok := b.nodes[owner]
+	if !ok {
+		return nil, false
+	}
+	n, ok := subset[string(path)]
+	return n, ok
+}
+
+// count returns the number of buffered nodes.
+func (b *nodebuffer) count() int {
+	var count int
+	for _, subset := range b.nodes {
+		count += len(subset)
+	}
+	return count
+}
+
+// commit merges the given nodes into the buffer, overwriting the nodes at the
+// same paths.
+func (b *nodebuffer) commit(nodes map[common.Hash]map[string]*Node) {
+	for owner, subset := range nodes {
+		current, ok := b.nodes[owner]
+		if !ok {
+			current = make(map[string]*Node, len(subset))
+			b.nodes[owner] = current
+		}
+		for path, n := range subset {
+			if orig, exist := current[path]; exist {
+				b.size -= orig.size()
+			} else {
+				b.size += uint64(len(path))
+			}
+			b.size += n.size()
+			current[path] = n
+		}
+	}
+}
+
+// flush writes the buffered nodes to disk in a single batch and empties the
+// buffer. The clean cache is updated with the written nodes.
+func (b *nodebuffer) flush(db ethdb.KeyValueStore, cleans *fastcache.Cache) error {
+	var (
+		start = time.Now()
+		count = b.count()
+		batch = db.NewBatch()
+	)
+	for owner, subset := range b.nodes {
+		for path, n := range subset {
+			if owner == (common.Hash{}) {
+				if n.IsDeleted() {
+					rawdb.DeleteAccountTrieNode(batch, []byte(path))
+				} else {
+					rawdb.WriteAccountTrieNode(batch, []byte(path), n.Blob)
+				}
+			} else {
+				if n.IsDeleted() {
+					rawdb.DeleteStorageTrieNode(batch, owner, []byte(path))
+				} else {
+					rawdb.WriteStorageTrieNode(batch, owner, []byte(path), n.Blob)
+				}
+			}
+		}
+	}
+	// The buffer is written in one go, a partial write would leave the disk
+	// with a mix of two states.
+	if err := batch.Write(); err != nil {
+		return err
+	}
+	for owner, subset := range b.nodes {
+		for path, n := range subset {
+			if n.IsDeleted() {
+				cleans.Del(cacheKey(owner, []byte(path)))
+			} else {
+				cleans.Set(cacheKey(owner, []byte(path)), n.Blob)
+			}
+		}
+	}
+	flushTimeTimer.UpdateSince(start)
+	flushNodesMeter.Mark(int64(count))
+	flushSizeMeter.Mark(int64(b.size))
+	log.Debug("Persisted buffered trie nodes", "nodes", count, "size", common.StorageSize(b.size), "elapsed", common.PrettyDuration(time.Since(start)))
+
+	b.nodes, b.size = make(map[common.Hash]map[string]*Node), 0
+	return nil
+}
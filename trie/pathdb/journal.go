@@ -0,0 +1,204 @@
+// Copyright 2022 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package pathdb
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/electroneum/electroneum-sc/common"
+	"github.com/electroneum/electroneum-sc/core/rawdb"
+	"github.com/electroneum/electroneum-sc/crypto"
+	"github.com/electroneum/electroneum-sc/log"
+	"github.com/electroneum/electroneum-sc/rlp"
+)
+
+const journalVersion uint64 = 0
+
+var (
+	// errMissingJournal is returned if the journal is not found in the database.
+	errMissingJournal = errors.New("journal not found")
+
+	// errUnmatchedJournal is returned if the journal was written on top of a
+	// different state than the one persisted on disk.
+	errUnmatchedJournal = errors.New("unmatched journal")
+)
+
+// journalNode is a trie node entry in a layer's disk journal.
+type journalNode struct {
+	Path []byte
+	Blob []byte
+}
+
+// journalNodes is the set of trie nodes of a single trie in a layer's disk journal.
+type journalNodes struct {
+	Owner common.Hash
+	Nodes []journalNode
+}
+
+// encodeNodes converts a set of trie nodes into its journal representation.
+func encodeNodes(nodes map[common.Hash]map[string]*Node) []journalNodes {
+	ret := make([]journalNodes, 0, len(nodes))
+	for owner, subset := range nodes {
+		entry := journalNodes{Owner: owner, Nodes: make([]journalNode, 0, len(subset))}
+		for path, n := range subset {
+			entry.Nodes = append(entry.Nodes, journalNode{Path: []byte(path), Blob: n.Blob})
+		}
+		ret = append(ret, entry)
+	}
+	return ret
+}
+
+// decodeNodes converts the journal representation of a set of trie nodes back
+// into the set, rehashing the node blobs.
+func decodeNodes(entries []journalNodes) map[common.Hash]map[string]*Node {
+	nodes := make(map[common.Hash]map[string]*Node, len(entries))
+	for _, entry := range entries {
+		subset := make(map[string]*Node, len(entry.Nodes))
+		for _, n := range entry.Nodes {
+			if len(n.Blob) > 0 {
+				subset[string(n.Path)] = &Node{Hash: crypto.Keccak256Hash(n.Blob), Blob: n.Blob}
+			} else {
+				subset[string(n.Path)] = &Node{}
+			}
+		}
+		nodes[entry.Owner] = subset
+	}
+	return nodes
+}
+
+// loadLayers restores the layer tree from the journal, or starts from a bare
+// disk layer of the persisted state if the journal is missing or unusable.
+func (db *Database) loadLayers() {
+	root := persistedRoot(db.diskdb)
+	if err := db.loadJournal(root); err != nil {
+		if !errors.Is(err, errMissingJournal) {
+			log.Info("Failed to load trie journal, discarding it", "err", err)
+		}
+		db.disk = newDiskLayer(root, db, newNodeBuffer(db.config.DirtyCacheSize, nil, 0))
+		db.layers = map[common.Hash]layer{root: db.disk}
+	}
+}
+
+// loadJournal reconstructs the disk layer and the diff layers on top of it from
+// the journal, checking that it was written on top of the persisted state.
+func (db *Database) loadJournal(diskRoot common.Hash) error {
+	journal := rawdb.ReadTrieJournal(db.diskdb)
+	if len(journal) == 0 {
+		return errMissingJournal
+	}
+	r := rlp.NewStream(bytes.NewReader(journal), 0)
+
+	var version uint64
+	if err := r.Decode(&version); err != nil {
+		return fmt.Errorf("load journal version: %v", err)
+	}
+	if version != journalVersion {
+		return fmt.Errorf("unsupported journal version %d", version)
+	}
+	var root common.Hash
+	if err := r.Decode(&root); err != nil {
+		return fmt.Errorf("load persisted root: %v", err)
+	}
+	if root != diskRoot {
+		return fmt.Errorf("%w, disk %#x, journal %#x", errUnmatchedJournal, diskRoot, root)
+	}
+	// Load the disk layer along with its write buffer
+	if err := r.Decode(&root); err != nil {
+		return fmt.Errorf("load disk root: %v", err)
+	}
+	var entries []journalNodes
+	if err := r.Decode(&entries); err != nil {
+		return fmt.Errorf("load disk nodes: %v", err)
+	}
+	buffer := newNodeBuffer(db.config.DirtyCacheSize, nil, 0)
+	buffer.commit(decodeNodes(entries))
+
+	disk := newDiskLayer(root, db, buffer)
+	layers := map[common.Hash]layer{root: disk}
+
+	// Load the diff layers on top, parents first
+	var parent layer = disk
+	for {
+		if err := r.Decode(&root); err != nil {
+			// The read may fail with EOF, marking the end of the journal
+			if err == io.EOF {
+				break
+			}
+			return fmt.Errorf("load diff root: %v", err)
+		}
+		if err := r.Decode(&entries); err != nil {
+			return fmt.Errorf("load diff nodes: %v", err)
+		}
+		parent = newDiffLayer(parent, root, decodeNodes(entries))
+		layers[root] = parent
+	}
+	db.disk, db.layers = disk, layers
+
+	log.Debug("Loaded trie journal", "diskroot", diskRoot, "head", parent.rootHash(), "layers", len(layers))
+	return nil
+}
+
+// Journal writes the layers from the given state down to the disk layer, along
+// with the disk layer's write buffer, into the journal, so they survive a node
+// restart. Layers not on that path are dropped.
+func (db *Database) Journal(root common.Hash) error {
+	db.lock.RLock()
+	defer db.lock.RUnlock()
+
+	l := db.layers[root]
+	if l == nil {
+		return fmt.Errorf("triedb layer [%#x] missing", root)
+	}
+	start := time.Now()
+
+	journal := new(bytes.Buffer)
+	if err := rlp.Encode(journal, journalVersion); err != nil {
+		return err
+	}
+	if err := rlp.Encode(journal, persistedRoot(db.diskdb)); err != nil {
+		return err
+	}
+	if err := journalLayer(journal, l); err != nil {
+		return err
+	}
+	rawdb.WriteTrieJournal(db.diskdb, journal.Bytes())
+
+	log.Info("Persisted dirty state to disk", "root", root, "size", common.StorageSize(journal.Len()), "elapsed", common.PrettyDuration(time.Since(start)))
+	return nil
+}
+
+// journalLayer writes the given layer into the journal, after its parents.
+func journalLayer(w io.Writer, l layer) error {
+	var nodes map[common.Hash]map[string]*Node
+	switch l := l.(type) {
+	case *diskLayer:
+		nodes = l.buffer.nodes
+	case *diffLayer:
+		if err := journalLayer(w, l.parent); err != nil {
+			return err
+		}
+		nodes = l.nodes
+	}
+	if err := rlp.Encode(w, l.rootHash()); err != nil {
+		return err
+	}
+	return rlp.Encode(w, encodeNodes(nodes))
+}
@@ -0,0 +1,35 @@
+// Copyright 2022 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package pathdb
+
+import "github.com/electroneum/electroneum-sc/metrics"
+
+var (
+	cleanHitMeter   = metrics.NewRegisteredMeter("pathdb/clean/hit", nil)
+	cleanMissMeter  = metrics.NewRegisteredMeter("pathdb/clean/miss", nil)
+	cleanReadMeter  = metrics.NewRegisteredMeter("pathdb/clean/read", nil)
+	cleanWriteMeter = metrics.NewRegisteredMeter("pathdb/clean/write", nil)
+
+	dirtyHitMeter   = metrics.NewRegisteredMeter("pathdb/dirty/hit", nil)
+	dirtyMissMeter  = metrics.NewRegisteredMeter("pathdb/dirty/miss", nil)
+	dirtyReadMeter  = metrics.NewRegisteredMeter("pathdb/dirty/read", nil)
+	dirtyWriteMeter = metrics.NewRegisteredMeter("pathdb/dirty/write", nil)
+
+	flushTimeTimer  = metrics.NewRegisteredResettingTimer("pathdb/flush/time", nil)
+	flushNodesMeter = metrics.NewRegisteredMeter("pathdb/flush/nodes", nil)
+	flushSizeMeter  = metrics.NewRegisteredMeter("pathdb/flush/size", nil)
+)
@@ -35,7 +35,8 @@ import (
 // with the node that proves the absence of the key.
 func (t *Trie) Prove(key []byte, fromLevel uint, proofDb ethdb.KeyValueWriter) error {
 	// Collect all nodes on the path to key.
-	key = keybytesToHex(key)
+	hexKey := keybytesToHex(key)
+	key = hexKey
 	var nodes []node
 	tn := t.root
 	for len(key) > 0 && tn != nil {
@@ -55,7 +56,7 @@ func (t *Trie) Prove(key []byte, fromLevel uint, proofDb ethdb.KeyValueWriter) e
 			nodes = append(nodes, n)
 		case hashNode:
 			var err error
-			tn, err = t.resolveHash(n, nil)
+			tn, err = t.resolveHash(n, hexKey[:len(hexKey)-len(key)])
 			if err != nil {
 				log.Error(fmt.Sprintf("Unhandled trie error: %v", err))
 				return err
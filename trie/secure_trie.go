@@ -54,10 +54,17 @@ type SecureTrie struct {
 // A new cache generation is created by each call to Commit.
 // cachelimit sets the number of past cache generations to keep.
 func NewSecure(root common.Hash, db *Database) (*SecureTrie, error) {
+	return NewSecureWithOwner(root, common.Hash{}, root, db)
+}
+
+// NewSecureWithOwner creates a secure trie identified by the given owner, the
+// hash of the account for storage tries, within the state with the given root.
+// See NewWithOwner.
+func NewSecureWithOwner(stateRoot common.Hash, owner common.Hash, root common.Hash, db *Database) (*SecureTrie, error) {
 	if db == nil {
 		panic("trie.NewSecure called without a database")
 	}
-	trie, err := New(root, db)
+	trie, err := NewWithOwner(stateRoot, owner, root, db)
 	if err != nil {
 		return nil, err
 	}
@@ -162,6 +169,21 @@ func (t *SecureTrie) GetKey(shaKey []byte) []byte {
 // Committing flushes nodes from memory. Subsequent Get calls will load nodes
 // from the database.
 func (t *SecureTrie) Commit(onleaf LeafCallback) (common.Hash, int, error) {
+	t.commitPreimages()
+
+	// Commit the trie to its intermediate node database
+	return t.trie.Commit(onleaf)
+}
+
+// CommitNodes collapses the trie and returns the modified nodes by path, see
+// Trie.CommitNodes. The preimages of the keys are written to the database.
+func (t *SecureTrie) CommitNodes() (common.Hash, *NodeSet, error) {
+	t.commitPreimages()
+	return t.trie.CommitNodes()
+}
+
+// commitPreimages moves the preimages of the accessed keys into the database.
+func (t *SecureTrie) commitPreimages() {
 	// Write all the pre-images to the actual disk database
 	if len(t.getSecKeyCache()) > 0 {
 		if t.trie.db.preimages != nil { // Ugly direct check but avoids the below write lock
@@ -173,8 +195,6 @@ func (t *SecureTrie) Commit(onleaf LeafCallback) (common.Hash, int, error) {
 		}
 		t.secKeyCache = make(map[string][]byte)
 	}
-	// Commit the trie to its intermediate node database
-	return t.trie.Commit(onleaf)
 }
 
 // Hash returns the root hash of SecureTrie. It does not write to the
@@ -29,6 +29,7 @@ import (
 	"github.com/electroneum/electroneum-sc/crypto"
 	"github.com/electroneum/electroneum-sc/log"
 	"github.com/electroneum/electroneum-sc/rlp"
+	"github.com/electroneum/electroneum-sc/trie/pathdb"
 )
 
 var (
@@ -61,8 +62,10 @@ type LeafCallback func(paths [][]byte, hexpath []byte, leaf []byte, parent commo
 //
 // Trie is not safe for concurrent use.
 type Trie struct {
-	db   *Database
-	root node
+	db     *Database
+	root   node
+	owner  common.Hash    // Owner of the trie, the account hash for storage tries
+	reader *pathdb.Reader // Node reader of the state the trie belongs to, path scheme only
 
 	// Keep track of the number leaves which have been inserted since the last
 	// hashing operation. This number will not directly map to the number of
@@ -84,6 +87,8 @@ func (t *Trie) Copy() *Trie {
 	return &Trie{
 		db:       t.db,
 		root:     t.root,
+		owner:    t.owner,
+		reader:   t.reader,
 		unhashed: t.unhashed,
 		tracer:   t.tracer.copy(),
 	}
@@ -96,14 +101,37 @@ func (t *Trie) Copy() *Trie {
 // New will panic if db is nil and returns a MissingNodeError if root does
 // not exist in the database. Accessing the trie loads nodes from db on demand.
 func New(root common.Hash, db *Database) (*Trie, error) {
+	return NewWithOwner(root, common.Hash{}, root, db)
+}
+
+// NewWithOwner creates a trie with an existing root node from db, identified
+// by the given owner. The owner is the hash of the account a storage trie
+// belongs to, and the zero hash for the account trie. The state root is the
+// root of the account trie the trie is part of. Both are only relevant for the
+// path scheme, where the nodes of different tries are stored apart and nodes
+// are retrieved from the layers of the state they belong to.
+func NewWithOwner(stateRoot common.Hash, owner common.Hash, root common.Hash, db *Database) (*Trie, error) {
 	if db == nil {
 		panic("trie.New called without a database")
 	}
 	trie := &Trie{
-		db: db,
-		//tracer: newTracer(),
+		db:    db,
+		owner: owner,
+	}
+	empty := root == (common.Hash{}) || root == emptyRoot
+
+	// The path scheme needs to know the stored nodes removed from the trie. An
+	// empty trie has nothing to load, it doesn't need the state to be available.
+	if db.pathdb != nil {
+		trie.tracer = newTracer()
+
+		reader, err := db.pathdb.Reader(stateRoot)
+		if err != nil && !empty {
+			return nil, err
+		}
+		trie.reader = reader
 	}
-	if root != (common.Hash{}) && root != emptyRoot {
+	if !empty {
 		rootnode, err := trie.resolveHash(root[:], nil)
 		if err != nil {
 			return nil, err
@@ -221,7 +249,7 @@ func (t *Trie) tryGetNode(origNode node, path []byte, pos int) (item []byte, new
 		if hash == nil {
 			return nil, origNode, 0, errors.New("non-consensus node")
 		}
-		blob, err := t.db.Node(common.BytesToHash(hash))
+		blob, err := t.resolveBlob(hash, path[:pos])
 		return blob, origNode, 1, err
 	}
 	// Path still needs to be traversed, descend into children
@@ -497,7 +525,7 @@ func (t *Trie) delete(n node, prefix, key []byte) (bool, node, error) {
 				// shortNode{..., shortNode{...}}.  Since the entry
 				// might not be loaded yet, resolve it just for this
 				// check.
-				cnode, err := t.resolve(n.Children[pos], prefix)
+				cnode, err := t.resolve(n.Children[pos], append(prefix, byte(pos)))
 				if err != nil {
 					return false, nil, err
 				}
@@ -559,19 +587,47 @@ func (t *Trie) resolve(n node, prefix []byte) (node, error) {
 
 func (t *Trie) resolveHash(n hashNode, prefix []byte) (node, error) {
 	hash := common.BytesToHash(n)
+	if t.db.pathdb != nil {
+		blob, err := t.readNode(prefix, hash)
+		if err != nil {
+			return nil, err
+		}
+		t.tracer.onRead(prefix)
+		return mustDecodeNode(n, blob), nil
+	}
 	if node := t.db.node(hash); node != nil {
 		return node, nil
 	}
-	return nil, &MissingNodeError{NodeHash: hash, Path: prefix}
+	return nil, &MissingNodeError{Owner: t.owner, NodeHash: hash, Path: prefix}
 }
 
 func (t *Trie) resolveBlob(n hashNode, prefix []byte) ([]byte, error) {
 	hash := common.BytesToHash(n)
+	if t.db.pathdb != nil {
+		return t.readNode(prefix, hash)
+	}
 	blob, _ := t.db.Node(hash)
 	if len(blob) != 0 {
 		return blob, nil
 	}
-	return nil, &MissingNodeError{NodeHash: hash, Path: prefix}
+	return nil, &MissingNodeError{Owner: t.owner, NodeHash: hash, Path: prefix}
+}
+
+// readNode retrieves a node from the layers of the state the trie belongs to,
+// in the path scheme. If the state is no longer available, the error says so
+// rather than reporting a missing node.
+func (t *Trie) readNode(prefix []byte, hash common.Hash) ([]byte, error) {
+	if t.reader == nil {
+		return nil, pathdb.ErrStateUnavailable
+	}
+	blob, err := t.reader.Node(t.owner, prefix, hash)
+	if errors.Is(err, pathdb.ErrStateUnavailable) {
+		return nil, err
+	}
+	if err != nil {
+		return nil, &MissingNodeError{Owner: t.owner, NodeHash: hash, Path: prefix}
+	}
+	return blob, nil
 }
 
 // Hash returns the root hash of the trie. It does not write to the
@@ -584,10 +640,17 @@ func (t *Trie) Hash() common.Hash {
 
 // Commit writes all nodes to the trie's memory database, tracking the internal
 // and external (for account tries) references.
+//
+// Tries backed by the path scheme can't be committed on their own, they must
+// be committed with CommitNodes and the nodes passed on to the database along
+// with the state they belong to.
 func (t *Trie) Commit(onleaf LeafCallback) (common.Hash, int, error) {
 	if t.db == nil {
 		panic("commit called on trie with nil database")
 	}
+	if t.db.pathdb != nil {
+		return common.Hash{}, 0, errors.New("path scheme tries must be committed with CommitNodes")
+	}
 	defer t.tracer.reset()
 
 	if t.root == nil {
@@ -631,6 +694,86 @@ func (t *Trie) Commit(onleaf LeafCallback) (common.Hash, int, error) {
 	return rootHash, committed, nil
 }
 
+// CommitNodes collapses all dirty nodes like Commit, but instead of inserting
+// them into the database, it returns them in a node set keyed by path, along
+// with deletion markers for the stored nodes which are no longer part of the
+// trie. It's used by the path scheme, where the nodes of all the tries modified
+// by a state transition are inserted into the database at once.
+func (t *Trie) CommitNodes() (common.Hash, *NodeSet, error) {
+	if t.db == nil {
+		panic("commit called on trie with nil database")
+	}
+	nodes := NewNodeSet(t.owner)
+	if t.root == nil {
+		for _, path := range t.tracer.accessList() {
+			nodes.MarkDeleted(path)
+		}
+		t.tracer.reset()
+		return emptyRoot, nodes, nil
+	}
+	// Derive the hash for all dirty nodes first. We hold the assumption
+	// in the following procedure that all nodes are hashed.
+	rootHash := t.Hash()
+
+	// Do a quick check if we really need to commit. An unmodified trie can't
+	// have dropped any of its stored nodes either.
+	if _, dirty := t.root.cache(); !dirty {
+		return rootHash, nodes, nil
+	}
+	defer t.tracer.reset()
+
+	// Mark the stored nodes loaded from the database as deleted if the trie
+	// doesn't hold a standalone node at their paths anymore. Committed nodes
+	// take precedence over the markers.
+	for _, path := range t.tracer.accessList() {
+		if !t.hasStoredNode(path) {
+			nodes.MarkDeleted(path)
+		}
+	}
+	h := newCommitter()
+	defer returnCommitterToPool(h)
+
+	h.nodes = nodes
+	newRoot, _, err := h.Commit(t.root, t.db)
+	if err != nil {
+		return common.Hash{}, nil, err
+	}
+	t.root = newRoot
+	return rootHash, nodes, nil
+}
+
+// hasStoredNode reports whether the hashed trie holds a node at the given path
+// that is stored on its own rather than embedded in its parent. Unresolved
+// parts of the trie are unmodified, so the nodes within them are reported as
+// stored.
+func (t *Trie) hasStoredNode(path []byte) bool {
+	n := t.root
+	for {
+		switch nn := n.(type) {
+		case *shortNode:
+			if len(path) == 0 {
+				hash, _ := nn.cache()
+				return hash != nil
+			}
+			if !bytes.HasPrefix(path, nn.Key) {
+				return false
+			}
+			n, path = nn.Val, path[len(nn.Key):]
+		case *fullNode:
+			if len(path) == 0 {
+				hash, _ := nn.cache()
+				return hash != nil
+			}
+			n, path = nn.Children[path[0]], path[1:]
+		case hashNode:
+			return true
+		default:
+			// The path ends in a value, or leads nowhere
+			return false
+		}
+	}
+}
+
 // hashRoot calculates the root hash of the given trie
 func (t *Trie) hashRoot() (node, node, error) {
 	if t.root == nil {
@@ -648,6 +648,89 @@ func benchmarkCommitAfterHash(b *testing.B, onleaf LeafCallback) {
 	trie.Commit(onleaf)
 }
 
+// Tests that committing tries backed by the path scheme stores exactly the nodes
+// of the latest state on disk, deleting the nodes which are no longer part of it.
+func TestPathSchemeCommit(t *testing.T) {
+	diskdb := rawdb.NewMemoryDatabase()
+	triedb := NewDatabaseWithConfig(diskdb, &Config{Scheme: rawdb.PathScheme})
+
+	// commit writes the trie changes into a new state and checks the nodes on
+	// disk match the ones of the trie.
+	commit := func(trie *Trie, parent common.Hash) common.Hash {
+		t.Helper()
+
+		root, nodes, err := trie.CommitNodes()
+		if err != nil {
+			t.Fatalf("failed to commit trie: %v", err)
+		}
+		set := NewMergedNodeSet()
+		set.Merge(nodes)
+		if err := triedb.Update(root, parent, set); err != nil {
+			t.Fatalf("failed to update database: %v", err)
+		}
+		if err := triedb.Commit(root, false, nil); err != nil {
+			t.Fatalf("failed to commit database: %v", err)
+		}
+		var want int
+		if root != emptyRoot {
+			trie, _ := New(root, triedb)
+			for it := trie.NodeIterator(nil); it.Next(true); {
+				if it.Hash() != (common.Hash{}) {
+					want++
+				}
+			}
+		}
+		var have int
+		it := diskdb.NewIterator(rawdb.TrieNodeAccountPrefix, nil)
+		for it.Next() {
+			if rawdb.IsAccountTrieNode(it.Key()) {
+				have++
+			}
+		}
+		it.Release()
+		if have != want {
+			t.Fatalf("stored node count mismatch: have %d, want %d", have, want)
+		}
+		return root
+	}
+	trie, _ := New(common.Hash{}, triedb)
+	for i := 0; i < 500; i++ {
+		key, val := randBytes(32), randBytes(20)
+		trie.Update(key, val)
+	}
+	root := commit(trie, emptyRoot)
+
+	// Delete half of the entries from the reopened trie
+	trie, _ = New(root, triedb)
+	var (
+		it   = NewIterator(trie.NodeIterator(nil))
+		keys [][]byte
+	)
+	for it.Next() {
+		keys = append(keys, common.CopyBytes(it.Key))
+	}
+	for i, key := range keys {
+		if i%2 == 0 {
+			trie.Delete(key)
+		}
+	}
+	root = commit(trie, root)
+
+	trie, _ = New(root, triedb)
+	for i, key := range keys {
+		if val := trie.Get(key); (i%2 == 0) != (val == nil) {
+			t.Fatalf("key %x: value mismatch: %x", key, val)
+		}
+	}
+	// Delete everything, nothing should be left on disk
+	for i, key := range keys {
+		if i%2 != 0 {
+			trie.Delete(key)
+		}
+	}
+	commit(trie, root)
+}
+
 func TestTinyTrie(t *testing.T) {
 	// Create a realistic account trie to hash
 	_, accounts := makeAccounts(5)
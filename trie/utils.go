@@ -29,11 +29,15 @@ package trie
 // This tool can track all of them no matter the node is embedded in its
 // parent or not, but valueNode is never tracked.
 //
+// Besides, the tracer records the paths of the nodes loaded from the database,
+// so that only nodes actually stored are reported as deleted.
+//
 // Note tracer is not thread-safe, callers should be responsible for handling
 // the concurrency issues by themselves.
 type tracer struct {
 	insert map[string]struct{}
 	delete map[string]struct{}
+	access map[string]struct{}
 }
 
 // newTracer initializes trie node diff tracer.
@@ -41,9 +45,19 @@ func newTracer() *tracer {
 	return &tracer{
 		insert: make(map[string]struct{}),
 		delete: make(map[string]struct{}),
+		access: make(map[string]struct{}),
 	}
 }
 
+// onRead tracks the trie node loaded from the database.
+func (t *tracer) onRead(key []byte) {
+	// Tracer isn't used right now, remove this check later.
+	if t == nil {
+		return
+	}
+	t.access[string(key)] = struct{}{}
+}
+
 // onInsert tracks the newly inserted trie node. If it's already
 // in the deletion set(resurrected node), then just wipe it from
 // the deletion set as it's untouched.
@@ -100,6 +114,19 @@ func (t *tracer) deleteList() [][]byte {
 	return ret
 }
 
+// accessList returns the paths of the trie nodes loaded from the database.
+func (t *tracer) accessList() [][]byte {
+	// Tracer isn't used right now, remove this check later.
+	if t == nil {
+		return nil
+	}
+	var ret [][]byte
+	for key := range t.access {
+		ret = append(ret, []byte(key))
+	}
+	return ret
+}
+
 // reset clears the content tracked by tracer.
 func (t *tracer) reset() {
 	// Tracer isn't used right now, remove this check later.
@@ -108,6 +135,7 @@ func (t *tracer) reset() {
 	}
 	t.insert = make(map[string]struct{})
 	t.delete = make(map[string]struct{})
+	t.access = make(map[string]struct{})
 }
 
 // copy returns a deep copied tracer instance.
@@ -119,6 +147,7 @@ func (t *tracer) copy() *tracer {
 	var (
 		insert = make(map[string]struct{})
 		delete = make(map[string]struct{})
+		access = make(map[string]struct{})
 	)
 	for key := range t.insert {
 		insert[key] = struct{}{}
@@ -126,8 +155,12 @@ func (t *tracer) copy() *tracer {
 	for key := range t.delete {
 		delete[key] = struct{}{}
 	}
+	for key := range t.access {
+		access[key] = struct{}{}
+	}
 	return &tracer{
 		insert: insert,
 		delete: delete,
+		access: access,
 	}
 }